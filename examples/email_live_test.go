@@ -7,9 +7,12 @@ import (
 	"fmt"
 	"net/http"
 	"os"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/infobip-community/infobip-api-go-sdk/v3/pkg/infobip"
+	"github.com/infobip-community/infobip-api-go-sdk/v3/pkg/infobip/email"
 	"github.com/infobip-community/infobip-api-go-sdk/v3/pkg/infobip/models"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -76,6 +79,57 @@ func TestSendEmail(t *testing.T) {
 	assert.Equal(t, http.StatusOK, respDetails.HTTPResponse.StatusCode)
 }
 
+func TestSendEmailAttachmentSources(t *testing.T) {
+	client, err := infobip.NewClient(baseURL, apiKey)
+	require.Nil(t, err)
+
+	mail := models.EmailMsg{
+		From:    "somemail@somedomain.com",
+		To:      "somemail@somedomain.com",
+		Subject: "Your invoice",
+		Text:    "Please find your invoice attached.",
+		AttachmentSources: []models.Attachment{
+			models.NewBytesAttachment("invoice.txt", "text/plain", []byte("Invoice #42")),
+			models.NewFSAttachment(os.DirFS("../pkg/infobip/email/testdata"), "image.png"),
+		},
+	}
+
+	msgResp, respDetails, err := client.Email.Send(context.Background(), mail)
+
+	fmt.Println(msgResp)
+	fmt.Println(respDetails)
+
+	require.Nil(t, err)
+	assert.Equal(t, http.StatusOK, respDetails.HTTPResponse.StatusCode)
+}
+
+func TestSendComposedEmail(t *testing.T) {
+	client, err := infobip.NewClient(baseURL, apiKey)
+	require.Nil(t, err)
+
+	composer := email.Composer{
+		From: "somemail@somedomain.com",
+		Recipients: []email.Recipient{
+			{Address: "jane.doe@somedomain.com", Placeholders: map[string]string{"name": "Jane"}},
+			{Address: "john.doe@somedomain.com"},
+		},
+		Subject:             "Order {{.Order}} shipped",
+		HTML:                `<p>Hi {{placeholder "name"}},</p><p>Order {{.Order}} is on its way.</p>`,
+		Data:                map[string]string{"Order": "#42"},
+		DefaultPlaceholders: map[string]string{"name": "there"},
+	}
+	mail, err := composer.Compose()
+	require.NoError(t, err)
+
+	msgResp, respDetails, err := client.Email.Send(context.Background(), mail)
+
+	fmt.Println(msgResp)
+	fmt.Println(respDetails)
+
+	require.Nil(t, err)
+	assert.Equal(t, http.StatusOK, respDetails.HTTPResponse.StatusCode)
+}
+
 func TestSendEmailBulk(t *testing.T) {
 	client, err := infobip.NewClient(baseURL, apiKey)
 	require.Nil(t, err)
@@ -365,3 +419,140 @@ func TestVerifyDomain(t *testing.T) {
 	assert.NotEqual(t, models.ResponseDetails{}, respDetails)
 	assert.Equal(t, http.StatusAccepted, respDetails.HTTPResponse.StatusCode)
 }
+
+func TestCheckAndVerifyDomain(t *testing.T) {
+	client, err := infobip.NewClient(baseURL, apiKey)
+	require.Nil(t, err)
+
+	verifier := email.NewDomainVerifier(client.Email, nil)
+	report, err := verifier.Verify(context.Background(), "test-domain.com")
+
+	fmt.Println(report)
+	fmt.Println(report.ZoneFile())
+
+	require.Nil(t, err)
+	assert.NotEmpty(t, report.Records)
+	assert.Equal(t, report.Matched(), report.VerificationRequested)
+}
+
+func TestEmailSuppressions(t *testing.T) {
+	client, err := infobip.NewClient(baseURL, apiKey)
+	require.Nil(t, err)
+
+	req := models.EmailSuppressionsRequest{Suppressions: []models.EmailSuppressionsEntry{
+		{DomainName: "test-domain.com", EmailAddress: []string{"someone@test-domain.com"}, Type: "BOUNCE"},
+	}}
+	suppressions := client.Email.(email.Suppressions)
+	respDetails, err := suppressions.AddSuppressions(context.Background(), req)
+	require.Nil(t, err)
+	assert.Equal(t, http.StatusNoContent, respDetails.HTTPResponse.StatusCode)
+
+	resp, respDetails, err := suppressions.GetSuppressions(context.Background(), models.GetEmailSuppressionsParams{
+		DomainName: "test-domain.com",
+		Type:       "BOUNCE",
+	})
+	fmt.Println(resp)
+	require.Nil(t, err)
+	assert.Equal(t, http.StatusOK, respDetails.HTTPResponse.StatusCode)
+
+	filter := email.NewSuppressionFilter(client.Email, time.Minute)
+	msg, removed, err := filter.Filter(context.Background(), models.EmailMsg{
+		From:         "someone@test-domain.com",
+		To:           "someone@test-domain.com",
+		AdditionalTo: []string{"someone.else@test-domain.com"},
+		Subject:      "Some subject",
+	})
+	fmt.Println(msg, removed)
+	require.Nil(t, err)
+	assert.Equal(t, "someone.else@test-domain.com", msg.To)
+
+	respDetails, err = suppressions.DeleteSuppressions(context.Background(), req)
+	require.Nil(t, err)
+	assert.Equal(t, http.StatusNoContent, respDetails.HTTPResponse.StatusCode)
+}
+
+func TestEmailIPPools(t *testing.T) {
+	client, err := infobip.NewClient(baseURL, apiKey)
+	require.Nil(t, err)
+	ctx := context.Background()
+
+	ipManagement := client.Email.(email.IPManagement)
+	ips, respDetails, err := ipManagement.GetIPs(ctx)
+	fmt.Println(ips)
+	require.Nil(t, err)
+	assert.Equal(t, http.StatusOK, respDetails.HTTPResponse.StatusCode)
+
+	pool, respDetails, err := ipManagement.CreateIPPool(ctx, models.CreateEmailIPPoolRequest{Name: "warmup"})
+	fmt.Println(pool)
+	require.Nil(t, err)
+	assert.Equal(t, http.StatusOK, respDetails.HTTPResponse.StatusCode)
+
+	domain, pools, err := email.DomainIPPools(ctx, client.Email, "test-domain.com")
+	fmt.Println(domain, pools)
+	require.Nil(t, err)
+
+	respDetails, err = ipManagement.AssignIPPoolToDomain(ctx, domain.DomainID, models.AssignEmailIPPoolRequest{
+		PoolID:   pool.ID,
+		Priority: len(pools.Pools),
+	})
+	require.Nil(t, err)
+	assert.Equal(t, http.StatusNoContent, respDetails.HTTPResponse.StatusCode)
+
+	respDetails, err = ipManagement.DeleteIPPool(ctx, pool.ID)
+	require.Nil(t, err)
+	assert.Equal(t, http.StatusNoContent, respDetails.HTTPResponse.StatusCode)
+}
+
+func TestBulkValidateAddresses(t *testing.T) {
+	client, err := infobip.NewClient(baseURL, apiKey)
+	require.Nil(t, err)
+
+	csvFile := strings.NewReader("name,email\nJohn,john@example.com\nInfo,info@example.com\n")
+	addresses, err := email.NewCSVAddresses(csvFile, "email")
+	require.Nil(t, err)
+
+	validator := email.NewBulkValidator(client.Email, email.BulkValidatorConfig{
+		Workers:       4,
+		RatePerSecond: 10,
+		CacheTTL:      24 * time.Hour,
+	})
+	results := make(chan email.ValidationResult)
+	go func() {
+		for result := range results {
+			fmt.Println(result.Index, result.Address, result.Type(), result.Err)
+		}
+	}()
+
+	err = validator.Validate(context.Background(), addresses, results)
+	require.Nil(t, err)
+}
+
+func TestEmailTemplates(t *testing.T) {
+	client, err := infobip.NewClient(baseURL, apiKey)
+	require.Nil(t, err)
+	ctx := context.Background()
+
+	templates := client.Email.(email.Templates)
+	template, respDetails, err := templates.CreateTemplate(ctx, models.CreateEmailTemplateRequest{
+		Name:         "Order shipped",
+		From:         "somemail@somedomain.com",
+		Subject:      "Order {{order}} shipped",
+		HTML:         "<p>Hi {{name}}, your order is on its way.</p>",
+		Placeholders: []string{"name", "order"},
+	})
+	fmt.Println(template)
+	require.Nil(t, err)
+	assert.Equal(t, http.StatusOK, respDetails.HTTPResponse.StatusCode)
+
+	msg := models.EmailMsg{
+		From:       "somemail@somedomain.com",
+		To:         `{"to":"john@example.com","placeholders":{"name":"John","order":"#42"}}`,
+		Subject:    template.Subject,
+		TemplateID: int(template.ID),
+	}
+	require.Nil(t, email.CheckMsgTemplate(ctx, client.Email, msg))
+
+	respDetails, err = templates.DeleteTemplate(ctx, template.ID)
+	require.Nil(t, err)
+	assert.Equal(t, http.StatusNoContent, respDetails.HTTPResponse.StatusCode)
+}
@@ -0,0 +1,42 @@
+package examples
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/infobip-community/infobip-api-go-sdk/v3/pkg/infobip"
+	"github.com/infobip-community/infobip-api-go-sdk/v3/pkg/infobip/failover"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFailoverExample(t *testing.T) {
+	client, err := infobip.NewClient(baseURL, apiKey)
+	require.NoError(t, err)
+	failoverSender := failover.NewSender(failover.Config{
+		Policy: failover.Policy{
+			{Channel: failover.WhatsApp, From: sender, Timeout: 2 * time.Minute},
+			{Channel: failover.SMS, From: "InfoSMS", Timeout: 5 * time.Minute},
+			{Channel: failover.Email, From: "Jane Doe <some@selfserviceib.com>"},
+		},
+		Transports: failover.ClientTransports(client),
+		Pollers:    failover.ClientPollers(client),
+	})
+	// WhatsApp reports are only received with webhooks: serve failoverSender.WebhookHandler() at the NotifyURL.
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Minute)
+	defer cancel()
+	go func() { _ = failoverSender.Run(ctx) }()
+
+	result, err := failoverSender.Send(ctx, failover.Message{
+		PhoneNumber:  destNumber,
+		EmailAddress: "john.doe@example.com",
+		Subject:      "Your order shipped",
+		Text:         "Your order is on its way.",
+		NotifyURL:    "https://example.com/reports",
+	})
+	for _, attempt := range result.Attempts {
+		fmt.Printf("%s %s %s\n", attempt.Channel, attempt.MessageID, attempt.Status)
+	}
+	require.NoError(t, err)
+}
@@ -0,0 +1,36 @@
+package examples
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/infobip-community/infobip-api-go-sdk/v3/pkg/infobip"
+	"github.com/infobip-community/infobip-api-go-sdk/v3/pkg/infobip/messaging"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMessagesSendExample(t *testing.T) {
+	client, err := infobip.NewClient(baseURL, apiKey)
+	require.NoError(t, err)
+	msg := messaging.Message{
+		From:     sender,
+		To:       destNumber,
+		Text:     "Your order is on its way.",
+		Media:    &messaging.Media{URL: "https://www.infobip.com/img/logo.png"},
+		Metadata: map[string]string{"order": "42"},
+	}
+
+	for _, channel := range []messaging.Channel{messaging.WhatsApp, messaging.MMS, messaging.SMS} {
+		result, respDetails, err := client.Messages.Send(context.Background(), channel, msg)
+		var featureErr *messaging.UnsupportedFeatureError
+		if errors.As(err, &featureErr) {
+			fmt.Printf("%s: unsupported %s\n", channel, featureErr.Feature)
+			continue
+		}
+		require.NoError(t, err)
+		fmt.Printf("%s: %+v\n", channel, result)
+		fmt.Printf("%+v\n", respDetails)
+	}
+}
@@ -4,8 +4,11 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/infobip-community/infobip-api-go-sdk/v3/pkg/infobip"
+	"github.com/infobip-community/infobip-api-go-sdk/v3/pkg/infobip/media"
+	"github.com/infobip-community/infobip-api-go-sdk/v3/pkg/infobip/mms"
 	"github.com/infobip-community/infobip-api-go-sdk/v3/pkg/infobip/models"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -65,3 +68,65 @@ func TestGetInboundMMSExample(t *testing.T) {
 	require.NoError(t, err)
 	assert.NotEqual(t, models.ResponseDetails{}, respDetails)
 }
+
+func TestSendComposedMMSExample(t *testing.T) {
+	apiKey := "secret"
+	baseURL := "https://myinfobipurl.com"
+	client, err := infobip.NewClient(baseURL, apiKey)
+	require.NoError(t, err)
+	photo := models.NewFileAttachment("../pkg/infobip/email/testdata/image.png")
+	composer := mms.Composer{
+		Head: models.MMSHead{
+			From: "111111111111",
+			To:   "222222222222",
+		},
+		Slides: []mms.Slide{
+			{Visual: &photo, Text: "Your parcel is on its way", Duration: 8 * time.Second},
+			{Text: "Track it at https://example.com/track"},
+		},
+		Profile: mms.CarrierProfile600KB,
+	}
+	message, err := composer.Compose()
+	require.NoError(t, err)
+
+	msgResp, respDetails, err := client.MMS.Send(context.Background(), message)
+	fmt.Printf("%+v\n", msgResp)
+
+	require.NoError(t, err)
+	assert.NotEqual(t, models.ResponseDetails{}, respDetails)
+	assert.NotEqual(t, models.SendMMSResponse{}, msgResp)
+}
+
+func TestSendMMSWithMediaPipelineExample(t *testing.T) {
+	apiKey := "secret"
+	baseURL := "https://myinfobipurl.com"
+	pipeline := media.NewPipeline(
+		&media.ImageProcessor{ConvertPNG: true},
+		media.NewTranscoderProcessor("ffmpeg", &media.CommandTranscoder{
+			Path:        "ffmpeg",
+			Args:        []string{"-i", "pipe:0", "-fs", "{budget}", "-f", "3gp", "pipe:1"},
+			ContentType: "video/3gpp",
+		}, "video/"),
+	)
+	client, err := infobip.NewClient(baseURL, apiKey, infobip.WithMediaPipeline(pipeline))
+	require.NoError(t, err)
+	message := models.MMSMsg{
+		Head: models.MMSHead{
+			From: "111111111111",
+			To:   "222222222222",
+		},
+		MediaParts: []models.MMSMediaPart{
+			{ContentID: "photo.png", Media: models.NewFileAttachment("../pkg/infobip/email/testdata/image.png")},
+		},
+	}
+
+	msgResp, respDetails, err := client.MMS.Send(context.Background(), message)
+	fmt.Printf("%+v\n", msgResp)
+	for _, transformation := range msgResp.MediaTransformations {
+		fmt.Printf("%s: %d -> %d bytes\n", transformation.Name, transformation.OriginalSize, transformation.Size)
+	}
+
+	require.NoError(t, err)
+	assert.NotEqual(t, models.ResponseDetails{}, respDetails)
+	assert.NotEqual(t, models.SendMMSResponse{}, msgResp)
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/infobip-community/infobip-api-go-sdk/v3/pkg/infobip"
 	"github.com/infobip-community/infobip-api-go-sdk/v3/pkg/infobip/models"
+	"github.com/infobip-community/infobip-api-go-sdk/v3/pkg/infobip/sms"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -572,3 +573,131 @@ func TestGetTFAVerificationStatus(t *testing.T) {
 	assert.NotEqual(t, models.ResponseDetails{}, resp)
 	assert.Equal(t, http.StatusOK, respDetails.HTTPResponse.StatusCode)
 }
+
+func TestCreateTFAEmailMessageTemplate(t *testing.T) {
+	client, err := infobip.NewClientFromEnv()
+	require.Nil(t, err)
+
+	appID := "43D78365E3257420D78752A62845A8CB"
+	req := models.CreateTFAEmailMessageTemplateRequest{
+		From:            "Infobip Gopher <gopher@example.com>",
+		EmailTemplateID: 1234,
+		PINLength:       4,
+		PINType:         models.NUMERIC,
+	}
+
+	resp, respDetails, err := client.SMS.(sms.TFAEmail).CreateTFAEmailMessageTemplate(context.Background(), appID, req)
+
+	fmt.Println(resp)
+	fmt.Println(respDetails)
+
+	require.Nil(t, err)
+	assert.NotNil(t, respDetails)
+	assert.NotEmptyf(t, resp.MessageID, "MessageID should not be empty")
+	assert.Equal(t, http.StatusOK, respDetails.HTTPResponse.StatusCode)
+}
+
+func TestUpdateTFAEmailMessageTemplate(t *testing.T) {
+	client, err := infobip.NewClientFromEnv()
+	require.Nil(t, err)
+
+	appID := "43D78365E3257420D78752A62845A8CB"
+	messageID := "6D06F1CD7B0B4E3A8A53B8D6DA7C8E6B"
+	req := models.UpdateTFAEmailMessageTemplateRequest{
+		From:            "gopher@example.com",
+		EmailTemplateID: 1234,
+		PINLength:       6,
+		PINType:         models.NUMERIC,
+	}
+
+	tfaEmail := client.SMS.(sms.TFAEmail)
+	resp, respDetails, err := tfaEmail.UpdateTFAEmailMessageTemplate(context.Background(), appID, messageID, req)
+
+	fmt.Println(resp)
+	fmt.Println(respDetails)
+
+	require.Nil(t, err)
+	assert.NotNil(t, respDetails)
+	assert.Equal(t, http.StatusOK, respDetails.HTTPResponse.StatusCode)
+}
+
+func TestSendPINOverEmail(t *testing.T) {
+	client, err := infobip.NewClientFromEnv()
+	require.Nil(t, err)
+
+	req := models.SendPINOverEmailRequest{
+		ApplicationID: "43D78365E3257420D78752A62845A8CB",
+		MessageID:     "6D06F1CD7B0B4E3A8A53B8D6DA7C8E6B",
+		To:            "someone@example.com",
+	}
+
+	resp, respDetails, err := client.SMS.(sms.TFAEmail).SendPINOverEmail(context.Background(), req)
+
+	fmt.Println(resp)
+	fmt.Println(respDetails)
+
+	require.Nil(t, err)
+	assert.NotNil(t, respDetails)
+	assert.NotEmptyf(t, resp.PINID, "ID should not be empty")
+	assert.Equal(t, http.StatusOK, respDetails.HTTPResponse.StatusCode)
+}
+
+func TestResendPINOverEmail(t *testing.T) {
+	client, err := infobip.NewClientFromEnv()
+	require.Nil(t, err)
+
+	pinID := "A787EC9C153328E9D276D98861C9CEA1"
+	req := models.ResendPINOverEmailRequest{}
+
+	resp, respDetails, err := client.SMS.(sms.TFAEmail).ResendPINOverEmail(context.Background(), pinID, req)
+
+	fmt.Println(resp)
+	fmt.Println(respDetails)
+
+	require.Nil(t, err)
+	assert.NotNil(t, respDetails)
+	assert.NotEmptyf(t, resp.PINID, "ID should not be empty")
+	assert.Equal(t, http.StatusOK, respDetails.HTTPResponse.StatusCode)
+}
+
+func TestSendSMSWithURLTracking(t *testing.T) {
+	client, err := infobip.NewClient(baseURL, apiKey)
+	require.Nil(t, err)
+	shortenURL, trackClicks := true, true
+	request := models.SendSMSRequest{
+		Messages: []models.SMSMsg{
+			{
+				Destinations: []models.SMSDestination{{To: destNumber}},
+				From:         "Infobip Gopher",
+				Text:         "Hello from Go SDK, see https://www.infobip.com/docs",
+			},
+		},
+		URLOptions: &models.SMSURLOptions{ShortenURL: &shortenURL, TrackClicks: &trackClicks},
+	}
+
+	resp, respDetails, err := client.SMS.Send(context.Background(), request)
+
+	fmt.Println(resp)
+	fmt.Println(respDetails)
+
+	require.Nil(t, err)
+	assert.NotNil(t, respDetails)
+	assert.NotEmptyf(t, resp.Messages[0].MessageID, "MessageID should not be empty")
+	assert.Equal(t, http.StatusOK, respDetails.HTTPResponse.StatusCode)
+}
+
+func TestConfirmSMSConversion(t *testing.T) {
+	client, err := infobip.NewClientFromEnv()
+	require.Nil(t, err)
+
+	messageID := "MESSAGE-ID-123-xyz"
+
+	resp, respDetails, err := client.SMS.(sms.Conversions).ConfirmConversion(context.Background(), messageID)
+
+	fmt.Println(resp)
+	fmt.Println(respDetails)
+
+	require.Nil(t, err)
+	assert.NotNil(t, respDetails)
+	assert.Equal(t, http.StatusOK, respDetails.HTTPResponse.StatusCode)
+}
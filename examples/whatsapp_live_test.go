@@ -3,12 +3,16 @@ package examples
 import (
 	"context"
 	"fmt"
+	"io/ioutil"
 	"net/http"
+	"os"
 	"testing"
+	"time"
 
 	"github.com/infobip-community/infobip-api-go-sdk/v3/pkg/infobip"
 	"github.com/infobip-community/infobip-api-go-sdk/v3/pkg/infobip/models"
 	"github.com/infobip-community/infobip-api-go-sdk/v3/pkg/infobip/utils"
+	"github.com/infobip-community/infobip-api-go-sdk/v3/pkg/infobip/whatsapp"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -366,6 +370,49 @@ func TestCreateTemplateExample(t *testing.T) {
 	assert.Equal(t, http.StatusCreated, respDetails.HTTPResponse.StatusCode)
 }
 
+func TestEditTemplateExample(t *testing.T) {
+	client, err := infobip.NewClient(baseURL, apiKey)
+	require.NoError(t, err)
+	template := models.TemplateEdit{
+		Structure: models.TemplateStructure{
+			Body: &models.TemplateStructureBody{Text: "new body {{1}} content"},
+			Type: "TEXT",
+		},
+	}
+
+	editor := client.WhatsApp.(whatsapp.TemplateEditor)
+	msgResp, respDetails, err := editor.EditTemplate(context.Background(), sender, "111", template)
+	fmt.Printf("%+v\n", msgResp)
+
+	require.NoError(t, err)
+	assert.NotEqual(t, models.ResponseDetails{}, respDetails)
+	assert.Equal(t, http.StatusOK, respDetails.HTTPResponse.StatusCode)
+}
+
+func TestTemplateSyncExample(t *testing.T) {
+	client, err := infobip.NewClient(baseURL, apiKey)
+	require.NoError(t, err)
+	desired, err := whatsapp.ParseTemplates([]byte(`
+- name: template_name_my_test
+  language: en
+  category: UTILITY
+  structure:
+    body:
+      text: "body {{1}} content"
+    type: TEXT
+`))
+	require.NoError(t, err)
+
+	sync := whatsapp.NewTemplateSync(client.WhatsApp, whatsapp.TemplateSyncConfig{Sender: sender})
+	plan, err := sync.Plan(context.Background(), desired)
+	require.NoError(t, err)
+	fmt.Print(plan)
+
+	results, err := sync.Apply(context.Background(), plan)
+	fmt.Printf("%+v\n", results)
+	require.NoError(t, err)
+}
+
 func TestDeleteTemplateExample(t *testing.T) {
 	client, err := infobip.NewClient(baseURL, apiKey)
 	require.NoError(t, err)
@@ -375,3 +422,144 @@ func TestDeleteTemplateExample(t *testing.T) {
 	assert.NotEqual(t, models.ResponseDetails{}, respDetails)
 	assert.NotEqual(t, http.StatusNoContent, respDetails.HTTPResponse.StatusCode)
 }
+
+func TestUploadMediaExample(t *testing.T) {
+	client, err := infobip.NewClient(baseURL, apiKey)
+	require.NoError(t, err)
+	file, err := os.Open("../pkg/infobip/email/testdata/image.png")
+	require.NoError(t, err)
+	defer file.Close()
+
+	mediaStore := client.WhatsApp.(whatsapp.MediaStore)
+	resp, respDetails, err := mediaStore.UploadMedia(context.Background(), sender, models.WAUploadMediaRequest{
+		MediaType:   models.WAMediaImage,
+		ContentType: "image/png",
+		Filename:    "image.png",
+		Media:       file,
+	})
+	fmt.Printf("%+v\n", resp)
+
+	require.NoError(t, err)
+	assert.NotEqual(t, models.ResponseDetails{}, respDetails)
+	assert.Equal(t, http.StatusOK, respDetails.HTTPResponse.StatusCode)
+}
+
+func TestDownloadMediaExample(t *testing.T) {
+	client, err := infobip.NewClient(baseURL, apiKey)
+	require.NoError(t, err)
+	mediaID := "your-media-id"
+
+	mediaStore := client.WhatsApp.(whatsapp.MediaStore)
+	metadata, respDetails, err := mediaStore.GetMediaMetadata(context.Background(), sender, mediaID)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, respDetails.HTTPResponse.StatusCode)
+	fmt.Printf("%+v\n", metadata)
+
+	body, respDetails, err := mediaStore.DownloadMedia(context.Background(), sender, mediaID)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, respDetails.HTTPResponse.StatusCode)
+	defer body.Close()
+
+	content, err := ioutil.ReadAll(body)
+	require.NoError(t, err)
+	assert.Equal(t, metadata.ContentLength, int64(len(content)))
+}
+
+func TestSessionGuardExample(t *testing.T) {
+	client, err := infobip.NewClient(baseURL, apiKey)
+	require.NoError(t, err)
+	tracker := whatsapp.NewSessionTracker(nil)
+	// Feed the tracker from the inbound message webhook with RecordInboundMessages, or record messages manually.
+	err = tracker.RecordInbound(context.Background(), "441134960001", time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+
+	guard := whatsapp.NewSessionGuard(client.WhatsApp, tracker, nil)
+	msgResp, respDetails, err := guard.SendText(context.Background(), models.WATextMsg{
+		MsgCommon: models.MsgCommon{From: sender, To: "441134960001"},
+		Content:   models.TextContent{Text: "Thanks for reaching out!"},
+	})
+	fmt.Printf("%+v\n", msgResp)
+
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, respDetails.HTTPResponse.StatusCode)
+}
+
+func TestReplyWithReactionExample(t *testing.T) {
+	client, err := infobip.NewClient(baseURL, apiKey)
+	require.NoError(t, err)
+	inboundMessageID := "your-inbound-message-id"
+
+	actions := client.WhatsApp.(whatsapp.ConversationActions)
+	respDetails, err := actions.SendTypingIndicator(context.Background(), sender, inboundMessageID)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, respDetails.HTTPResponse.StatusCode)
+
+	msgResp, respDetails, err := actions.SendReaction(context.Background(), models.WAReactionMsg{
+		MsgCommon: models.MsgCommon{From: sender, To: "441134960001"},
+		Content:   models.ReactionContent{MessageID: inboundMessageID, Reaction: "👍"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, respDetails.HTTPResponse.StatusCode)
+	fmt.Printf("%+v\n", msgResp)
+
+	msgResp, respDetails, err = client.WhatsApp.SendText(context.Background(), models.WATextMsg{
+		MsgCommon: models.MsgCommon{
+			From:    sender,
+			To:      "441134960001",
+			Context: &models.WAMsgContext{MessageID: inboundMessageID},
+		},
+		Content: models.TextContent{Text: "Your order is on its way."},
+	})
+	fmt.Printf("%+v\n", msgResp)
+
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, respDetails.HTTPResponse.StatusCode)
+}
+
+func TestInteractiveFlowExample(t *testing.T) {
+	client, err := infobip.NewClient(baseURL, apiKey)
+	require.NoError(t, err)
+	msg := models.WAInteractiveFlowMsg{
+		MsgCommon: models.MsgCommon{From: sender, To: "441134960001"},
+		Content: models.InteractiveFlowContent{
+			Body: models.InteractiveFlowBody{Text: "Book your appointment"},
+			Action: models.InteractiveFlowAction{
+				FlowID:             "your-flow-id",
+				FlowToken:          "appointment-42",
+				CallToActionButton: "Book",
+				FlowActionPayload:  &models.InteractiveFlowPayload{Screen: "APPOINTMENT"},
+			},
+		},
+	}
+	// The answers arrive at the inbound message webhook as an INTERACTIVE_FLOW_REPLY message, read them with
+	// Message.FlowReply().
+	requests := client.WhatsApp.(whatsapp.InteractiveRequests)
+	msgResp, respDetails, err := requests.SendInteractiveFlow(context.Background(), msg)
+	fmt.Printf("%+v\n", msgResp)
+
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, respDetails.HTTPResponse.StatusCode)
+}
+
+func TestAuditSendersExample(t *testing.T) {
+	client, err := infobip.NewClient(baseURL, apiKey)
+	require.NoError(t, err)
+	management := client.WhatsApp.(whatsapp.SenderManagement)
+	senders, respDetails, err := management.GetSenders(context.Background(), models.GetWASendersParams{Size: 100})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, respDetails.HTTPResponse.StatusCode)
+
+	numbers := make([]string, 0, len(senders.Senders))
+	for _, s := range senders.Senders {
+		numbers = append(numbers, s.Sender)
+	}
+	quality, respDetails, err := management.GetSenderQuality(context.Background(), numbers)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, respDetails.HTTPResponse.StatusCode)
+
+	for _, result := range quality.Results {
+		profile, _, profileErr := management.GetBusinessProfile(context.Background(), result.Sender)
+		require.NoError(t, profileErr)
+		fmt.Printf("%s %s %s %+v\n", result.Sender, result.QualityRating, result.CurrentLimit, profile)
+	}
+}
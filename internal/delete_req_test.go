@@ -3,6 +3,7 @@ package internal
 import (
 	"context"
 	"encoding/json"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -72,3 +73,40 @@ func TestDeleteReqErr(t *testing.T) {
 	assert.NotNil(t, respDetails)
 	assert.Equal(t, models.SendWAMsgResponse{}, models.SendWAMsgResponse{})
 }
+
+func TestDeleteJSONReqOK(t *testing.T) {
+	req := models.EmailSuppressionsRequest{Suppressions: []models.EmailSuppressionsEntry{
+		{DomainName: "example.com", EmailAddress: []string{"jane@example.com"}, Type: "BOUNCE"},
+	}}
+
+	serv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodDelete, r.Method)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		parsedBody, servErr := ioutil.ReadAll(r.Body)
+		assert.Nil(t, servErr)
+
+		var receivedReq models.EmailSuppressionsRequest
+		servErr = json.Unmarshal(parsedBody, &receivedReq)
+		assert.Nil(t, servErr)
+		assert.Equal(t, req, receivedReq)
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer serv.Close()
+
+	handler := HTTPHandler{HTTPClient: http.Client{}, BaseURL: serv.URL}
+	respDetails, err := handler.DeleteJSONReq(context.Background(), &req, nil, "some/path", nil)
+
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, respDetails.HTTPResponse.StatusCode)
+	assert.Equal(t, models.ErrorDetails{}, respDetails.ErrorResponse)
+}
+
+func TestDeleteJSONReqInvalid(t *testing.T) {
+	handler := HTTPHandler{HTTPClient: http.Client{}, BaseURL: "nonexistent"}
+	respDetails, err := handler.DeleteJSONReq(
+		context.Background(), &models.EmailSuppressionsRequest{}, nil, "some/path", nil)
+
+	require.Error(t, err)
+	assert.Equal(t, models.ResponseDetails{}, respDetails)
+}
@@ -0,0 +1,59 @@
+package internal
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetStreamReqOK(t *testing.T) {
+	content := []byte("binary content")
+	serv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "value", r.URL.Query().Get("param"))
+		_, servErr := w.Write(content)
+		assert.Nil(t, servErr)
+	}))
+	defer serv.Close()
+
+	handler := HTTPHandler{HTTPClient: http.Client{}, BaseURL: serv.URL}
+	body, respDetails, err := handler.GetStreamRequest(
+		context.Background(), "some/path", []QueryParameter{{Name: "param", Value: "value"}})
+	require.NoError(t, err)
+	defer body.Close()
+
+	received, err := ioutil.ReadAll(body)
+	require.NoError(t, err)
+	assert.Equal(t, content, received)
+	assert.Equal(t, http.StatusOK, respDetails.HTTPResponse.StatusCode)
+}
+
+func TestGetStreamReq4xx(t *testing.T) {
+	rawJSONResp := []byte(`{
+		"requestError": {
+			"serviceException": {
+				"messageId": "UNAUTHORIZED",
+				"text": "Invalid login details"
+			}
+		}
+	}`)
+	serv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, servErr := w.Write(rawJSONResp)
+		assert.Nil(t, servErr)
+	}))
+	defer serv.Close()
+
+	handler := HTTPHandler{HTTPClient: http.Client{}, BaseURL: serv.URL}
+	body, respDetails, err := handler.GetStreamRequest(context.Background(), "some/path", nil)
+
+	require.NoError(t, err)
+	assert.Nil(t, body)
+	assert.Equal(t, http.StatusUnauthorized, respDetails.HTTPResponse.StatusCode)
+	assert.Equal(t, "UNAUTHORIZED", respDetails.ErrorResponse.RequestError.ServiceException.MessageID)
+}
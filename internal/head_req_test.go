@@ -0,0 +1,28 @@
+package internal
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHeadReqOK(t *testing.T) {
+	serv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodHead, r.Method)
+		w.Header().Set("Content-Type", "video/mp4")
+		w.Header().Set("Content-Length", "1024")
+	}))
+	defer serv.Close()
+
+	handler := HTTPHandler{HTTPClient: http.Client{}, BaseURL: serv.URL}
+	respDetails, err := handler.HeadRequest(context.Background(), "some/path", nil)
+
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, respDetails.HTTPResponse.StatusCode)
+	assert.Equal(t, "video/mp4", respDetails.HTTPResponse.Header.Get("Content-Type"))
+	assert.Equal(t, int64(1024), respDetails.HTTPResponse.ContentLength)
+}
@@ -4,9 +4,11 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
+	"mime/multipart"
 	"net/http"
 	"net/url"
 	"runtime"
@@ -84,6 +86,53 @@ func (h *HTTPHandler) GetRequest(
 	return respDetails, err
 }
 
+// GetStreamRequest returns the body of a successful response without reading it, for binary resources such as
+// media. The caller must close the body. Error responses are read and parsed into respDetails, and a nil body is
+// returned for them.
+func (h *HTTPHandler) GetStreamRequest(
+	ctx context.Context,
+	reqPath string,
+	queryParams []QueryParameter,
+) (body io.ReadCloser, respDetails models.ResponseDetails, err error) {
+	req, err := h.createReq(ctx, http.MethodGet, reqPath, nil, queryParams)
+	if err != nil {
+		return nil, respDetails, err
+	}
+
+	resp, err := h.HTTPClient.Do(req) //nolint: bodyclose // closed here on errors, by the caller otherwise
+	if err != nil {
+		return nil, respDetails, err
+	}
+	respDetails.HTTPResponse = *resp
+
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		parsedBody, _ := ioutil.ReadAll(resp.Body)
+		_ = json.Unmarshal(parsedBody, &respDetails.ErrorResponse)
+		return nil, respDetails, nil
+	}
+	return resp.Body, respDetails, nil
+}
+
+// HeadRequest fetches only the headers of a resource, such as the content type and length of media.
+func (h *HTTPHandler) HeadRequest(
+	ctx context.Context,
+	reqPath string,
+	queryParams []QueryParameter,
+) (respDetails models.ResponseDetails, err error) {
+	req, err := h.createReq(ctx, http.MethodHead, reqPath, nil, queryParams)
+	if err != nil {
+		return respDetails, err
+	}
+
+	resp, _, err := h.executeReq(req) //nolint: bodyclose // closed in the method itself
+	if err != nil {
+		return respDetails, err
+	}
+	respDetails.HTTPResponse = *resp
+	return respDetails, nil
+}
+
 func (h *HTTPHandler) PostJSONReq(
 	ctx context.Context,
 	postResource models.Validatable,
@@ -142,12 +191,33 @@ func (h *HTTPHandler) PutJSONReq(
 	if err != nil {
 		return respDetails, err
 	}
-	return h.putRequest(ctx, payload, respResource, reqPath, "application/json", queryParams)
+	return h.updateRequest(ctx, http.MethodPut, payload, respResource, reqPath, "application/json", queryParams)
+}
+
+func (h *HTTPHandler) PatchJSONReq(
+	ctx context.Context,
+	patchResource models.Validatable,
+	respResource interface{},
+	reqPath string,
+	queryParams []QueryParameter,
+) (respDetails models.ResponseDetails, err error) {
+	err = patchResource.Validate()
+	if err != nil {
+		return respDetails, err
+	}
+	payload, err := patchResource.Marshal()
+	if err != nil {
+		return respDetails, err
+	}
+	return h.updateRequest(ctx, http.MethodPatch, payload, respResource, reqPath, "application/json", queryParams)
 }
 
+// PostMultipartReq sends postResource as multipart/form-data. The body of a models.MultipartWritable is streamed
+// through a pipe, so that attachments are never held in memory, and written again from the start when the HTTP
+// client needs to resend it. The body of a models.MultipartValidatable is the buffer returned by Marshal.
 func (h *HTTPHandler) PostMultipartReq(
 	ctx context.Context,
-	postResource models.MultipartValidatable,
+	postResource models.Validatable,
 	respResource interface{},
 	reqPath string,
 ) (respDetails models.ResponseDetails, err error) {
@@ -155,18 +225,91 @@ func (h *HTTPHandler) PostMultipartReq(
 	if err != nil {
 		return respDetails, err
 	}
-	payload, err := postResource.Marshal()
+
+	switch resource := postResource.(type) {
+	case models.MultipartWritable:
+		return h.streamMultipart(ctx, resource, respResource, reqPath)
+	case models.MultipartValidatable:
+		payload, marshalErr := resource.Marshal()
+		if marshalErr != nil {
+			return respDetails, marshalErr
+		}
+		contentType := fmt.Sprintf("multipart/form-data; boundary=%s", resource.GetMultipartBoundary())
+		return h.postRequest(ctx, payload, respResource, reqPath, contentType, nil)
+	}
+	return respDetails, fmt.Errorf("%T is not a multipart model", postResource)
+}
+
+func (h *HTTPHandler) streamMultipart(
+	ctx context.Context,
+	postResource models.MultipartWritable,
+	respResource interface{},
+	reqPath string,
+) (respDetails models.ResponseDetails, err error) {
+	if preparer, ok := postResource.(models.MultipartPreparer); ok {
+		postResource = preparer.PrepareMultipart()
+	}
+	body := &multipartBody{resource: postResource, boundary: multipart.NewWriter(ioutil.Discard).Boundary()}
+	req, err := h.createReq(ctx, http.MethodPost, reqPath, body.open(), nil)
 	if err != nil {
+		body.close()
 		return respDetails, err
 	}
-	return h.postRequest(
-		ctx,
-		payload,
-		respResource,
-		reqPath,
-		fmt.Sprintf("multipart/form-data; boundary=%s", postResource.GetMultipartBoundary()),
-		nil,
-	)
+	req.GetBody = func() (io.ReadCloser, error) {
+		return body.open(), nil
+	}
+	req.Header.Set("Content-Type", fmt.Sprintf("multipart/form-data; boundary=%s", body.boundary))
+
+	respDetails, err = h.doPost(req, respResource)
+	// Errors writing the body, such as oversized media, explain a failed or rejected request best.
+	if bodyErr := body.close(); bodyErr != nil {
+		return respDetails, bodyErr
+	}
+	return respDetails, err
+}
+
+// multipartBody writes a multipart body into a pipe from a goroutine. Each call to open starts a new write after
+// stopping the previous one, so only one write reads the sources of the resource at a time.
+type multipartBody struct {
+	resource models.MultipartWritable
+	boundary string
+	reader   *io.PipeReader
+	done     chan struct{}
+	err      error
+}
+
+func (b *multipartBody) open() io.ReadCloser {
+	b.close()
+	reader, writer := io.Pipe()
+	done := make(chan struct{})
+	b.reader, b.done, b.err = reader, done, nil
+
+	go func() {
+		defer close(done)
+		multipartWriter := multipart.NewWriter(writer)
+		err := multipartWriter.SetBoundary(b.boundary)
+		if err == nil {
+			err = b.resource.WriteMultipart(multipartWriter)
+		}
+		if err == nil {
+			err = multipartWriter.Close()
+		}
+		if err != nil && !errors.Is(err, io.ErrClosedPipe) {
+			b.err = err
+		}
+		writer.CloseWithError(err)
+	}()
+	return reader
+}
+
+// close stops the current write and returns its error.
+func (b *multipartBody) close() error {
+	if b.reader == nil {
+		return nil
+	}
+	b.reader.Close()
+	<-b.done
+	return b.err
 }
 
 func (h *HTTPHandler) DeleteRequest(
@@ -193,6 +336,25 @@ func (h *HTTPHandler) DeleteRequest(
 	return respDetails, err
 }
 
+// DeleteJSONReq sends a DELETE request with a JSON body, for endpoints that select what to delete in the body.
+func (h *HTTPHandler) DeleteJSONReq(
+	ctx context.Context,
+	deleteResource models.Validatable,
+	respResource interface{},
+	reqPath string,
+	queryParams []QueryParameter,
+) (respDetails models.ResponseDetails, err error) {
+	err = deleteResource.Validate()
+	if err != nil {
+		return respDetails, err
+	}
+	payload, err := deleteResource.Marshal()
+	if err != nil {
+		return respDetails, err
+	}
+	return h.updateRequest(ctx, http.MethodDelete, payload, respResource, reqPath, "application/json", queryParams)
+}
+
 func (h *HTTPHandler) postRequest(
 	ctx context.Context,
 	payload *bytes.Buffer,
@@ -206,7 +368,13 @@ func (h *HTTPHandler) postRequest(
 		return respDetails, err
 	}
 	req.Header.Set("Content-Type", contentType)
+	return h.doPost(req, respResource)
+}
 
+func (h *HTTPHandler) doPost(
+	req *http.Request,
+	respResource interface{},
+) (respDetails models.ResponseDetails, err error) {
 	resp, parsedBody, err := h.executeReq(req) //nolint: bodyclose // closed in the method itself
 	if err != nil {
 		_ = json.Unmarshal(parsedBody, &respDetails.ErrorResponse)
@@ -258,15 +426,16 @@ func (h *HTTPHandler) postNoBodyRequest(
 	return respDetails, err
 }
 
-func (h *HTTPHandler) putRequest(
+func (h *HTTPHandler) updateRequest(
 	ctx context.Context,
+	method string,
 	payload *bytes.Buffer,
 	respResource interface{},
 	reqPath string,
 	contentType string,
 	queryParams []QueryParameter,
 ) (respDetails models.ResponseDetails, err error) {
-	req, err := h.createReq(ctx, http.MethodPut, reqPath, payload, queryParams)
+	req, err := h.createReq(ctx, method, reqPath, payload, queryParams)
 	if err != nil {
 		return respDetails, err
 	}
@@ -279,7 +448,7 @@ func (h *HTTPHandler) putRequest(
 	}
 	respDetails.HTTPResponse = *resp
 
-	if resp.StatusCode == http.StatusOK {
+	if resp.StatusCode == http.StatusOK || (resp.StatusCode == http.StatusAccepted && len(parsedBody) > 0) {
 		err = json.Unmarshal(parsedBody, &respResource)
 	} else {
 		_ = json.Unmarshal(parsedBody, &respDetails.ErrorResponse)
@@ -0,0 +1,92 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/infobip-community/infobip-api-go-sdk/v3/pkg/infobip/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPatchReqOK(t *testing.T) {
+	req := models.UpdateScheduledSMSStatusRequest{
+		Status: "PAUSED",
+	}
+	rawJSONResp := []byte(`
+		{
+			"bulkId": "test-bulk-73",
+			"status": "PAUSED"
+		}
+	`)
+	var expectedResp models.UpdateScheduledSMSStatusResponse
+	err := json.Unmarshal(rawJSONResp, &expectedResp)
+	require.NoError(t, err)
+
+	serv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPatch, r.Method)
+		assert.Equal(t, r.Header.Get("Content-Type"), "application/json")
+		parsedBody, servErr := ioutil.ReadAll(r.Body)
+		assert.Nil(t, servErr)
+
+		var receivedReq models.UpdateScheduledSMSStatusRequest
+		servErr = json.Unmarshal(parsedBody, &receivedReq)
+		assert.Nil(t, servErr)
+		assert.Equal(t, receivedReq, req)
+
+		w.WriteHeader(http.StatusAccepted)
+		_, servErr = w.Write(rawJSONResp)
+		assert.Nil(t, servErr)
+	}))
+	defer serv.Close()
+
+	handler := HTTPHandler{HTTPClient: http.Client{}, BaseURL: serv.URL}
+	respResource := models.UpdateScheduledSMSStatusResponse{}
+	respDetails, err := handler.PatchJSONReq(
+		context.Background(), &req, &respResource, "some/path", []QueryParameter{})
+
+	require.NoError(t, err)
+	assert.Equal(t, expectedResp, respResource)
+	assert.Equal(t, http.StatusAccepted, respDetails.HTTPResponse.StatusCode)
+	assert.Equal(t, models.ErrorDetails{}, respDetails.ErrorResponse)
+}
+
+func TestPatchReq4xx(t *testing.T) {
+	req := models.UpdateScheduledSMSStatusRequest{
+		Status: "PAUSED",
+	}
+	rawJSONResp := []byte(`
+		{
+		  "requestError": {
+			"serviceException": {
+			  "messageId": "string",
+			  "text": "string"
+			}
+		  }
+		}
+	`)
+	var expectedResp models.ErrorDetails
+	err := json.Unmarshal(rawJSONResp, &expectedResp)
+	require.NoError(t, err)
+
+	serv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, servErr := w.Write(rawJSONResp)
+		assert.Nil(t, servErr)
+	}))
+	defer serv.Close()
+
+	handler := HTTPHandler{HTTPClient: http.Client{}, BaseURL: serv.URL}
+	respResource := models.UpdateScheduledSMSStatusResponse{}
+	respDetails, err := handler.PatchJSONReq(
+		context.Background(), &req, &respResource, "some/path", []QueryParameter{})
+
+	require.NoError(t, err)
+	assert.Equal(t, expectedResp, respDetails.ErrorResponse)
+	assert.Equal(t, http.StatusBadRequest, respDetails.HTTPResponse.StatusCode)
+	assert.Equal(t, models.UpdateScheduledSMSStatusResponse{}, respResource)
+}
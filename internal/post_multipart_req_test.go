@@ -1,10 +1,14 @@
 package internal
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
 	"os"
@@ -155,3 +159,167 @@ func TestPostMultipartReqErr(t *testing.T) {
 	assert.NotNil(t, respDetails)
 	assert.Equal(t, models.SendEmailResponse{}, respResource)
 }
+
+func TestPostMultipartReqResendsBody(t *testing.T) {
+	image, err := os.Open("testdata/image.png")
+	require.NoError(t, err)
+	info, err := image.Stat()
+	require.NoError(t, err)
+	msg := models.GenerateEmailMsg()
+	msg.InlineImages = []*os.File{image}
+
+	var sizes []int64
+	serv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		err := r.ParseMultipartForm(10240)
+		assert.NoError(t, err)
+		sizes = append(sizes, r.MultipartForm.File["inlineImage"][0].Size)
+		if r.URL.Path == "/some/path" {
+			http.Redirect(w, r, "/other/path", http.StatusTemporaryRedirect)
+			return
+		}
+		_, err = w.Write([]byte(`{"messages": [{"messageId": "someExternalMessageId0"}]}`))
+		assert.NoError(t, err)
+	}))
+	defer serv.Close()
+
+	handler := HTTPHandler{HTTPClient: http.Client{}, BaseURL: serv.URL}
+	respResource := models.SendEmailResponse{}
+	respDetails, err := handler.PostMultipartReq(context.Background(), &msg, &respResource, "some/path")
+
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, respDetails.HTTPResponse.StatusCode)
+	assert.Equal(t, []int64{info.Size(), info.Size()}, sizes)
+	assert.Equal(t, "someExternalMessageId0", respResource.Messages[0].MessageID)
+}
+
+func TestPostMultipartReqResendsPipe(t *testing.T) {
+	reader, writer, err := os.Pipe()
+	require.NoError(t, err)
+	defer reader.Close()
+	go func() {
+		_, _ = writer.WriteString("piped content")
+		writer.Close()
+	}()
+	msg := models.GenerateEmailMsg()
+	msg.Attachment = reader
+
+	serv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(ioutil.Discard, r.Body)
+		http.Redirect(w, r, "/other/path", http.StatusTemporaryRedirect)
+	}))
+	defer serv.Close()
+
+	handler := HTTPHandler{HTTPClient: http.Client{}, BaseURL: serv.URL}
+	respResource := models.SendEmailResponse{}
+	_, err = handler.PostMultipartReq(context.Background(), &msg, &respResource, "some/path")
+
+	require.ErrorIs(t, err, models.ErrMediaNotRewindable)
+}
+
+type failingMultipart struct{}
+
+var errWritePart = errors.New("write part")
+
+func (f *failingMultipart) Validate() error {
+	return nil
+}
+
+func (f *failingMultipart) Marshal() (*bytes.Buffer, error) {
+	return nil, errWritePart
+}
+
+func (f *failingMultipart) WriteMultipart(writer *multipart.Writer) error {
+	part, err := writer.CreateFormFile("media", "media.bin")
+	if err != nil {
+		return err
+	}
+	if _, err = part.Write(make([]byte, 64*1024)); err != nil {
+		return err
+	}
+	return errWritePart
+}
+
+func TestPostMultipartReqWriteErr(t *testing.T) {
+	serv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(ioutil.Discard, r.Body)
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer serv.Close()
+
+	handler := HTTPHandler{HTTPClient: http.Client{}, BaseURL: serv.URL}
+	respResource := models.SendMMSResponse{}
+	_, err := handler.PostMultipartReq(context.Background(), &failingMultipart{}, &respResource, "some/path")
+
+	require.ErrorIs(t, err, errWritePart)
+}
+
+// bufferedMultipart implements models.MultipartValidatable only, as models written before bodies were streamed.
+type bufferedMultipart struct {
+	boundary string
+}
+
+func (b *bufferedMultipart) Validate() error {
+	return nil
+}
+
+func (b *bufferedMultipart) Marshal() (*bytes.Buffer, error) {
+	buf := &bytes.Buffer{}
+	writer := multipart.NewWriter(buf)
+	if err := writer.WriteField("text", "buffered"); err != nil {
+		return nil, err
+	}
+	b.boundary = writer.Boundary()
+	return buf, writer.Close()
+}
+
+func (b *bufferedMultipart) GetMultipartBoundary() string {
+	return b.boundary
+}
+
+func TestPostMultipartReqBuffered(t *testing.T) {
+	serv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.NoError(t, r.ParseMultipartForm(1024))
+		assert.Equal(t, []string{"buffered"}, r.MultipartForm.Value["text"])
+		_, err := w.Write([]byte(`{"messages": [{"messageId": "someExternalMessageId0"}]}`))
+		assert.NoError(t, err)
+	}))
+	defer serv.Close()
+
+	handler := HTTPHandler{HTTPClient: http.Client{}, BaseURL: serv.URL}
+	respResource := models.SendMMSResponse{}
+	respDetails, err := handler.PostMultipartReq(context.Background(), &bufferedMultipart{}, &respResource, "some/path")
+
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, respDetails.HTTPResponse.StatusCode)
+	assert.Equal(t, "someExternalMessageId0", respResource.Messages[0].MessageID)
+}
+
+func BenchmarkPostMultipartReq(b *testing.B) {
+	serv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(ioutil.Discard, r.Body)
+		_, _ = w.Write([]byte(`{"messages": []}`))
+	}))
+	defer serv.Close()
+	handler := HTTPHandler{HTTPClient: http.Client{}, BaseURL: serv.URL}
+
+	// Allocations per request stay the same whatever the attachment size, as attachments are never buffered.
+	for _, size := range []int64{1 << 20, 16 << 20, 64 << 20} {
+		attachment, err := ioutil.TempFile(b.TempDir(), "attachment")
+		require.NoError(b, err)
+		require.NoError(b, attachment.Truncate(size))
+
+		b.Run(fmt.Sprintf("%dMB", size>>20), func(b *testing.B) {
+			b.ReportAllocs()
+			b.SetBytes(size)
+			for i := 0; i < b.N; i++ {
+				msg := models.GenerateEmailMsg()
+				msg.Attachment = attachment
+				respResource := models.SendEmailResponse{}
+				_, err := handler.PostMultipartReq(context.Background(), &msg, &respResource, "some/path")
+				if err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
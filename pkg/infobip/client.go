@@ -12,7 +12,10 @@ import (
 	"github.com/infobip-community/infobip-api-go-sdk/v3/internal"
 	"github.com/infobip-community/infobip-api-go-sdk/v3/pkg/infobip/account"
 	"github.com/infobip-community/infobip-api-go-sdk/v3/pkg/infobip/email"
+	"github.com/infobip-community/infobip-api-go-sdk/v3/pkg/infobip/media"
+	"github.com/infobip-community/infobip-api-go-sdk/v3/pkg/infobip/messaging"
 	"github.com/infobip-community/infobip-api-go-sdk/v3/pkg/infobip/mms"
+	"github.com/infobip-community/infobip-api-go-sdk/v3/pkg/infobip/models"
 	"github.com/infobip-community/infobip-api-go-sdk/v3/pkg/infobip/numbers"
 	"github.com/infobip-community/infobip-api-go-sdk/v3/pkg/infobip/rcs"
 	"github.com/infobip-community/infobip-api-go-sdk/v3/pkg/infobip/sms"
@@ -25,6 +28,8 @@ type Client struct {
 	apiKey     string
 	baseURL    string
 	httpClient http.Client
+	pipeline   *media.Pipeline
+	smsRules   models.SMSRegionalRules
 	WhatsApp   whatsapp.WhatsApp
 	MMS        mms.MMS
 	Email      email.Email
@@ -33,6 +38,8 @@ type Client struct {
 	RCS        rcs.RCS
 	Numbers    numbers.Numbers
 	Account    account.Account
+	// Messages sends channel-agnostic messages over the SMS, WhatsApp, email, MMS and RCS channels.
+	Messages messaging.Messages
 }
 
 // NewClientFromEnv returns a client object using the credentials from the environment.
@@ -62,10 +69,12 @@ func NewClient(baseURL string, apiKey string, options ...func(*Client)) (Client,
 	}
 
 	c.WhatsApp = &whatsapp.Channel{
-		ReqHandler: internal.HTTPHandler{APIKey: apiKey, BaseURL: baseURL, HTTPClient: c.httpClient},
+		ReqHandler:    internal.HTTPHandler{APIKey: apiKey, BaseURL: baseURL, HTTPClient: c.httpClient},
+		MediaPipeline: c.pipeline,
 	}
 	c.MMS = &mms.Channel{
-		ReqHandler: internal.HTTPHandler{APIKey: apiKey, BaseURL: baseURL, HTTPClient: c.httpClient},
+		ReqHandler:    internal.HTTPHandler{APIKey: apiKey, BaseURL: baseURL, HTTPClient: c.httpClient},
+		MediaPipeline: c.pipeline,
 	}
 
 	c.Email = &email.Channel{
@@ -73,7 +82,8 @@ func NewClient(baseURL string, apiKey string, options ...func(*Client)) (Client,
 	}
 
 	c.SMS = &sms.Channel{
-		ReqHandler: internal.HTTPHandler{APIKey: apiKey, BaseURL: baseURL, HTTPClient: c.httpClient},
+		ReqHandler:    internal.HTTPHandler{APIKey: apiKey, BaseURL: baseURL, HTTPClient: c.httpClient},
+		RegionalRules: c.smsRules,
 	}
 
 	c.WebRTC = &webrtc.Channel{
@@ -91,6 +101,10 @@ func NewClient(baseURL string, apiKey string, options ...func(*Client)) (Client,
 	c.Account = &account.Platform{
 		ReqHandler: internal.HTTPHandler{APIKey: apiKey, BaseURL: baseURL, HTTPClient: c.httpClient},
 	}
+
+	c.Messages = messaging.NewService(messaging.Channels{
+		SMS: c.SMS, WhatsApp: c.WhatsApp, Email: c.Email, MMS: c.MMS, RCS: c.RCS,
+	})
 	return c, nil
 }
 
@@ -109,3 +123,19 @@ func WithHTTPClient(httpClient http.Client) func(*Client) {
 		c.httpClient = httpClient
 	}
 }
+
+// WithMediaPipeline fits the media sent over MMS and uploaded to WhatsApp with the pipeline, e.g.
+// media.NewPipeline(media.NewImageProcessor()).
+func WithMediaPipeline(pipeline *media.Pipeline) func(*Client) {
+	return func(c *Client) {
+		c.pipeline = pipeline
+	}
+}
+
+// WithSMSRegionalRules requires the regional SMS parameters selected by rules for the destinations of sent SMS,
+// e.g. models.SMSRegionalRules{IndiaDLT: true}.
+func WithSMSRegionalRules(rules models.SMSRegionalRules) func(*Client) {
+	return func(c *Client) {
+		c.smsRules = rules
+	}
+}
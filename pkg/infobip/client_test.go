@@ -6,6 +6,8 @@ import (
 	"testing"
 	"time"
 
+	"github.com/infobip-community/infobip-api-go-sdk/v3/pkg/infobip/media"
+	"github.com/infobip-community/infobip-api-go-sdk/v3/pkg/infobip/messaging"
 	"github.com/infobip-community/infobip-api-go-sdk/v3/pkg/infobip/mms"
 	"github.com/infobip-community/infobip-api-go-sdk/v3/pkg/infobip/whatsapp"
 	"github.com/stretchr/testify/assert"
@@ -28,6 +30,11 @@ func TestDefaultClient(t *testing.T) {
 	assert.Equal(t, http.Client{}, mmsChannel.(*mms.Channel).ReqHandler.HTTPClient)
 	assert.Equal(t, apiKey, mmsChannel.(*mms.Channel).ReqHandler.APIKey)
 	assert.Equal(t, baseURL, mmsChannel.(*mms.Channel).ReqHandler.BaseURL)
+
+	messages := client.Messages.(*messaging.Service)
+	assert.Equal(t, &messaging.SMSAdapter{Client: client.SMS}, messages.Adapters[messaging.SMS])
+	assert.Equal(t, &messaging.MMSAdapter{Client: client.MMS}, messages.Adapters[messaging.MMS])
+	assert.Len(t, messages.Adapters, 5)
 }
 
 func TestClientWithOptions(t *testing.T) {
@@ -48,6 +55,15 @@ func TestClientWithOptions(t *testing.T) {
 	assert.Equal(t, customClient.Timeout, mmsChannel.(*mms.Channel).ReqHandler.HTTPClient.Timeout)
 }
 
+func TestClientWithMediaPipeline(t *testing.T) {
+	pipeline := media.NewPipeline(media.NewImageProcessor())
+	client, err := NewClient("https://k31ke1.api.infobip.com", "secret", WithMediaPipeline(pipeline))
+	require.NoError(t, err)
+
+	assert.Same(t, pipeline, client.MMS.(*mms.Channel).MediaPipeline)
+	assert.Same(t, pipeline, client.WhatsApp.(*whatsapp.Channel).MediaPipeline)
+}
+
 func TestClientMissingScheme(t *testing.T) {
 	apiKey := "secret"
 	baseURL := "test.com"
@@ -0,0 +1,369 @@
+package email
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/mail"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/infobip-community/infobip-api-go-sdk/v3/pkg/infobip/models"
+)
+
+const (
+	defaultValidationWorkers = 10
+	maxAddressLength         = 254
+	maxLocalPartLength       = 64
+	maxDomainLabelLength     = 63
+)
+
+// ValidationType classifies a validated address. Addresses can have several traits, e.g. a role-based address on a
+// catch-all domain, so the type is the first that applies in the order InvalidAddress, Disposable, CatchAll,
+// RoleBased, ValidMailbox, UnknownMailbox.
+type ValidationType string
+
+const (
+	ValidMailbox   ValidationType = "VALID_MAILBOX"
+	CatchAll       ValidationType = "CATCH_ALL"
+	Disposable     ValidationType = "DISPOSABLE"
+	RoleBased      ValidationType = "ROLE_BASED"
+	InvalidAddress ValidationType = "INVALID"
+	UnknownMailbox ValidationType = "UNKNOWN"
+)
+
+// MailboxStatus is whether the mailbox of an address exists, as reported in the validMailbox field.
+type MailboxStatus string
+
+const (
+	MailboxValid   MailboxStatus = "VALID"
+	MailboxInvalid MailboxStatus = "INVALID"
+	MailboxUnknown MailboxStatus = "UNKNOWN"
+)
+
+// ValidationResult is the validation of one address of the input. Index is the position of the address in the
+// input, since results are streamed in the order they complete. Offline results failed the local syntax check and
+// were not sent to the API. Results with Err could not be validated.
+type ValidationResult struct {
+	Index       int
+	Address     string
+	ValidSyntax bool
+	Mailbox     MailboxStatus
+	CatchAll    bool
+	Disposable  bool
+	RoleBased   bool
+	Offline     bool
+	Cached      bool
+	Err         error
+}
+
+// Type returns the classification of the address, UnknownMailbox for results with Err.
+func (r ValidationResult) Type() ValidationType {
+	switch {
+	case r.Err != nil:
+		return UnknownMailbox
+	case !r.ValidSyntax || r.Mailbox == MailboxInvalid:
+		return InvalidAddress
+	case r.Disposable:
+		return Disposable
+	case r.CatchAll:
+		return CatchAll
+	case r.RoleBased:
+		return RoleBased
+	case r.Mailbox == MailboxValid:
+		return ValidMailbox
+	default:
+		return UnknownMailbox
+	}
+}
+
+// Addresses is an iterator over the addresses to validate. Next returns io.EOF after the last address.
+type Addresses interface {
+	Next() (string, error)
+}
+
+type sliceAddresses struct {
+	addresses []string
+	next      int
+}
+
+// SliceAddresses iterates over the addresses of a slice.
+func SliceAddresses(addresses []string) Addresses {
+	return &sliceAddresses{addresses: addresses}
+}
+
+func (s *sliceAddresses) Next() (string, error) {
+	if s.next >= len(s.addresses) {
+		return "", io.EOF
+	}
+	s.next++
+	return s.addresses[s.next-1], nil
+}
+
+type csvAddresses struct {
+	reader *csv.Reader
+	column int
+}
+
+// NewCSVAddresses iterates over the addresses in a column of a CSV file, found by name in the header row. Rows with
+// an empty address are skipped.
+func NewCSVAddresses(r io.Reader, column string) (Addresses, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("reading CSV header: %w", err)
+	}
+	for i, name := range header {
+		if strings.EqualFold(strings.TrimSpace(name), column) {
+			return &csvAddresses{reader: reader, column: i}, nil
+		}
+	}
+	return nil, fmt.Errorf("CSV header has no %q column", column)
+}
+
+func (c *csvAddresses) Next() (string, error) {
+	for {
+		record, err := c.reader.Read()
+		if err != nil {
+			return "", err
+		}
+		if c.column < len(record) && strings.TrimSpace(record[c.column]) != "" {
+			return record[c.column], nil
+		}
+	}
+}
+
+// BulkValidatorConfig configures a BulkValidator.
+type BulkValidatorConfig struct {
+	// Workers is the number of concurrent ValidateAddresses calls. Defaults to 10.
+	Workers int
+	// RatePerSecond limits the number of ValidateAddresses calls per second. Zero means no limit.
+	RatePerSecond float64
+	// CacheTTL is how long results are kept and reused for the same address. Zero disables caching.
+	CacheTTL time.Duration
+}
+
+// BulkValidator validates lists of addresses with ValidateAddresses, which takes one address per call. Addresses
+// failing a local syntax check are not sent, and results are cached across Validate calls.
+type BulkValidator struct {
+	Client Email
+	config BulkValidatorConfig
+	now    func() time.Time
+
+	mu    sync.Mutex
+	cache map[string]cachedValidation
+}
+
+type cachedValidation struct {
+	result    ValidationResult
+	expiresAt time.Time
+}
+
+func NewBulkValidator(client Email, config BulkValidatorConfig) *BulkValidator {
+	if config.Workers <= 0 {
+		config.Workers = defaultValidationWorkers
+	}
+	return &BulkValidator{Client: client, config: config, now: time.Now, cache: map[string]cachedValidation{}}
+}
+
+// Validate validates the addresses and sends a result for each of them to results, which is closed when done.
+// The returned error is the error of the iterator or of the context; errors of single addresses are reported in
+// their results.
+func (v *BulkValidator) Validate(ctx context.Context, addresses Addresses, results chan<- ValidationResult) error {
+	defer close(results)
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var limit <-chan time.Time
+	if v.config.RatePerSecond > 0 {
+		// Rates above one call per nanosecond would round the interval down to zero, which NewTicker rejects.
+		interval := time.Duration(float64(time.Second) / v.config.RatePerSecond)
+		if interval < time.Nanosecond {
+			interval = time.Nanosecond
+		}
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		limit = ticker.C
+	}
+
+	type job struct {
+		index   int
+		address string
+	}
+	jobs := make(chan job)
+	var workers sync.WaitGroup
+	for i := 0; i < v.config.Workers; i++ {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			for j := range jobs {
+				result, ok := v.validate(ctx, j.address, limit)
+				if !ok {
+					continue
+				}
+				result.Index = j.index
+				select {
+				case results <- result:
+				case <-ctx.Done():
+				}
+			}
+		}()
+	}
+
+	err := feedAddresses(ctx, addresses, func(index int, address string) bool {
+		select {
+		case jobs <- job{index: index, address: address}:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	})
+	close(jobs)
+	if err != nil {
+		cancel()
+	}
+	workers.Wait()
+	if err == nil {
+		err = ctx.Err()
+	}
+	return err
+}
+
+func feedAddresses(ctx context.Context, addresses Addresses, feed func(index int, address string) bool) error {
+	for index := 0; ; index++ {
+		address, err := addresses.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if !feed(index, address) {
+			return ctx.Err()
+		}
+	}
+}
+
+// validate returns the result for an address, and false when the context was canceled while waiting for the rate
+// limit.
+func (v *BulkValidator) validate(ctx context.Context, address string, limit <-chan time.Time) (
+	ValidationResult, bool,
+) {
+	address = strings.TrimSpace(address)
+	if !ValidSyntax(address) {
+		return ValidationResult{Address: address, Mailbox: MailboxInvalid, Offline: true}, true
+	}
+
+	key := strings.ToLower(address)
+	if cached, ok := v.cached(key); ok {
+		cached.Address = address
+		cached.Cached = true
+		return cached, true
+	}
+
+	if limit != nil {
+		select {
+		case <-limit:
+		case <-ctx.Done():
+			return ValidationResult{}, false
+		}
+	}
+	resp, respDetails, err := v.Client.ValidateAddresses(ctx, models.ValidateEmailAddressesRequest{To: address})
+	if err = models.CheckResponse(respDetails, err); err != nil {
+		if ctx.Err() != nil {
+			return ValidationResult{}, false
+		}
+		return ValidationResult{Address: address, Mailbox: MailboxUnknown, Err: err}, true
+	}
+
+	result := ValidationResult{
+		Address:     address,
+		ValidSyntax: resp.ValidSyntax,
+		Mailbox:     mailboxStatus(resp.ValidMailbox),
+		CatchAll:    resp.CatchAll,
+		Disposable:  resp.Disposable,
+		RoleBased:   resp.RoleBased,
+	}
+	v.store(key, result)
+	return result, true
+}
+
+func (v *BulkValidator) cached(key string) (ValidationResult, bool) {
+	if v.config.CacheTTL <= 0 {
+		return ValidationResult{}, false
+	}
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	cached, ok := v.cache[key]
+	if !ok {
+		return ValidationResult{}, false
+	}
+	if !v.now().Before(cached.expiresAt) {
+		delete(v.cache, key)
+		return ValidationResult{}, false
+	}
+	return cached.result, true
+}
+
+func (v *BulkValidator) store(key string, result ValidationResult) {
+	if v.config.CacheTTL <= 0 {
+		return
+	}
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.cache[key] = cachedValidation{result: result, expiresAt: v.now().Add(v.config.CacheTTL)}
+}
+
+func mailboxStatus(validMailbox string) MailboxStatus {
+	switch strings.ToLower(validMailbox) {
+	case "true":
+		return MailboxValid
+	case "false":
+		return MailboxInvalid
+	default:
+		return MailboxUnknown
+	}
+}
+
+// ValidSyntax reports whether address is a bare address, without a display name, with a local part of at most 64
+// characters and a domain name of dot-separated labels.
+func ValidSyntax(address string) bool {
+	if len(address) > maxAddressLength {
+		return false
+	}
+	parsed, err := mail.ParseAddress(address)
+	if err != nil || parsed.Name != "" || parsed.Address != address {
+		return false
+	}
+	at := strings.LastIndex(address, "@")
+	if at > maxLocalPartLength {
+		return false
+	}
+	labels := strings.Split(address[at+1:], ".")
+	if len(labels) < 2 {
+		return false
+	}
+	for _, label := range labels {
+		if !validDomainLabel(label) {
+			return false
+		}
+	}
+	return true
+}
+
+func validDomainLabel(label string) bool {
+	if label == "" || len(label) > maxDomainLabelLength || label[0] == '-' || label[len(label)-1] == '-' {
+		return false
+	}
+	for _, r := range label {
+		if !(r == '-' || r >= '0' && r <= '9' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z') {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,241 @@
+package email
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/infobip-community/infobip-api-go-sdk/v3/internal"
+	"github.com/infobip-community/infobip-api-go-sdk/v3/pkg/infobip/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testValidations = map[string]models.ValidateEmailAddressesResponse{
+	"jane@example.com":    {ValidMailbox: "true", ValidSyntax: true},
+	"info@example.com":    {ValidMailbox: "true", ValidSyntax: true, RoleBased: true},
+	"any@catchall.com":    {ValidMailbox: "unknown", ValidSyntax: true, CatchAll: true},
+	"temp@mailinator.com": {ValidMailbox: "true", ValidSyntax: true, Disposable: true},
+	"gone@example.com":    {ValidMailbox: "false", ValidSyntax: true},
+}
+
+type validationServer struct {
+	calls       int32
+	inFlight    int32
+	maxInFlight int32
+}
+
+func newValidationServer(t *testing.T, delay time.Duration) (*Channel, *validationServer) {
+	stats := &validationServer{}
+	serv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&stats.calls, 1)
+		inFlight := atomic.AddInt32(&stats.inFlight, 1)
+		defer atomic.AddInt32(&stats.inFlight, -1)
+		for {
+			maxInFlight := atomic.LoadInt32(&stats.maxInFlight)
+			if inFlight <= maxInFlight || atomic.CompareAndSwapInt32(&stats.maxInFlight, maxInFlight, inFlight) {
+				break
+			}
+		}
+		time.Sleep(delay)
+
+		body, err := ioutil.ReadAll(r.Body)
+		assert.NoError(t, err)
+		var req models.ValidateEmailAddressesRequest
+		assert.NoError(t, json.Unmarshal(body, &req))
+		resp, ok := testValidations[strings.ToLower(req.To)]
+		if !ok {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		resp.To = req.To
+		assert.NoError(t, json.NewEncoder(w).Encode(resp))
+	}))
+	t.Cleanup(serv.Close)
+	return &Channel{ReqHandler: internal.HTTPHandler{HTTPClient: http.Client{}, BaseURL: serv.URL}}, stats
+}
+
+func collect(t *testing.T, validator *BulkValidator, addresses Addresses) ([]ValidationResult, error) {
+	results := make(chan ValidationResult)
+	var collected []ValidationResult
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for result := range results {
+			collected = append(collected, result)
+		}
+	}()
+	err := validator.Validate(context.Background(), addresses, results)
+	wg.Wait()
+	sort.Slice(collected, func(i, j int) bool { return collected[i].Index < collected[j].Index })
+	return collected, err
+}
+
+func TestBulkValidatorValidate(t *testing.T) {
+	client, stats := newValidationServer(t, 0)
+	validator := NewBulkValidator(client, BulkValidatorConfig{Workers: 3})
+
+	results, err := collect(t, validator, SliceAddresses([]string{
+		"jane@example.com", " info@example.com", "any@catchall.com", "temp@mailinator.com", "gone@example.com",
+		"not-an-address", "Jane <jane@example.com>", "busy@example.com",
+	}))
+
+	require.NoError(t, err)
+	require.Len(t, results, 8)
+	types := make([]ValidationType, 0, len(results))
+	for i, result := range results {
+		assert.Equal(t, i, result.Index)
+		types = append(types, result.Type())
+	}
+	assert.Equal(t, []ValidationType{
+		ValidMailbox, RoleBased, CatchAll, Disposable, InvalidAddress, InvalidAddress, InvalidAddress, UnknownMailbox,
+	}, types)
+	assert.Equal(t, "info@example.com", results[1].Address)
+	assert.True(t, results[5].Offline)
+	assert.True(t, results[6].Offline)
+	var apiErr *models.APIError
+	require.ErrorAs(t, results[7].Err, &apiErr)
+	assert.Equal(t, http.StatusTooManyRequests, apiErr.StatusCode)
+	assert.Equal(t, int32(6), atomic.LoadInt32(&stats.calls))
+}
+
+func TestBulkValidatorConcurrency(t *testing.T) {
+	client, stats := newValidationServer(t, 20*time.Millisecond)
+	validator := NewBulkValidator(client, BulkValidatorConfig{Workers: 2})
+	addresses := make([]string, 8)
+	for i := range addresses {
+		addresses[i] = "jane@example.com"
+	}
+
+	results, err := collect(t, validator, SliceAddresses(addresses))
+
+	require.NoError(t, err)
+	assert.Len(t, results, 8)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&stats.maxInFlight))
+}
+
+func TestBulkValidatorRateLimit(t *testing.T) {
+	client, _ := newValidationServer(t, 0)
+	validator := NewBulkValidator(client, BulkValidatorConfig{Workers: 5, RatePerSecond: 50})
+
+	start := time.Now()
+	results, err := collect(t, validator, SliceAddresses([]string{
+		"jane@example.com", "info@example.com", "any@catchall.com", "temp@mailinator.com", "gone@example.com",
+	}))
+
+	require.NoError(t, err)
+	assert.Len(t, results, 5)
+	assert.GreaterOrEqual(t, time.Since(start), 100*time.Millisecond)
+}
+
+func TestBulkValidatorHighRate(t *testing.T) {
+	client, _ := newValidationServer(t, 0)
+	validator := NewBulkValidator(client, BulkValidatorConfig{Workers: 2, RatePerSecond: 1e12})
+
+	results, err := collect(t, validator, SliceAddresses([]string{"jane@example.com", "info@example.com"}))
+
+	require.NoError(t, err)
+	assert.Len(t, results, 2)
+}
+
+func TestBulkValidatorCache(t *testing.T) {
+	client, stats := newValidationServer(t, 0)
+	validator := NewBulkValidator(client, BulkValidatorConfig{Workers: 1, CacheTTL: time.Hour})
+	now := time.Date(2022, 5, 5, 12, 0, 0, 0, time.UTC)
+	validator.now = func() time.Time { return now }
+
+	results, err := collect(t, validator, SliceAddresses([]string{"jane@example.com", "JANE@example.com"}))
+	require.NoError(t, err)
+	assert.False(t, results[0].Cached)
+	assert.True(t, results[1].Cached)
+	assert.Equal(t, "JANE@example.com", results[1].Address)
+	assert.Equal(t, ValidMailbox, results[1].Type())
+	assert.Equal(t, int32(1), atomic.LoadInt32(&stats.calls))
+
+	now = now.Add(time.Hour)
+	results, err = collect(t, validator, SliceAddresses([]string{"jane@example.com", "busy@example.com"}))
+	require.NoError(t, err)
+	assert.False(t, results[0].Cached)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&stats.calls))
+
+	results, err = collect(t, validator, SliceAddresses([]string{"busy@example.com"}))
+	require.NoError(t, err)
+	assert.False(t, results[0].Cached, "errors are not cached")
+}
+
+func TestBulkValidatorCSV(t *testing.T) {
+	client, _ := newValidationServer(t, 0)
+	validator := NewBulkValidator(client, BulkValidatorConfig{})
+	addresses, err := NewCSVAddresses(strings.NewReader(
+		"id,Name,E-mail\n1,Jane,jane@example.com\n2,No address\n3,Info,info@example.com\n"), "e-mail")
+	require.NoError(t, err)
+
+	results, err := collect(t, validator, addresses)
+
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.Equal(t, "jane@example.com", results[0].Address)
+	assert.Equal(t, RoleBased, results[1].Type())
+
+	_, err = NewCSVAddresses(strings.NewReader("id,name\n"), "email")
+	assert.EqualError(t, err, `CSV header has no "email" column`)
+}
+
+type failingAddresses struct {
+	next int
+}
+
+func (f *failingAddresses) Next() (string, error) {
+	f.next++
+	if f.next > 2 {
+		return "", errors.New("read failed")
+	}
+	return "jane@example.com", nil
+}
+
+func TestBulkValidatorSourceError(t *testing.T) {
+	client, _ := newValidationServer(t, 0)
+	validator := NewBulkValidator(client, BulkValidatorConfig{Workers: 1})
+
+	_, err := collect(t, validator, &failingAddresses{})
+
+	assert.EqualError(t, err, "read failed")
+}
+
+func TestBulkValidatorCanceled(t *testing.T) {
+	client, _ := newValidationServer(t, 0)
+	validator := NewBulkValidator(client, BulkValidatorConfig{Workers: 1, RatePerSecond: 0.001})
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	results := make(chan ValidationResult, 10)
+	err := validator.Validate(ctx, SliceAddresses([]string{"jane@example.com", "info@example.com"}), results)
+
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Empty(t, results)
+}
+
+func TestValidSyntax(t *testing.T) {
+	valid := []string{"jane@example.com", "jane.doe+news@mail.example.co.uk", "o'neil@example.com"}
+	for _, address := range valid {
+		assert.True(t, ValidSyntax(address), address)
+	}
+	invalid := []string{
+		"", "jane", "jane@", "@example.com", "jane@localhost", "jane@example..com", "jane@-example.com",
+		"jane@exa_mple.com", "Jane <jane@example.com>", "jane doe@example.com",
+		strings.Repeat("a", 65) + "@example.com", "jane@" + strings.Repeat("a", 64) + ".com",
+	}
+	for _, address := range invalid {
+		assert.False(t, ValidSyntax(address), address)
+	}
+}
@@ -0,0 +1,208 @@
+package email
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"sort"
+	"strings"
+	texttemplate "text/template"
+
+	"github.com/infobip-community/infobip-api-go-sdk/v3/pkg/infobip/models"
+)
+
+const (
+	placeholderOpen  = "{{"
+	placeholderClose = "}}"
+	// placeholderMarker stands in for placeholders while the HTML is rendered. It is made of characters that no
+	// html/template context escapes, so it can be swapped for the placeholder afterwards.
+	placeholderMarker = "infobip_placeholder_"
+)
+
+var ErrNoRecipients = errors.New("at least one recipient is required")
+
+// MissingPlaceholdersError is returned when placeholders used in the subject or body have no value for a recipient,
+// neither their own nor a default one.
+type MissingPlaceholdersError struct {
+	Recipient    string
+	Placeholders []string
+}
+
+func (e *MissingPlaceholdersError) Error() string {
+	return fmt.Sprintf("recipient %s has no value for placeholders %s", e.Recipient,
+		strings.Join(e.Placeholders, ", "))
+}
+
+// Recipient is an email recipient with the values of the placeholders personalized for them.
+type Recipient struct {
+	Address      string
+	Placeholders map[string]string
+}
+
+// Composer builds an EmailMsg for multiple recipients. Subject, HTML and Text are Go templates rendered locally
+// with Data, using text/template for the subject and text and html/template for the HTML. Values personalized
+// for each recipient are inserted by Infobip: use {{placeholder "name"}} in the templates to write the {{name}}
+// placeholder, which is replaced with the value of the recipient or DefaultPlaceholders. Placeholders are written
+// as is in every HTML context, including href attributes. When Text is empty, it is generated from the rendered
+// HTML with HTMLToText.
+type Composer struct {
+	From                string
+	Recipients          []Recipient
+	Subject             string
+	HTML                string
+	Text                string
+	Data                interface{}
+	DefaultPlaceholders map[string]string
+}
+
+// Compose renders the templates and returns the message, after checking that every placeholder used has a value
+// for every recipient. Other fields of the message, such as attachments, can be set on the result.
+func (c *Composer) Compose() (models.EmailMsg, error) {
+	if len(c.Recipients) == 0 {
+		return models.EmailMsg{}, ErrNoRecipients
+	}
+
+	subject, err := renderText("subject", c.Subject, c.Data)
+	if err != nil {
+		return models.EmailMsg{}, err
+	}
+	htmlBody, err := renderHTML("html", c.HTML, c.Data)
+	if err != nil {
+		return models.EmailMsg{}, err
+	}
+	text, err := renderText("text", c.Text, c.Data)
+	if err != nil {
+		return models.EmailMsg{}, err
+	}
+	if c.Text == "" && htmlBody != "" {
+		text = HTMLToText(htmlBody)
+	}
+
+	used := Placeholders(subject + htmlBody + text)
+	recipients := make([]string, 0, len(c.Recipients))
+	for _, recipient := range c.Recipients {
+		if err = c.checkPlaceholders(recipient, used); err != nil {
+			return models.EmailMsg{}, err
+		}
+		var to string
+		if to, err = recipientJSON(recipient); err != nil {
+			return models.EmailMsg{}, err
+		}
+		recipients = append(recipients, to)
+	}
+
+	msg := models.EmailMsg{
+		From:         c.From,
+		To:           recipients[0],
+		AdditionalTo: recipients[1:],
+		Subject:      subject,
+		HTML:         htmlBody,
+		Text:         text,
+	}
+	if len(c.DefaultPlaceholders) > 0 {
+		var defaults []byte
+		if defaults, err = json.Marshal(c.DefaultPlaceholders); err != nil {
+			return models.EmailMsg{}, err
+		}
+		msg.DefaultPlaceholders = string(defaults)
+	}
+	return msg, nil
+}
+
+func (c *Composer) checkPlaceholders(recipient Recipient, used []string) error {
+	var missing []string
+	for _, name := range used {
+		if _, ok := recipient.Placeholders[name]; ok {
+			continue
+		}
+		if _, ok := c.DefaultPlaceholders[name]; !ok {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		return &MissingPlaceholdersError{Recipient: recipient.Address, Placeholders: missing}
+	}
+	return nil
+}
+
+// Placeholders returns the sorted names of the {{name}} placeholders in content.
+func Placeholders(content string) []string {
+	names := map[string]bool{}
+	for {
+		start := strings.Index(content, placeholderOpen)
+		if start < 0 {
+			break
+		}
+		content = content[start+len(placeholderOpen):]
+		end := strings.Index(content, placeholderClose)
+		if end < 0 {
+			break
+		}
+		if name := strings.TrimSpace(content[:end]); name != "" {
+			names[name] = true
+		}
+		content = content[end+len(placeholderClose):]
+	}
+
+	sorted := make([]string, 0, len(names))
+	for name := range names {
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+	return sorted
+}
+
+// recipientJSON returns the value of the to field: the address, or the JSON form with the placeholders.
+func recipientJSON(recipient Recipient) (string, error) {
+	if len(recipient.Placeholders) == 0 {
+		return recipient.Address, nil
+	}
+	to, err := json.Marshal(struct {
+		To           string            `json:"to"`
+		Placeholders map[string]string `json:"placeholders"`
+	}{To: recipient.Address, Placeholders: recipient.Placeholders})
+	return string(to), err
+}
+
+func placeholder(name string) string {
+	return placeholderOpen + name + placeholderClose
+}
+
+func renderText(name string, source string, data interface{}) (string, error) {
+	if source == "" {
+		return "", nil
+	}
+	tmpl, err := texttemplate.New(name).Funcs(texttemplate.FuncMap{"placeholder": placeholder}).Parse(source)
+	if err != nil {
+		return "", err
+	}
+	var out bytes.Buffer
+	if err = tmpl.Execute(&out, data); err != nil {
+		return "", err
+	}
+	return out.String(), nil
+}
+
+func renderHTML(name string, source string, data interface{}) (string, error) {
+	if source == "" {
+		return "", nil
+	}
+	// html/template escapes the braces of placeholders in URLs, so markers are rendered instead and replaced after.
+	var replacements []string
+	marker := func(name string) string {
+		m := fmt.Sprintf("%s%d_", placeholderMarker, len(replacements)/2)
+		replacements = append(replacements, m, placeholder(name))
+		return m
+	}
+	tmpl, err := htmltemplate.New(name).Funcs(htmltemplate.FuncMap{"placeholder": marker}).Parse(source)
+	if err != nil {
+		return "", err
+	}
+	var out bytes.Buffer
+	if err = tmpl.Execute(&out, data); err != nil {
+		return "", err
+	}
+	return strings.NewReplacer(replacements...).Replace(out.String()), nil
+}
@@ -0,0 +1,111 @@
+package email
+
+import (
+	"testing"
+
+	"github.com/infobip-community/infobip-api-go-sdk/v3/pkg/infobip/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComposerCompose(t *testing.T) {
+	composer := Composer{
+		From: "Shop <shop@example.com>",
+		Recipients: []Recipient{
+			{Address: "jane@example.com", Placeholders: map[string]string{"name": "Jane"}},
+			{Address: "john@example.com"},
+		},
+		Subject: `Order {{.Order}} shipped`,
+		HTML: `<h1>Hi {{placeholder "name"}},</h1><p>Order {{.Order}} of <b>{{.Shop}}</b> is on its way.</p>` +
+			`<a href="{{.TrackingURL}}">Track it</a>`,
+		Data: map[string]string{
+			"Order": "#42", "Shop": "Tom & Jerry", "TrackingURL": "https://example.com/track?id=42&lang=en",
+		},
+		DefaultPlaceholders: map[string]string{"name": "there"},
+	}
+
+	msg, err := composer.Compose()
+
+	require.NoError(t, err)
+	assert.Equal(t, models.EmailMsg{
+		From:         "Shop <shop@example.com>",
+		To:           `{"to":"jane@example.com","placeholders":{"name":"Jane"}}`,
+		AdditionalTo: []string{"john@example.com"},
+		Subject:      "Order #42 shipped",
+		HTML: `<h1>Hi {{name}},</h1><p>Order #42 of <b>Tom &amp; Jerry</b> is on its way.</p>` +
+			`<a href="https://example.com/track?id=42&amp;lang=en">Track it</a>`,
+		Text: "Hi {{name}},\n\nOrder #42 of Tom & Jerry is on its way.\n\n" +
+			"Track it (https://example.com/track?id=42&lang=en)",
+		DefaultPlaceholders: `{"name":"there"}`,
+	}, msg)
+	assert.NoError(t, msg.Validate())
+}
+
+func TestComposerPlaceholderInURL(t *testing.T) {
+	composer := Composer{
+		From:       "shop@example.com",
+		Recipients: []Recipient{{Address: "jane@example.com", Placeholders: map[string]string{"link": "x", "id": "7"}}},
+		Subject:    "Your order",
+		HTML: `<a href="{{placeholder "link"}}">Open</a> ` +
+			`<a href="https://example.com/track?id={{placeholder "id"}}" title="{{placeholder "id"}}">Track</a>`,
+	}
+
+	msg, err := composer.Compose()
+
+	require.NoError(t, err)
+	assert.Equal(t, `<a href="{{link}}">Open</a> <a href="https://example.com/track?id={{id}}" title="{{id}}">Track</a>`,
+		msg.HTML)
+}
+
+func TestComposerTextTemplate(t *testing.T) {
+	composer := Composer{
+		From:       "shop@example.com",
+		Recipients: []Recipient{{Address: "jane@example.com"}},
+		Subject:    "Hello",
+		HTML:       "<p>Hello</p>",
+		Text:       "Hello <{{.}}>",
+		Data:       "plain",
+	}
+
+	msg, err := composer.Compose()
+
+	require.NoError(t, err)
+	assert.Equal(t, "Hello <plain>", msg.Text)
+	assert.Empty(t, msg.AdditionalTo)
+	assert.Empty(t, msg.DefaultPlaceholders)
+}
+
+func TestComposerMissingPlaceholders(t *testing.T) {
+	composer := Composer{
+		From: "shop@example.com",
+		Recipients: []Recipient{
+			{Address: "jane@example.com", Placeholders: map[string]string{"name": "Jane", "code": "A1"}},
+			{Address: "john@example.com", Placeholders: map[string]string{"name": "John"}},
+		},
+		Subject: `Your code, {{placeholder "name"}}`,
+		Text:    `Use {{placeholder "code"}} at checkout before {{placeholder "expiry"}}.`,
+		DefaultPlaceholders: map[string]string{
+			"expiry": "Friday",
+		},
+	}
+
+	_, err := composer.Compose()
+
+	var missingErr *MissingPlaceholdersError
+	require.ErrorAs(t, err, &missingErr)
+	assert.Equal(t, "john@example.com", missingErr.Recipient)
+	assert.Equal(t, []string{"code"}, missingErr.Placeholders)
+}
+
+func TestComposerErrors(t *testing.T) {
+	_, err := (&Composer{From: "shop@example.com", Subject: "Hi"}).Compose()
+	assert.ErrorIs(t, err, ErrNoRecipients)
+
+	_, err = (&Composer{Recipients: []Recipient{{Address: "jane@example.com"}}, Subject: "{{name}}"}).Compose()
+	assert.Error(t, err)
+}
+
+func TestPlaceholders(t *testing.T) {
+	assert.Equal(t, []string{"code", "name"}, Placeholders("{{name}}, use {{ code }} or {{name}}. {{}} {{open"))
+	assert.Empty(t, Placeholders("no placeholders"))
+}
@@ -0,0 +1,353 @@
+package email
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/infobip-community/infobip-api-go-sdk/v3/pkg/infobip/models"
+)
+
+// Resolver looks up the DNS records of a domain. *net.Resolver implements it.
+type Resolver interface {
+	LookupTXT(ctx context.Context, name string) ([]string, error)
+	LookupCNAME(ctx context.Context, host string) (string, error)
+	LookupMX(ctx context.Context, name string) ([]*net.MX, error)
+}
+
+// RecordPurpose tells what a DNS record of a domain is used for.
+type RecordPurpose string
+
+const (
+	PurposeSPF        RecordPurpose = "SPF"
+	PurposeDKIM       RecordPurpose = "DKIM"
+	PurposeDMARC      RecordPurpose = "DMARC"
+	PurposeReturnPath RecordPurpose = "RETURN_PATH"
+	PurposeTracking   RecordPurpose = "TRACKING"
+	PurposeOther      RecordPurpose = "OTHER"
+)
+
+type RecordStatus string
+
+const (
+	RecordMatched  RecordStatus = "MATCHED"
+	RecordMismatch RecordStatus = "MISMATCH"
+	RecordMissing  RecordStatus = "MISSING"
+	// RecordLookupFailed is set when the records couldn't be resolved, or when the record type isn't supported.
+	RecordLookupFailed RecordStatus = "LOOKUP_FAILED"
+)
+
+const (
+	zoneFileTTL       = 3600
+	defaultMXPriority = 10
+	// maxTXTStringLength is the length of the character strings a TXT record value is split into.
+	maxTXTStringLength = 255
+)
+
+var errUnsupportedRecordType = errors.New("unsupported record type")
+
+// RecordCheck compares a DNS record expected by Infobip with the records resolved locally.
+type RecordCheck struct {
+	Purpose       RecordPurpose
+	RecordType    string
+	Name          string
+	ExpectedValue string
+	// VerifiedByInfobip is the state of the record at the last verification by Infobip.
+	VerifiedByInfobip bool
+	Found             []string
+	Status            RecordStatus
+	Err               error
+}
+
+// Action returns what to change in the DNS zone so that the record matches, or an empty string when it does.
+func (c RecordCheck) Action() string {
+	switch c.Status {
+	case RecordMissing:
+		return fmt.Sprintf("add a %s record for %s with the value %s", c.RecordType, c.Name, c.ExpectedValue)
+	case RecordMismatch:
+		if c.Purpose == PurposeSPF {
+			return fmt.Sprintf("merge %s into the SPF record of %s (%s), as a domain can only have one SPF record",
+				c.ExpectedValue, c.Name, strings.Join(c.Found, ", "))
+		}
+		return fmt.Sprintf("change the %s record of %s from %s to %s", c.RecordType, c.Name,
+			strings.Join(c.Found, ", "), c.ExpectedValue)
+	case RecordLookupFailed:
+		return fmt.Sprintf("check the %s record of %s manually: %v", c.RecordType, c.Name, c.Err)
+	}
+	return ""
+}
+
+// DomainReport is the result of checking the DNS records of a domain.
+type DomainReport struct {
+	DomainName string
+	Records    []RecordCheck
+	// VerificationRequested tells whether VerifyDomain was called, which only happens when all records match.
+	VerificationRequested bool
+}
+
+// Matched tells whether every record matches its expected value.
+func (r DomainReport) Matched() bool {
+	for _, record := range r.Records {
+		if record.Status != RecordMatched {
+			return false
+		}
+	}
+	return true
+}
+
+// String returns one line per record with the action to take, if any.
+func (r DomainReport) String() string {
+	var report strings.Builder
+	fmt.Fprintf(&report, "DNS records of %s:\n", r.DomainName)
+	for _, record := range r.Records {
+		fmt.Fprintf(&report, "[%s] %s %s %s", record.Status, record.Purpose, record.RecordType, record.Name)
+		if action := record.Action(); action != "" {
+			fmt.Fprintf(&report, ": %s", action)
+		}
+		report.WriteString("\n")
+	}
+	switch {
+	case r.VerificationRequested:
+		report.WriteString("All records match, verification was requested.\n")
+	case r.Matched():
+		report.WriteString("All records match.\n")
+	default:
+		report.WriteString("Update the DNS zone with ZoneFile and check again once the changes have propagated.\n")
+	}
+	return report.String()
+}
+
+// ZoneFile returns the records that don't match yet in zone file format, ready to paste into the DNS zone.
+func (r DomainReport) ZoneFile() string {
+	var zone strings.Builder
+	for _, record := range r.Records {
+		if record.Status == RecordMatched {
+			continue
+		}
+		name := fqdn(record.Name)
+		switch strings.ToUpper(record.RecordType) {
+		case "TXT":
+			fmt.Fprintf(&zone, "%s %d IN TXT %s\n", name, zoneFileTTL, quoteTXT(record.ExpectedValue))
+		case "CNAME":
+			fmt.Fprintf(&zone, "%s %d IN CNAME %s\n", name, zoneFileTTL, fqdn(record.ExpectedValue))
+		case "MX":
+			priority, host := parseMX(record.ExpectedValue)
+			fmt.Fprintf(&zone, "%s %d IN MX %d %s\n", name, zoneFileTTL, priority, fqdn(host))
+		default:
+			fmt.Fprintf(&zone, "; %s %d IN %s %s\n", name, zoneFileTTL, record.RecordType, record.ExpectedValue)
+		}
+	}
+	return zone.String()
+}
+
+// DomainVerifier checks the DNS records of email domains locally before asking Infobip to verify them, since
+// verification only succeeds once all records are published.
+type DomainVerifier struct {
+	Client   Email
+	Resolver Resolver
+}
+
+// NewDomainVerifier returns a verifier using resolver, or the default resolver of the net package when nil.
+func NewDomainVerifier(client Email, resolver Resolver) *DomainVerifier {
+	if resolver == nil {
+		resolver = net.DefaultResolver
+	}
+	return &DomainVerifier{Client: client, Resolver: resolver}
+}
+
+// Check gets the records expected for the domain and compares them with the resolved records.
+func (v *DomainVerifier) Check(ctx context.Context, domainName string) (DomainReport, error) {
+	domain, respDetails, err := v.Client.GetDomain(ctx, domainName)
+	if err = models.CheckResponse(respDetails, err); err != nil {
+		return DomainReport{}, err
+	}
+
+	report := DomainReport{DomainName: domainName, Records: make([]RecordCheck, 0, len(domain.DNSRecords))}
+	for _, record := range domain.DNSRecords {
+		report.Records = append(report.Records, v.checkRecord(ctx, record))
+	}
+	return report, nil
+}
+
+// Verify checks the records of the domain and calls VerifyDomain when all of them match.
+func (v *DomainVerifier) Verify(ctx context.Context, domainName string) (DomainReport, error) {
+	report, err := v.Check(ctx, domainName)
+	if err != nil || !report.Matched() {
+		return report, err
+	}
+	if err = models.CheckResponse(v.Client.VerifyDomain(ctx, domainName)); err != nil {
+		return report, err
+	}
+	report.VerificationRequested = true
+	return report, nil
+}
+
+func (v *DomainVerifier) checkRecord(ctx context.Context, record models.EmailDNSRecord) RecordCheck {
+	check := RecordCheck{
+		Purpose:           recordPurpose(record),
+		RecordType:        strings.ToUpper(record.RecordType),
+		Name:              record.Name,
+		ExpectedValue:     record.ExpectedValue,
+		VerifiedByInfobip: record.Verified,
+	}
+
+	var matched bool
+	switch check.RecordType {
+	case "TXT":
+		check.Found, matched, check.Err = v.checkTXT(ctx, record)
+	case "CNAME":
+		check.Found, matched, check.Err = v.checkCNAME(ctx, record)
+	case "MX":
+		check.Found, matched, check.Err = v.checkMX(ctx, record)
+	default:
+		check.Err = fmt.Errorf("%w %s", errUnsupportedRecordType, record.RecordType)
+	}
+
+	var dnsErr *net.DNSError
+	switch {
+	case errors.As(check.Err, &dnsErr) && dnsErr.IsNotFound:
+		check.Status, check.Err = RecordMissing, nil
+	case check.Err != nil:
+		check.Status = RecordLookupFailed
+	case matched:
+		check.Status = RecordMatched
+	case len(check.Found) == 0:
+		check.Status = RecordMissing
+	default:
+		check.Status = RecordMismatch
+	}
+	return check
+}
+
+func (v *DomainVerifier) checkTXT(ctx context.Context, record models.EmailDNSRecord) ([]string, bool, error) {
+	values, err := v.Resolver.LookupTXT(ctx, record.Name)
+	if err != nil {
+		return nil, false, err
+	}
+	expected := normalizeTXT(record.ExpectedValue)
+	purpose := recordPurpose(record)
+	var found []string
+	matched := false
+	for _, value := range values {
+		// Only the records of the same kind are relevant, e.g. the SPF record among verification tokens.
+		if purpose == PurposeSPF && !isSPF(value) {
+			continue
+		}
+		found = append(found, value)
+		matched = matched || normalizeTXT(value) == expected
+	}
+	return found, matched, nil
+}
+
+func (v *DomainVerifier) checkCNAME(ctx context.Context, record models.EmailDNSRecord) ([]string, bool, error) {
+	target, err := v.Resolver.LookupCNAME(ctx, record.Name)
+	if err != nil {
+		return nil, false, err
+	}
+	// Without a CNAME record, the canonical name is the name itself.
+	if sameHost(target, record.Name) {
+		return nil, false, nil
+	}
+	if sameHost(target, record.ExpectedValue) {
+		return []string{trimDot(target)}, true, nil
+	}
+	// The lookup follows the whole chain, so the expected target may itself be an alias of the resolved name.
+	expectedTarget, err := v.Resolver.LookupCNAME(ctx, record.ExpectedValue)
+	matched := err == nil && sameHost(target, expectedTarget)
+	return []string{trimDot(target)}, matched, nil
+}
+
+func (v *DomainVerifier) checkMX(ctx context.Context, record models.EmailDNSRecord) ([]string, bool, error) {
+	records, err := v.Resolver.LookupMX(ctx, record.Name)
+	if err != nil {
+		return nil, false, err
+	}
+	_, expectedHost := parseMX(record.ExpectedValue)
+	found := make([]string, 0, len(records))
+	matched := false
+	for _, mx := range records {
+		found = append(found, fmt.Sprintf("%d %s", mx.Pref, trimDot(mx.Host)))
+		matched = matched || sameHost(mx.Host, expectedHost)
+	}
+	return found, matched, nil
+}
+
+func recordPurpose(record models.EmailDNSRecord) RecordPurpose {
+	name := strings.ToLower(record.Name)
+	value := strings.ToLower(strings.Trim(record.ExpectedValue, `"`))
+	switch strings.ToUpper(record.RecordType) {
+	case "TXT":
+		switch {
+		case isSPF(value):
+			return PurposeSPF
+		case strings.Contains(name, "._domainkey.") || strings.HasPrefix(value, "v=dkim1"):
+			return PurposeDKIM
+		case strings.HasPrefix(name, "_dmarc."):
+			return PurposeDMARC
+		}
+	case "CNAME":
+		if strings.Contains(name, "track") || strings.Contains(value, "track") {
+			return PurposeTracking
+		}
+		return PurposeReturnPath
+	case "MX":
+		return PurposeReturnPath
+	}
+	return PurposeOther
+}
+
+func isSPF(value string) bool {
+	value = strings.ToLower(strings.TrimSpace(strings.Trim(value, `"`)))
+	return value == "v=spf1" || strings.HasPrefix(value, "v=spf1 ")
+}
+
+// normalizeTXT removes quotes and repeated whitespace, which don't change the meaning of the records checked.
+func normalizeTXT(value string) string {
+	return strings.Join(strings.Fields(strings.ReplaceAll(value, `"`, "")), " ")
+}
+
+// parseMX splits a value such as "10 mx.example.com" into priority and host. The priority is optional.
+func parseMX(value string) (uint16, string) {
+	fields := strings.Fields(value)
+	if len(fields) == 0 {
+		return defaultMXPriority, ""
+	}
+	var priority uint16
+	if len(fields) > 1 {
+		if _, err := fmt.Sscan(fields[0], &priority); err == nil {
+			return priority, fields[len(fields)-1]
+		}
+	}
+	return defaultMXPriority, fields[len(fields)-1]
+}
+
+func sameHost(a string, b string) bool {
+	return strings.EqualFold(trimDot(a), trimDot(b))
+}
+
+func trimDot(host string) string {
+	return strings.TrimSuffix(strings.TrimSpace(host), ".")
+}
+
+func fqdn(host string) string {
+	return trimDot(host) + "."
+}
+
+// quoteTXT quotes a TXT value, split into character strings of at most 255 bytes as required by DNS.
+func quoteTXT(value string) string {
+	value = strings.ReplaceAll(strings.Trim(value, `"`), `"`, `\"`)
+	var chunks []string
+	for len(value) > maxTXTStringLength {
+		cut := maxTXTStringLength
+		// Don't split escape sequences.
+		for cut > 0 && value[cut-1] == '\\' {
+			cut--
+		}
+		chunks = append(chunks, `"`+value[:cut]+`"`)
+		value = value[cut:]
+	}
+	chunks = append(chunks, `"`+value+`"`)
+	return strings.Join(chunks, " ")
+}
@@ -0,0 +1,204 @@
+package email
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/infobip-community/infobip-api-go-sdk/v3/internal"
+	"github.com/infobip-community/infobip-api-go-sdk/v3/pkg/infobip/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeResolver struct {
+	txt   map[string][]string
+	cname map[string]string
+	mx    map[string][]*net.MX
+}
+
+func notFound(name string) error {
+	return &net.DNSError{Err: "no such host", Name: name, IsNotFound: true}
+}
+
+func (r *fakeResolver) LookupTXT(_ context.Context, name string) ([]string, error) {
+	if values, ok := r.txt[name]; ok {
+		return values, nil
+	}
+	return nil, notFound(name)
+}
+
+func (r *fakeResolver) LookupCNAME(_ context.Context, host string) (string, error) {
+	if target, ok := r.cname[host]; ok {
+		return target, nil
+	}
+	return "", notFound(host)
+}
+
+func (r *fakeResolver) LookupMX(_ context.Context, name string) ([]*net.MX, error) {
+	if records, ok := r.mx[name]; ok {
+		return records, nil
+	}
+	return nil, notFound(name)
+}
+
+const testDomainResp = `{
+	"domainId": 1,
+	"domainName": "example.com",
+	"dnsRecords": [
+		{"recordType": "TXT", "name": "example.com", "expectedValue": "v=spf1 include:spf.infobip.com ~all"},
+		{"recordType": "TXT", "name": "s1._domainkey.example.com", "expectedValue": "v=DKIM1; k=rsa; p=MIGfMA0"},
+		{"recordType": "MX", "name": "bounce.example.com", "expectedValue": "10 mx.infobip.com"},
+		{"recordType": "CNAME", "name": "track.example.com", "expectedValue": "track.infobip.com"}
+	]
+}`
+
+func newVerifierServer(t *testing.T, verified *int) *Channel {
+	serv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/"+getDomainPath+"/example.com":
+			_, err := w.Write([]byte(testDomainResp))
+			assert.NoError(t, err)
+		case r.Method == http.MethodPost && r.URL.Path == "/"+verifyDomainPath+"/example.com/verify":
+			*verified++
+			w.WriteHeader(http.StatusAccepted)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, err := w.Write([]byte(`{"requestError": {"serviceException": {"text": "Domain not found"}}}`))
+			assert.NoError(t, err)
+		}
+	}))
+	t.Cleanup(serv.Close)
+	return &Channel{ReqHandler: internal.HTTPHandler{HTTPClient: http.Client{}, BaseURL: serv.URL, APIKey: "secret"}}
+}
+
+func matchingResolver() *fakeResolver {
+	return &fakeResolver{
+		txt: map[string][]string{
+			"example.com":               {"google-site-verification=abc", "v=spf1  include:spf.infobip.com ~all"},
+			"s1._domainkey.example.com": {`v=DKIM1; k=rsa; p=MIGfMA0`},
+		},
+		cname: map[string]string{"track.example.com": "track.infobip.com."},
+		mx:    map[string][]*net.MX{"bounce.example.com": {{Host: "MX.infobip.com.", Pref: 10}}},
+	}
+}
+
+func TestDomainVerifierVerify(t *testing.T) {
+	verified := 0
+	verifier := NewDomainVerifier(newVerifierServer(t, &verified), matchingResolver())
+
+	report, err := verifier.Verify(context.Background(), "example.com")
+
+	require.NoError(t, err)
+	assert.True(t, report.Matched())
+	assert.True(t, report.VerificationRequested)
+	assert.Equal(t, 1, verified)
+	purposes := make([]RecordPurpose, 0, len(report.Records))
+	for _, record := range report.Records {
+		purposes = append(purposes, record.Purpose)
+	}
+	assert.Equal(t, []RecordPurpose{PurposeSPF, PurposeDKIM, PurposeReturnPath, PurposeTracking}, purposes)
+	assert.Equal(t, []string{"v=spf1  include:spf.infobip.com ~all"}, report.Records[0].Found)
+	assert.Empty(t, report.ZoneFile())
+	assert.Contains(t, report.String(), "verification was requested")
+}
+
+func TestDomainVerifierMismatch(t *testing.T) {
+	verified := 0
+	resolver := matchingResolver()
+	resolver.txt["example.com"] = []string{"v=spf1 include:_spf.google.com ~all"}
+	delete(resolver.txt, "s1._domainkey.example.com")
+	resolver.cname["track.example.com"] = "track.example.com."
+	resolver.mx["bounce.example.com"] = []*net.MX{{Host: "mx.other.com.", Pref: 5}}
+	verifier := NewDomainVerifier(newVerifierServer(t, &verified), resolver)
+
+	report, err := verifier.Verify(context.Background(), "example.com")
+
+	require.NoError(t, err)
+	assert.False(t, report.Matched())
+	assert.False(t, report.VerificationRequested)
+	assert.Equal(t, 0, verified)
+	statuses := make([]RecordStatus, 0, len(report.Records))
+	for _, record := range report.Records {
+		statuses = append(statuses, record.Status)
+	}
+	assert.Equal(t, []RecordStatus{RecordMismatch, RecordMissing, RecordMismatch, RecordMissing}, statuses)
+	assert.Equal(t, "example.com. 3600 IN TXT \"v=spf1 include:spf.infobip.com ~all\"\n"+
+		"s1._domainkey.example.com. 3600 IN TXT \"v=DKIM1; k=rsa; p=MIGfMA0\"\n"+
+		"bounce.example.com. 3600 IN MX 10 mx.infobip.com.\n"+
+		"track.example.com. 3600 IN CNAME track.infobip.com.\n", report.ZoneFile())
+	assert.Contains(t, report.String(),
+		"[MISMATCH] SPF TXT example.com: merge v=spf1 include:spf.infobip.com ~all into the SPF record of example.com")
+	assert.Contains(t, report.String(), "[MISMATCH] RETURN_PATH MX bounce.example.com: change the MX record of "+
+		"bounce.example.com from 5 mx.other.com to 10 mx.infobip.com")
+}
+
+type failingResolver struct {
+	fakeResolver
+}
+
+func (r *failingResolver) LookupTXT(context.Context, string) ([]string, error) {
+	return nil, errors.New("i/o timeout")
+}
+
+func TestDomainVerifierLookupFailed(t *testing.T) {
+	verified := 0
+	verifier := NewDomainVerifier(newVerifierServer(t, &verified), &failingResolver{*matchingResolver()})
+
+	report, err := verifier.Verify(context.Background(), "example.com")
+
+	require.NoError(t, err)
+	assert.Equal(t, RecordLookupFailed, report.Records[0].Status)
+	assert.EqualError(t, report.Records[0].Err, "i/o timeout")
+	assert.Equal(t, RecordMatched, report.Records[2].Status)
+	assert.Equal(t, 0, verified)
+}
+
+func TestDomainVerifierUnknownDomain(t *testing.T) {
+	verified := 0
+	verifier := NewDomainVerifier(newVerifierServer(t, &verified), matchingResolver())
+
+	_, err := verifier.Verify(context.Background(), "unknown.com")
+
+	var apiErr *models.APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+	assert.EqualError(t, err, "request failed with status 404: Domain not found")
+}
+
+func TestDomainVerifierCNAMEChain(t *testing.T) {
+	resolver := &fakeResolver{cname: map[string]string{
+		"track.example.com": "edge.infobip.net.",
+		"track.infobip.com": "edge.infobip.net.",
+	}}
+	verifier := NewDomainVerifier(nil, resolver)
+
+	check := verifier.checkRecord(context.Background(), models.EmailDNSRecord{
+		RecordType: "cname", Name: "track.example.com", ExpectedValue: "track.infobip.com",
+	})
+
+	assert.Equal(t, RecordMatched, check.Status)
+	assert.Equal(t, []string{"edge.infobip.net"}, check.Found)
+}
+
+func TestQuoteTXT(t *testing.T) {
+	assert.Equal(t, `"v=spf1 -all"`, quoteTXT(`"v=spf1 -all"`))
+
+	long := "p=" + strings.Repeat("A", 300)
+	quoted := quoteTXT(long)
+	assert.Equal(t, `"p=`+strings.Repeat("A", 253)+`" "`+strings.Repeat("A", 47)+`"`, quoted)
+}
+
+func TestParseMX(t *testing.T) {
+	priority, host := parseMX("20 mx.infobip.com")
+	assert.Equal(t, uint16(20), priority)
+	assert.Equal(t, "mx.infobip.com", host)
+
+	priority, host = parseMX("mx.infobip.com")
+	assert.Equal(t, uint16(defaultMXPriority), priority)
+	assert.Equal(t, "mx.infobip.com", host)
+}
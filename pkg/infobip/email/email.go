@@ -2,6 +2,7 @@ package email
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/infobip-community/infobip-api-go-sdk/v3/internal"
@@ -23,8 +24,19 @@ const (
 	deleteDomainPath                  = "email/1/domains"
 	updateDomainTrackingPath          = "email/1/domains"
 	verifyDomainPath                  = "email/1/domains"
+	suppressionsPath                  = "email/1/suppressions"
+	getIPsPath                        = "email/1/ips"
+	domainIPsPath                     = "email/1/domain-ips"
+	ipManagementIPsPath               = "email/1/ip-management/ips"
+	ipManagementPoolsPath             = "email/1/ip-management/pools"
+	ipManagementDomainsPath           = "email/1/ip-management/domains"
+	templatesPath                     = "email/1/templates"
 )
 
+// ErrUnsupportedClient is returned when an email client lacks a method kept out of the Email interface, such as
+// GetSuppressions of Suppressions.
+var ErrUnsupportedClient = errors.New("email client does not support the method")
+
 type Channel struct {
 	ReqHandler internal.HTTPHandler
 }
@@ -93,6 +105,111 @@ type Email interface {
 		respDetails models.ResponseDetails, err error)
 }
 
+// Suppressions manages the suppression lists of the domains of the account. Channel implements it; it is kept out
+// of Email so that other implementations of Email don't have to implement it.
+type Suppressions interface {
+	// GetSuppressions returns a page of the suppressed addresses of a domain for a suppression type.
+	GetSuppressions(ctx context.Context, queryParams models.GetEmailSuppressionsParams) (
+		resp models.GetEmailSuppressionsResponse, respDetails models.ResponseDetails, err error)
+
+	// AddSuppressions adds addresses to the suppression lists of the provided domains.
+	AddSuppressions(ctx context.Context, req models.EmailSuppressionsRequest) (
+		respDetails models.ResponseDetails, err error)
+
+	// DeleteSuppressions removes addresses from the suppression lists of the provided domains.
+	DeleteSuppressions(ctx context.Context, req models.EmailSuppressionsRequest) (
+		respDetails models.ResponseDetails, err error)
+}
+
+// IPManagement manages the dedicated IPs and IP pools of the account and assigns them to domains. Channel
+// implements it, e.g. client.Email.(email.IPManagement).
+type IPManagement interface {
+	// GetIPs returns all IPs of the account with their status and the number of domains using them.
+	GetIPs(ctx context.Context) (
+		resp models.GetEmailIPsResponse, respDetails models.ResponseDetails, err error)
+
+	// GetDomainIPs returns the IPs assigned to a domain.
+	GetDomainIPs(ctx context.Context, domainName string) (
+		resp models.GetEmailIPsResponse, respDetails models.ResponseDetails, err error)
+
+	// AssignIPToDomain assigns a dedicated IP of the account to a domain.
+	AssignIPToDomain(ctx context.Context, req models.AssignEmailIPRequest) (
+		resp models.AssignEmailIPResponse, respDetails models.ResponseDetails, err error)
+
+	// UnassignIPFromDomain removes an IP from a domain. Domains keep at least one IP.
+	UnassignIPFromDomain(ctx context.Context, domainName string, ipAddress string) (
+		respDetails models.ResponseDetails, err error)
+
+	// GetIP returns the details of a dedicated IP, including the pools it belongs to.
+	GetIP(ctx context.Context, ipID string) (
+		resp models.EmailIPDetails, respDetails models.ResponseDetails, err error)
+
+	// GetIPPools returns the IP pools of the account.
+	GetIPPools(ctx context.Context) (
+		resp []models.EmailIPPool, respDetails models.ResponseDetails, err error)
+
+	// CreateIPPool creates an empty IP pool.
+	CreateIPPool(ctx context.Context, req models.CreateEmailIPPoolRequest) (
+		resp models.EmailIPPool, respDetails models.ResponseDetails, err error)
+
+	// GetIPPool returns an IP pool with its IPs.
+	GetIPPool(ctx context.Context, poolID string) (
+		resp models.EmailIPPoolDetails, respDetails models.ResponseDetails, err error)
+
+	// DeleteIPPool deletes an IP pool, unassigning it from all domains.
+	DeleteIPPool(ctx context.Context, poolID string) (
+		respDetails models.ResponseDetails, err error)
+
+	// AddIPToPool adds a dedicated IP to an IP pool.
+	AddIPToPool(ctx context.Context, poolID string, req models.AddEmailIPToPoolRequest) (
+		respDetails models.ResponseDetails, err error)
+
+	// RemoveIPFromPool removes an IP from an IP pool.
+	RemoveIPFromPool(ctx context.Context, poolID string, ipID string) (
+		respDetails models.ResponseDetails, err error)
+
+	// GetDomainIPPools returns the IP pools assigned to a domain, by the DomainID of its EmailDomain.
+	GetDomainIPPools(ctx context.Context, domainID int64) (
+		resp models.EmailDomainIPPools, respDetails models.ResponseDetails, err error)
+
+	// AssignIPPoolToDomain assigns an IP pool to a domain, by the DomainID of its EmailDomain, with a priority.
+	AssignIPPoolToDomain(ctx context.Context, domainID int64, req models.AssignEmailIPPoolRequest) (
+		respDetails models.ResponseDetails, err error)
+
+	// UpdateDomainIPPoolPriority changes the priority of an IP pool assigned to a domain.
+	UpdateDomainIPPoolPriority(
+		ctx context.Context, domainID int64, poolID string, req models.UpdateEmailIPPoolPriorityRequest) (
+		respDetails models.ResponseDetails, err error)
+
+	// UnassignIPPoolFromDomain removes an IP pool from a domain.
+	UnassignIPPoolFromDomain(ctx context.Context, domainID int64, poolID string) (
+		respDetails models.ResponseDetails, err error)
+}
+
+// Templates manages the email templates used by EmailMsg.TemplateID. Channel implements it; Email itself is left
+// unchanged for its other implementations.
+type Templates interface {
+	// GetTemplates returns a page of the email templates of the account.
+	GetTemplates(ctx context.Context, queryParams models.GetEmailTemplatesParams) (
+		resp models.GetEmailTemplatesResponse, respDetails models.ResponseDetails, err error)
+
+	// GetTemplate returns the email template with the ID used in EmailMsg.TemplateID.
+	GetTemplate(ctx context.Context, templateID int64) (
+		resp models.EmailTemplate, respDetails models.ResponseDetails, err error)
+
+	// CreateTemplate creates an email template.
+	CreateTemplate(ctx context.Context, req models.CreateEmailTemplateRequest) (
+		resp models.EmailTemplate, respDetails models.ResponseDetails, err error)
+
+	// UpdateTemplate replaces the content and defaults of an email template.
+	UpdateTemplate(ctx context.Context, templateID int64, req models.UpdateEmailTemplateRequest) (
+		resp models.EmailTemplate, respDetails models.ResponseDetails, err error)
+
+	// DeleteTemplate deletes an email template.
+	DeleteTemplate(ctx context.Context, templateID int64) (
+		respDetails models.ResponseDetails, err error)
+}
+
 func (email *Channel) Send(
 	ctx context.Context,
 	msg models.EmailMsg,
@@ -238,3 +355,223 @@ func (email *Channel) VerifyDomain(
 		fmt.Sprint(verifyDomainPath, "/", domainName, "/verify"))
 	return respDetails, err
 }
+
+func (email *Channel) GetSuppressions(
+	ctx context.Context,
+	queryParams models.GetEmailSuppressionsParams,
+) (resp models.GetEmailSuppressionsResponse, respDetails models.ResponseDetails, err error) {
+	if err = queryParams.Validate(); err != nil {
+		return resp, respDetails, err
+	}
+	params := []internal.QueryParameter{
+		{Name: "domainName", Value: queryParams.DomainName},
+		{Name: "type", Value: queryParams.Type},
+		{Name: "emailAddress", Value: queryParams.EmailAddress},
+		{Name: "createdDateFrom", Value: queryParams.CreatedDateFrom},
+		{Name: "createdDateTo", Value: queryParams.CreatedDateTo},
+		{Name: "page", Value: fmt.Sprint(queryParams.Page)},
+	}
+	if queryParams.Size > 0 {
+		params = append(params, internal.QueryParameter{Name: "size", Value: fmt.Sprint(queryParams.Size)})
+	}
+	respDetails, err = email.ReqHandler.GetRequest(ctx, &resp, suppressionsPath, params)
+	return resp, respDetails, err
+}
+
+func (email *Channel) AddSuppressions(
+	ctx context.Context,
+	req models.EmailSuppressionsRequest,
+) (respDetails models.ResponseDetails, err error) {
+	respDetails, err = email.ReqHandler.PostJSONReq(ctx, &req, nil, suppressionsPath)
+	return respDetails, err
+}
+
+func (email *Channel) DeleteSuppressions(
+	ctx context.Context,
+	req models.EmailSuppressionsRequest,
+) (respDetails models.ResponseDetails, err error) {
+	respDetails, err = email.ReqHandler.DeleteJSONReq(ctx, &req, nil, suppressionsPath, nil)
+	return respDetails, err
+}
+
+func (email *Channel) GetIPs(
+	ctx context.Context,
+) (resp models.GetEmailIPsResponse, respDetails models.ResponseDetails, err error) {
+	respDetails, err = email.ReqHandler.GetRequest(ctx, &resp, getIPsPath, nil)
+	return resp, respDetails, err
+}
+
+func (email *Channel) GetDomainIPs(
+	ctx context.Context,
+	domainName string,
+) (resp models.GetEmailIPsResponse, respDetails models.ResponseDetails, err error) {
+	params := []internal.QueryParameter{{Name: "domainName", Value: domainName}}
+	respDetails, err = email.ReqHandler.GetRequest(ctx, &resp, domainIPsPath, params)
+	return resp, respDetails, err
+}
+
+func (email *Channel) AssignIPToDomain(
+	ctx context.Context,
+	req models.AssignEmailIPRequest,
+) (resp models.AssignEmailIPResponse, respDetails models.ResponseDetails, err error) {
+	respDetails, err = email.ReqHandler.PostJSONReq(ctx, &req, &resp, domainIPsPath)
+	return resp, respDetails, err
+}
+
+func (email *Channel) UnassignIPFromDomain(
+	ctx context.Context,
+	domainName string,
+	ipAddress string,
+) (respDetails models.ResponseDetails, err error) {
+	params := []internal.QueryParameter{
+		{Name: "domainName", Value: domainName},
+		{Name: "ipAddress", Value: ipAddress},
+	}
+	respDetails, err = email.ReqHandler.DeleteRequest(ctx, domainIPsPath, params)
+	return respDetails, err
+}
+
+func (email *Channel) GetIP(
+	ctx context.Context,
+	ipID string,
+) (resp models.EmailIPDetails, respDetails models.ResponseDetails, err error) {
+	respDetails, err = email.ReqHandler.GetRequest(ctx, &resp, fmt.Sprint(ipManagementIPsPath, "/", ipID), nil)
+	return resp, respDetails, err
+}
+
+func (email *Channel) GetIPPools(
+	ctx context.Context,
+) (resp []models.EmailIPPool, respDetails models.ResponseDetails, err error) {
+	respDetails, err = email.ReqHandler.GetRequest(ctx, &resp, ipManagementPoolsPath, nil)
+	return resp, respDetails, err
+}
+
+func (email *Channel) CreateIPPool(
+	ctx context.Context,
+	req models.CreateEmailIPPoolRequest,
+) (resp models.EmailIPPool, respDetails models.ResponseDetails, err error) {
+	respDetails, err = email.ReqHandler.PostJSONReq(ctx, &req, &resp, ipManagementPoolsPath)
+	return resp, respDetails, err
+}
+
+func (email *Channel) GetIPPool(
+	ctx context.Context,
+	poolID string,
+) (resp models.EmailIPPoolDetails, respDetails models.ResponseDetails, err error) {
+	respDetails, err = email.ReqHandler.GetRequest(ctx, &resp, fmt.Sprint(ipManagementPoolsPath, "/", poolID), nil)
+	return resp, respDetails, err
+}
+
+func (email *Channel) DeleteIPPool(
+	ctx context.Context,
+	poolID string,
+) (respDetails models.ResponseDetails, err error) {
+	respDetails, err = email.ReqHandler.DeleteRequest(ctx, fmt.Sprint(ipManagementPoolsPath, "/", poolID), nil)
+	return respDetails, err
+}
+
+func (email *Channel) AddIPToPool(
+	ctx context.Context,
+	poolID string,
+	req models.AddEmailIPToPoolRequest,
+) (respDetails models.ResponseDetails, err error) {
+	respDetails, err = email.ReqHandler.PostJSONReq(ctx, &req, nil, fmt.Sprint(ipManagementPoolsPath, "/", poolID, "/ips"))
+	return respDetails, err
+}
+
+func (email *Channel) RemoveIPFromPool(
+	ctx context.Context,
+	poolID string,
+	ipID string,
+) (respDetails models.ResponseDetails, err error) {
+	respDetails, err = email.ReqHandler.DeleteRequest(ctx,
+		fmt.Sprint(ipManagementPoolsPath, "/", poolID, "/ips/", ipID), nil)
+	return respDetails, err
+}
+
+func (email *Channel) GetDomainIPPools(
+	ctx context.Context,
+	domainID int64,
+) (resp models.EmailDomainIPPools, respDetails models.ResponseDetails, err error) {
+	respDetails, err = email.ReqHandler.GetRequest(ctx, &resp, fmt.Sprint(ipManagementDomainsPath, "/", domainID), nil)
+	return resp, respDetails, err
+}
+
+func (email *Channel) AssignIPPoolToDomain(
+	ctx context.Context,
+	domainID int64,
+	req models.AssignEmailIPPoolRequest,
+) (respDetails models.ResponseDetails, err error) {
+	respDetails, err = email.ReqHandler.PostJSONReq(ctx, &req, nil,
+		fmt.Sprint(ipManagementDomainsPath, "/", domainID, "/pools"))
+	return respDetails, err
+}
+
+func (email *Channel) UpdateDomainIPPoolPriority(
+	ctx context.Context,
+	domainID int64,
+	poolID string,
+	req models.UpdateEmailIPPoolPriorityRequest,
+) (respDetails models.ResponseDetails, err error) {
+	respDetails, err = email.ReqHandler.PutJSONReq(ctx, &req, nil,
+		fmt.Sprint(ipManagementDomainsPath, "/", domainID, "/pools/", poolID), nil)
+	return respDetails, err
+}
+
+func (email *Channel) UnassignIPPoolFromDomain(
+	ctx context.Context,
+	domainID int64,
+	poolID string,
+) (respDetails models.ResponseDetails, err error) {
+	respDetails, err = email.ReqHandler.DeleteRequest(ctx,
+		fmt.Sprint(ipManagementDomainsPath, "/", domainID, "/pools/", poolID), nil)
+	return respDetails, err
+}
+
+func (email *Channel) GetTemplates(
+	ctx context.Context,
+	queryParams models.GetEmailTemplatesParams,
+) (resp models.GetEmailTemplatesResponse, respDetails models.ResponseDetails, err error) {
+	if err = queryParams.Validate(); err != nil {
+		return resp, respDetails, err
+	}
+	params := []internal.QueryParameter{{Name: "page", Value: fmt.Sprint(queryParams.Page)}}
+	if queryParams.Size > 0 {
+		params = append(params, internal.QueryParameter{Name: "size", Value: fmt.Sprint(queryParams.Size)})
+	}
+	respDetails, err = email.ReqHandler.GetRequest(ctx, &resp, templatesPath, params)
+	return resp, respDetails, err
+}
+
+func (email *Channel) GetTemplate(
+	ctx context.Context,
+	templateID int64,
+) (resp models.EmailTemplate, respDetails models.ResponseDetails, err error) {
+	respDetails, err = email.ReqHandler.GetRequest(ctx, &resp, fmt.Sprint(templatesPath, "/", templateID), nil)
+	return resp, respDetails, err
+}
+
+func (email *Channel) CreateTemplate(
+	ctx context.Context,
+	req models.CreateEmailTemplateRequest,
+) (resp models.EmailTemplate, respDetails models.ResponseDetails, err error) {
+	respDetails, err = email.ReqHandler.PostJSONReq(ctx, &req, &resp, templatesPath)
+	return resp, respDetails, err
+}
+
+func (email *Channel) UpdateTemplate(
+	ctx context.Context,
+	templateID int64,
+	req models.UpdateEmailTemplateRequest,
+) (resp models.EmailTemplate, respDetails models.ResponseDetails, err error) {
+	respDetails, err = email.ReqHandler.PutJSONReq(ctx, &req, &resp, fmt.Sprint(templatesPath, "/", templateID), nil)
+	return resp, respDetails, err
+}
+
+func (email *Channel) DeleteTemplate(
+	ctx context.Context,
+	templateID int64,
+) (respDetails models.ResponseDetails, err error) {
+	respDetails, err = email.ReqHandler.DeleteRequest(ctx, fmt.Sprint(templatesPath, "/", templateID), nil)
+	return respDetails, err
+}
@@ -0,0 +1,202 @@
+package email
+
+import (
+	"html"
+	"strings"
+	"unicode"
+)
+
+// Line breaks around block elements: paragraphs are separated by an empty line.
+const (
+	lineBreak      = 1
+	paragraphBreak = 2
+)
+
+// blockBreak returns the number of line breaks before and after a block element.
+func blockBreak(tag string) int {
+	switch tag {
+	case "p", "h1", "h2", "h3", "h4", "h5", "h6", "blockquote", "pre", "table", "ul", "ol":
+		return paragraphBreak
+	case "br", "div", "tr", "hr", "li", "section", "article", "header", "footer":
+		return lineBreak
+	}
+	return 0
+}
+
+// skippedElement reports whether the content of the element is not text to show.
+func skippedElement(tag string) bool {
+	return tag == "script" || tag == "style" || tag == "head"
+}
+
+// HTMLToText converts an HTML body to a plain-text alternative. Block elements start new lines, list items are
+// bulleted, links are followed by their URL, and scripts, styles and the head are dropped. Placeholders such as
+// {{name}} are kept as they are.
+func HTMLToText(source string) string {
+	converter := htmlTextConverter{}
+	for len(source) > 0 {
+		start := strings.IndexByte(source, '<')
+		if start < 0 {
+			converter.text(source)
+			break
+		}
+		converter.text(source[:start])
+		source = converter.tag(source[start:])
+	}
+	return converter.result()
+}
+
+type htmlTextConverter struct {
+	out       strings.Builder
+	href      string
+	linkStart int
+}
+
+// text writes text content with whitespace collapsed, as browsers render it.
+func (c *htmlTextConverter) text(content string) {
+	content = html.UnescapeString(content)
+	if strings.TrimLeftFunc(content, unicode.IsSpace) != content {
+		c.space()
+	}
+	fields := strings.Fields(content)
+	if len(fields) == 0 {
+		return
+	}
+	c.out.WriteString(strings.Join(fields, " "))
+	if strings.TrimRightFunc(content, unicode.IsSpace) != content {
+		c.space()
+	}
+}
+
+// space separates words, unless the output is empty or already ends with a separator.
+func (c *htmlTextConverter) space() {
+	out := c.out.String()
+	if out != "" && !strings.HasSuffix(out, " ") && !strings.HasSuffix(out, "\n") {
+		c.out.WriteString(" ")
+	}
+}
+
+// breakLines ends the output with at least count line breaks, unless it is empty.
+func (c *htmlTextConverter) breakLines(count int) {
+	out := c.out.String()
+	if strings.TrimSpace(out) == "" {
+		return
+	}
+	trailing := strings.Count(out[len(strings.TrimRight(out, " \n")):], "\n")
+	for ; trailing < count; trailing++ {
+		c.out.WriteString("\n")
+	}
+}
+
+// tag handles the markup at the start of source and returns the rest of it.
+func (c *htmlTextConverter) tag(source string) string {
+	if strings.HasPrefix(source, "<!--") {
+		end := strings.Index(source, "-->")
+		if end < 0 {
+			return ""
+		}
+		return source[end+len("-->"):]
+	}
+	end := strings.IndexByte(source, '>')
+	if end < 0 {
+		return ""
+	}
+	markup, rest := source[1:end], source[end+1:]
+	closing := strings.HasPrefix(markup, "/")
+	name := strings.ToLower(strings.TrimPrefix(markup, "/"))
+	if i := strings.IndexAny(name, " \t\r\n/"); i >= 0 {
+		name = name[:i]
+	}
+
+	if !closing && skippedElement(name) {
+		return skipElement(rest, name)
+	}
+
+	switch {
+	case name == "li" && !closing:
+		c.breakLines(lineBreak)
+		c.out.WriteString("- ")
+	case name == "td" || name == "th":
+		c.space()
+	case name == "a" && !closing:
+		c.href, c.linkStart = attribute(markup, "href"), c.out.Len()
+	case name == "a":
+		c.closeLink()
+	default:
+		c.breakLines(blockBreak(name))
+	}
+	return rest
+}
+
+// skipElement returns the source after the end tag of the element.
+func skipElement(source string, name string) string {
+	closeAt := strings.Index(strings.ToLower(source), "</"+name)
+	if closeAt < 0 {
+		return ""
+	}
+	source = source[closeAt:]
+	if closeEnd := strings.IndexByte(source, '>'); closeEnd >= 0 {
+		return source[closeEnd+1:]
+	}
+	return ""
+}
+
+// closeLink writes the URL of the link after its text, unless the text already shows it.
+func (c *htmlTextConverter) closeLink() {
+	href := c.href
+	c.href = ""
+	if href == "" || strings.HasPrefix(href, "#") || strings.HasPrefix(href, "mailto:") {
+		return
+	}
+	linkText := c.out.String()[c.linkStart:]
+	if !strings.Contains(linkText, href) {
+		c.out.WriteString(" (" + href + ")")
+	}
+}
+
+// result trims every line and drops repeated empty lines.
+func (c *htmlTextConverter) result() string {
+	lines := strings.Split(c.out.String(), "\n")
+	kept := make([]string, 0, len(lines))
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		if line == "" && (len(kept) == 0 || kept[len(kept)-1] == "") {
+			continue
+		}
+		kept = append(kept, line)
+	}
+	return strings.TrimSpace(strings.Join(kept, "\n"))
+}
+
+// attribute returns the unescaped value of an attribute of a start tag, or an empty string.
+func attribute(markup string, name string) string {
+	lower := strings.ToLower(markup)
+	for from := 0; ; {
+		i := strings.Index(lower[from:], name)
+		if i < 0 {
+			return ""
+		}
+		i += from
+		from = i + len(name)
+		if i == 0 || !strings.ContainsAny(lower[i-1:i], " \t\r\n") {
+			continue
+		}
+		value := strings.TrimLeft(markup[from:], " \t\r\n")
+		if !strings.HasPrefix(value, "=") {
+			continue
+		}
+		value = strings.TrimLeft(value[1:], " \t\r\n")
+		if value == "" {
+			return ""
+		}
+		if quote := value[0]; quote == '"' || quote == '\'' {
+			if end := strings.IndexByte(value[1:], quote); end >= 0 {
+				return html.UnescapeString(value[1 : end+1])
+			}
+			return html.UnescapeString(value[1:])
+		}
+		if end := strings.IndexAny(value, " \t\r\n"); end >= 0 {
+			value = value[:end]
+		}
+		return html.UnescapeString(value)
+	}
+}
@@ -0,0 +1,63 @@
+package email
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTMLToText(t *testing.T) {
+	tests := []struct {
+		name     string
+		html     string
+		expected string
+	}{
+		{
+			name:     "text",
+			html:     "Hello   world",
+			expected: "Hello world",
+		},
+		{
+			name:     "paragraphs and line breaks",
+			html:     "<P>First\n  paragraph</P><p>Second<br/>line</p>",
+			expected: "First paragraph\n\nSecond\nline",
+		},
+		{
+			name:     "head, styles and scripts are dropped",
+			html:     "<html><head><title>T</title><style>p {}</style></head><body><script>x()</script>Hi</body></html>",
+			expected: "Hi",
+		},
+		{
+			name:     "lists",
+			html:     "<ul><li>One</li><li>Two</li></ul>After",
+			expected: "- One\n- Two\n\nAfter",
+		},
+		{
+			name: "links",
+			html: `<a href='https://example.com/a?b=1&amp;c=2'>Open</a> ` +
+				`<a href="https://example.com">https://example.com</a>`,
+			expected: "Open (https://example.com/a?b=1&c=2) https://example.com",
+		},
+		{
+			name:     "anchors and mailto links",
+			html:     `<a href="#top">Top</a> <a href="mailto:help@example.com">Help</a>`,
+			expected: "Top Help",
+		},
+		{
+			name:     "entities, comments and inline elements",
+			html:     "<!-- <p>hidden</p> --><b>Tom</b> &amp; <i>Jerry</i>&nbsp;{{name}}",
+			expected: "Tom & Jerry {{name}}",
+		},
+		{
+			name:     "table cells",
+			html:     "<table><tr><td>Item</td><td>1</td></tr><tr><td>Total</td><td>1</td></tr></table>",
+			expected: "Item 1\nTotal 1",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, HTMLToText(tc.html))
+		})
+	}
+}
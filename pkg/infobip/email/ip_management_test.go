@@ -0,0 +1,192 @@
+package email
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/infobip-community/infobip-api-go-sdk/v3/internal"
+	"github.com/infobip-community/infobip-api-go-sdk/v3/pkg/infobip/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recordedReq struct {
+	method string
+	uri    string
+	body   string
+}
+
+func newIPManagementServer(t *testing.T, responses map[string]string) (*Channel, *[]recordedReq) {
+	var reqs []recordedReq
+	serv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(r.Body)
+		assert.NoError(t, err)
+		reqs = append(reqs, recordedReq{method: r.Method, uri: r.URL.RequestURI(), body: string(body)})
+
+		resp, ok := responses[r.Method+" "+r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		_, err = w.Write([]byte(resp))
+		assert.NoError(t, err)
+	}))
+	t.Cleanup(serv.Close)
+	return &Channel{ReqHandler: internal.HTTPHandler{HTTPClient: http.Client{}, BaseURL: serv.URL}}, &reqs
+}
+
+func TestDomainIPs(t *testing.T) {
+	ipsResp := `{"result": [` +
+		`{"ipAddress": "11.11.11.1", "dedicated": true, "assignedDomainCount": 1, "status": "ASSIGNABLE"}]}`
+	email, reqs := newIPManagementServer(t, map[string]string{
+		"GET /email/1/ips":         ipsResp,
+		"GET /email/1/domain-ips":  ipsResp,
+		"POST /email/1/domain-ips": `{"result": "OK"}`,
+	})
+	ctx := context.Background()
+	expectedIPs := []models.EmailIP{
+		{IPAddress: "11.11.11.1", Dedicated: true, AssignedDomainCount: 1, Status: "ASSIGNABLE"},
+	}
+
+	ips, respDetails, err := email.GetIPs(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, respDetails.HTTPResponse.StatusCode)
+	assert.Equal(t, expectedIPs, ips.Result)
+
+	ips, _, err = email.GetDomainIPs(ctx, "example.com")
+	require.NoError(t, err)
+	assert.Equal(t, expectedIPs, ips.Result)
+
+	assigned, _, err := email.AssignIPToDomain(ctx, models.AssignEmailIPRequest{
+		DomainName: "example.com", IPAddress: "11.11.11.1",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "OK", assigned.Result)
+
+	respDetails, err = email.UnassignIPFromDomain(ctx, "example.com", "11.11.11.1")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, respDetails.HTTPResponse.StatusCode)
+
+	assert.Equal(t, []recordedReq{
+		{method: http.MethodGet, uri: "/email/1/ips"},
+		{method: http.MethodGet, uri: "/email/1/domain-ips?domainName=example.com"},
+		{method: http.MethodPost, uri: "/email/1/domain-ips",
+			body: `{"domainName":"example.com","ipAddress":"11.11.11.1"}`},
+		{method: http.MethodDelete, uri: "/email/1/domain-ips?domainName=example.com&ipAddress=11.11.11.1"},
+	}, *reqs)
+}
+
+func TestIPPools(t *testing.T) {
+	email, reqs := newIPManagementServer(t, map[string]string{
+		"GET /email/1/ip-management/ips/ip-1": `{"id": "ip-1", "ip": "11.11.11.1", ` +
+			`"pools": [{"id": "pool-1", "name": "warmup"}]}`,
+		"GET /email/1/ip-management/pools":  `[{"id": "pool-1", "name": "warmup"}]`,
+		"POST /email/1/ip-management/pools": `{"id": "pool-1", "name": "warmup"}`,
+		"GET /email/1/ip-management/pools/pool-1": `{"id": "pool-1", "name": "warmup", ` +
+			`"ips": [{"id": "ip-1", "ip": "11.11.11.1"}]}`,
+	})
+	ctx := context.Background()
+
+	ip, _, err := email.GetIP(ctx, "ip-1")
+	require.NoError(t, err)
+	assert.Equal(t, models.EmailIPDetails{
+		ID: "ip-1", IP: "11.11.11.1", Pools: []models.EmailIPPool{{ID: "pool-1", Name: "warmup"}},
+	}, ip)
+
+	pools, _, err := email.GetIPPools(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []models.EmailIPPool{{ID: "pool-1", Name: "warmup"}}, pools)
+
+	pool, _, err := email.CreateIPPool(ctx, models.CreateEmailIPPoolRequest{Name: "warmup"})
+	require.NoError(t, err)
+	assert.Equal(t, "pool-1", pool.ID)
+
+	_, err = email.AddIPToPool(ctx, pool.ID, models.AddEmailIPToPoolRequest{IPID: "ip-1"})
+	require.NoError(t, err)
+
+	details, _, err := email.GetIPPool(ctx, pool.ID)
+	require.NoError(t, err)
+	require.Len(t, details.IPs, 1)
+	assert.Equal(t, "11.11.11.1", details.IPs[0].IP)
+
+	_, err = email.RemoveIPFromPool(ctx, pool.ID, "ip-1")
+	require.NoError(t, err)
+	respDetails, err := email.DeleteIPPool(ctx, pool.ID)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, respDetails.HTTPResponse.StatusCode)
+
+	assert.Equal(t, []recordedReq{
+		{method: http.MethodGet, uri: "/email/1/ip-management/ips/ip-1"},
+		{method: http.MethodGet, uri: "/email/1/ip-management/pools"},
+		{method: http.MethodPost, uri: "/email/1/ip-management/pools", body: `{"name":"warmup"}`},
+		{method: http.MethodPost, uri: "/email/1/ip-management/pools/pool-1/ips", body: `{"ipId":"ip-1"}`},
+		{method: http.MethodGet, uri: "/email/1/ip-management/pools/pool-1"},
+		{method: http.MethodDelete, uri: "/email/1/ip-management/pools/pool-1/ips/ip-1"},
+		{method: http.MethodDelete, uri: "/email/1/ip-management/pools/pool-1"},
+	}, *reqs)
+}
+
+func TestDomainIPPools(t *testing.T) {
+	email, reqs := newIPManagementServer(t, map[string]string{
+		"GET /email/1/domains/example.com": `{"domainId": 42, "domainName": "example.com"}`,
+		"GET /email/1/ip-management/domains/42": `{"id": 42, "name": "example.com", ` +
+			`"pools": [{"id": "pool-1", "name": "warmup", "priority": 0}, {"id": "pool-2", "name": "main", "priority": 1}]}`,
+	})
+	ctx := context.Background()
+
+	domain, pools, err := DomainIPPools(ctx, email, "example.com")
+	require.NoError(t, err)
+	assert.Equal(t, int64(42), domain.DomainID)
+	require.Len(t, pools.Pools, 2)
+	assert.Equal(t, "main", pools.Pools[1].Name)
+	assert.Equal(t, 1, pools.Pools[1].Priority)
+
+	_, err = email.AssignIPPoolToDomain(ctx, domain.DomainID,
+		models.AssignEmailIPPoolRequest{PoolID: "pool-3", Priority: 2})
+	require.NoError(t, err)
+	_, err = email.UpdateDomainIPPoolPriority(ctx, domain.DomainID, "pool-3",
+		models.UpdateEmailIPPoolPriorityRequest{Priority: 0})
+	require.NoError(t, err)
+	_, err = email.UnassignIPPoolFromDomain(ctx, domain.DomainID, "pool-1")
+	require.NoError(t, err)
+
+	assert.Equal(t, []recordedReq{
+		{method: http.MethodGet, uri: "/email/1/domains/example.com"},
+		{method: http.MethodGet, uri: "/email/1/ip-management/domains/42"},
+		{method: http.MethodPost, uri: "/email/1/ip-management/domains/42/pools",
+			body: `{"poolId":"pool-3","priority":2}`},
+		{method: http.MethodPut, uri: "/email/1/ip-management/domains/42/pools/pool-3", body: `{"priority":0}`},
+		{method: http.MethodDelete, uri: "/email/1/ip-management/domains/42/pools/pool-1"},
+	}, *reqs)
+}
+
+func TestDomainIPPoolsUnknownDomain(t *testing.T) {
+	verified := 0
+	_, _, err := DomainIPPools(context.Background(), newVerifierServer(t, &verified), "unknown.com")
+
+	var apiErr *models.APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+
+	_, _, err = DomainIPPools(context.Background(), struct{ Email }{newVerifierServer(t, &verified)}, "example.com")
+	assert.ErrorIs(t, err, ErrUnsupportedClient)
+}
+
+func TestIPManagementInvalidRequests(t *testing.T) {
+	email := Channel{ReqHandler: internal.HTTPHandler{HTTPClient: http.Client{}, BaseURL: "nonexistent"}}
+	ctx := context.Background()
+
+	_, _, err := email.AssignIPToDomain(ctx, models.AssignEmailIPRequest{DomainName: "example.com", IPAddress: "ip"})
+	assert.Error(t, err)
+	_, _, err = email.CreateIPPool(ctx, models.CreateEmailIPPoolRequest{})
+	assert.Error(t, err)
+	_, err = email.AddIPToPool(ctx, "pool-1", models.AddEmailIPToPoolRequest{})
+	assert.Error(t, err)
+	_, err = email.AssignIPPoolToDomain(ctx, 42, models.AssignEmailIPPoolRequest{Priority: 1})
+	assert.Error(t, err)
+	_, err = email.UpdateDomainIPPoolPriority(ctx, 42, "pool-1", models.UpdateEmailIPPoolPriorityRequest{Priority: -1})
+	assert.Error(t, err)
+}
@@ -0,0 +1,29 @@
+package email
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/infobip-community/infobip-api-go-sdk/v3/pkg/infobip/models"
+)
+
+// DomainIPPools gets a domain by name and the IP pools assigned to it, since the IP management endpoints refer to
+// domains by their DomainID. The client must also implement IPManagement, as Channel does.
+func DomainIPPools(ctx context.Context, client Email, domainName string) (
+	models.GetEmailDomainResponse, models.EmailDomainIPPools, error,
+) {
+	ipManagement, ok := client.(IPManagement)
+	if !ok {
+		return models.GetEmailDomainResponse{}, models.EmailDomainIPPools{},
+			fmt.Errorf("%w: %T does not implement IPManagement", ErrUnsupportedClient, client)
+	}
+	domain, respDetails, err := client.GetDomain(ctx, domainName)
+	if err = models.CheckResponse(respDetails, err); err != nil {
+		return domain, models.EmailDomainIPPools{}, err
+	}
+	pools, respDetails, err := ipManagement.GetDomainIPPools(ctx, domain.DomainID)
+	if err = models.CheckResponse(respDetails, err); err != nil {
+		return domain, models.EmailDomainIPPools{}, err
+	}
+	return domain, pools, nil
+}
@@ -0,0 +1,229 @@
+package email
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/mail"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/infobip-community/infobip-api-go-sdk/v3/pkg/infobip/models"
+)
+
+const (
+	suppressionsPageSize      = 1000
+	defaultSuppressionsMaxAge = 5 * time.Minute
+)
+
+var ErrAllRecipientsSuppressed = errors.New("all recipients are suppressed")
+
+// SuppressedRecipient is a recipient removed from a message by SuppressionFilter, as written in the message, with
+// the suppression that matched it.
+type SuppressedRecipient struct {
+	Recipient   string
+	Suppression models.EmailSuppression
+}
+
+// SuppressionFilter removes suppressed addresses from EmailMsg.To and EmailMsg.AdditionalTo before sending.
+// Suppressions are looked up in the domain of the sender, for all suppression types. All the suppressions of the
+// domain are listed and kept for MaxAge, 5 minutes when zero. With a negative MaxAge, those of each recipient are
+// queried for every message instead, which takes one call per suppression type and recipient and so only suits
+// messages with few recipients. Cc and Bcc are left as they are. Client must also implement Suppressions, as
+// Channel does.
+type SuppressionFilter struct {
+	Client Email
+	MaxAge time.Duration
+
+	mu    sync.Mutex
+	cache map[string]suppressionSet
+	now   func() time.Time
+}
+
+type suppressionSet struct {
+	loadedAt  time.Time
+	addresses map[string]models.EmailSuppression
+}
+
+// NewSuppressionFilter returns a filter keeping the suppressions of each domain for maxAge, or querying those of the
+// recipients of each message when maxAge is negative.
+func NewSuppressionFilter(client Email, maxAge time.Duration) *SuppressionFilter {
+	return &SuppressionFilter{Client: client, MaxAge: maxAge}
+}
+
+// Filter returns msg without its suppressed recipients, and the recipients that were removed. When the first
+// recipient is removed, the next remaining one becomes EmailMsg.To. ErrAllRecipientsSuppressed is returned with the
+// removed recipients when none remains.
+func (f *SuppressionFilter) Filter(ctx context.Context, msg models.EmailMsg) (
+	models.EmailMsg, []SuppressedRecipient, error,
+) {
+	domain, err := SenderDomain(msg.From)
+	if err != nil {
+		return msg, nil, err
+	}
+	recipients := append([]string{msg.To}, msg.AdditionalTo...)
+	addresses := make([]string, len(recipients))
+	for i, recipient := range recipients {
+		addresses[i] = recipientAddress(recipient)
+	}
+	suppressed, err := f.suppressions(ctx, domain, addresses)
+	if err != nil {
+		return msg, nil, err
+	}
+
+	var kept []string
+	var removed []SuppressedRecipient
+	for i, recipient := range recipients {
+		if suppression, ok := suppressed[addresses[i]]; ok {
+			removed = append(removed, SuppressedRecipient{Recipient: recipient, Suppression: suppression})
+			continue
+		}
+		kept = append(kept, recipient)
+	}
+	if len(removed) == 0 {
+		return msg, nil, nil
+	}
+	if len(kept) == 0 {
+		return msg, removed, ErrAllRecipientsSuppressed
+	}
+	msg.To = kept[0]
+	msg.AdditionalTo = kept[1:]
+	return msg, removed, nil
+}
+
+// Send filters msg and sends it to the remaining recipients. Nothing is sent when all of them are suppressed.
+func (f *SuppressionFilter) Send(ctx context.Context, msg models.EmailMsg) (
+	resp models.SendEmailResponse, removed []SuppressedRecipient, respDetails models.ResponseDetails, err error,
+) {
+	msg, removed, err = f.Filter(ctx, msg)
+	if err != nil {
+		return resp, removed, respDetails, err
+	}
+	resp, respDetails, err = f.Client.Send(ctx, msg)
+	return resp, removed, respDetails, err
+}
+
+// Invalidate drops the cached suppressions of a domain, e.g. after adding or deleting suppressions.
+func (f *SuppressionFilter) Invalidate(domainName string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.cache, strings.ToLower(domainName))
+}
+
+func (f *SuppressionFilter) suppressions(
+	ctx context.Context, domainName string, recipients []string,
+) (map[string]models.EmailSuppression, error) {
+	maxAge := f.MaxAge
+	switch {
+	case maxAge < 0:
+		return f.recipientSuppressions(ctx, domainName, recipients)
+	case maxAge == 0:
+		maxAge = defaultSuppressionsMaxAge
+	}
+
+	now := time.Now
+	if f.now != nil {
+		now = f.now
+	}
+	f.mu.Lock()
+	cached, ok := f.cache[domainName]
+	f.mu.Unlock()
+	if ok && now().Sub(cached.loadedAt) < maxAge {
+		return cached.addresses, nil
+	}
+
+	addresses := map[string]models.EmailSuppression{}
+	params := models.GetEmailSuppressionsParams{DomainName: domainName}
+	if err := f.listSuppressions(ctx, params, addresses); err != nil {
+		return nil, err
+	}
+
+	f.mu.Lock()
+	if f.cache == nil {
+		f.cache = map[string]suppressionSet{}
+	}
+	f.cache[domainName] = suppressionSet{loadedAt: now(), addresses: addresses}
+	f.mu.Unlock()
+	return addresses, nil
+}
+
+// recipientSuppressions queries the suppressions of each recipient instead of listing those of the whole domain.
+func (f *SuppressionFilter) recipientSuppressions(
+	ctx context.Context, domainName string, recipients []string,
+) (map[string]models.EmailSuppression, error) {
+	addresses := map[string]models.EmailSuppression{}
+	queried := map[string]bool{}
+	for _, recipient := range recipients {
+		if queried[recipient] {
+			continue
+		}
+		queried[recipient] = true
+		params := models.GetEmailSuppressionsParams{DomainName: domainName, EmailAddress: recipient}
+		if err := f.listSuppressions(ctx, params, addresses); err != nil {
+			return nil, err
+		}
+	}
+	return addresses, nil
+}
+
+// listSuppressions adds the suppressions matching params, for all suppression types, to addresses.
+func (f *SuppressionFilter) listSuppressions(
+	ctx context.Context, params models.GetEmailSuppressionsParams, addresses map[string]models.EmailSuppression,
+) error {
+	client, ok := f.Client.(Suppressions)
+	if !ok {
+		return fmt.Errorf("%w: %T does not implement Suppressions", ErrUnsupportedClient, f.Client)
+	}
+	params.Size = suppressionsPageSize
+	for _, suppressionType := range []string{
+		models.EmailSuppressionBounce, models.EmailSuppressionComplaint, models.EmailSuppressionUnsubscribe,
+	} {
+		params.Type, params.Page = suppressionType, 0
+		for {
+			resp, respDetails, err := client.GetSuppressions(ctx, params)
+			if err = models.CheckResponse(respDetails, err); err != nil {
+				return err
+			}
+			for _, suppression := range resp.Results {
+				address := strings.ToLower(suppression.EmailAddress)
+				if _, ok := addresses[address]; !ok {
+					addresses[address] = suppression
+				}
+			}
+			if len(resp.Results) < params.Size {
+				break
+			}
+			params.Page++
+		}
+	}
+	return nil
+}
+
+// SenderDomain returns the lowercase domain of a From value such as "Jane <jane@example.com>".
+func SenderDomain(from string) (string, error) {
+	address, err := mail.ParseAddress(from)
+	if err != nil {
+		return "", fmt.Errorf("invalid sender %q: %w", from, err)
+	}
+	return strings.ToLower(address.Address[strings.LastIndex(address.Address, "@")+1:]), nil
+}
+
+// recipientAddress returns the lowercase address of a To value, which is an address or a JSON object with the
+// address and its placeholders.
+func recipientAddress(recipient string) string {
+	recipient = strings.TrimSpace(recipient)
+	if strings.HasPrefix(recipient, "{") {
+		var withPlaceholders struct {
+			To string `json:"to"`
+		}
+		if json.Unmarshal([]byte(recipient), &withPlaceholders) == nil {
+			recipient = withPlaceholders.To
+		}
+	}
+	if address, err := mail.ParseAddress(recipient); err == nil {
+		recipient = address.Address
+	}
+	return strings.ToLower(recipient)
+}
@@ -0,0 +1,174 @@
+package email
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/infobip-community/infobip-api-go-sdk/v3/internal"
+	"github.com/infobip-community/infobip-api-go-sdk/v3/pkg/infobip/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newSuppressionServer(t *testing.T, lists *int, suppressed map[string][]string) *Channel {
+	serv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/" + suppressionsPath:
+			*lists++
+			query := r.URL.Query()
+			if query.Get("domainName") != "example.com" {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			resp := models.GetEmailSuppressionsResponse{}
+			if query.Get("page") == "0" {
+				for _, address := range suppressed[query.Get("type")] {
+					if filter := query.Get("emailAddress"); filter != "" && !strings.EqualFold(filter, address) {
+						continue
+					}
+					resp.Results = append(resp.Results, models.EmailSuppression{
+						DomainName: "example.com", EmailAddress: address, Type: query.Get("type"),
+					})
+				}
+			}
+			assert.NoError(t, json.NewEncoder(w).Encode(resp))
+		case "/" + sendEmailPath:
+			assert.NoError(t, r.ParseMultipartForm(1<<20))
+			assert.Equal(t, []string{"john@example.com"}, r.MultipartForm.Value["to"])
+			_, err := w.Write([]byte(`{"bulkId": "bulk-1"}`))
+			assert.NoError(t, err)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(serv.Close)
+	return &Channel{ReqHandler: internal.HTTPHandler{HTTPClient: http.Client{}, BaseURL: serv.URL}}
+}
+
+func TestSuppressionFilterFilter(t *testing.T) {
+	lists := 0
+	client := newSuppressionServer(t, &lists, map[string][]string{
+		models.EmailSuppressionBounce:      {"Jane@Example.com"},
+		models.EmailSuppressionUnsubscribe: {"ann@example.com"},
+	})
+	filter := NewSuppressionFilter(client, -1)
+	msg := models.EmailMsg{
+		From:    "Shop <shop@Example.com>",
+		To:      `{"to":"jane@example.com","placeholders":{"name":"Jane"}}`,
+		Subject: "Hello",
+		AdditionalTo: []string{
+			"John <john@example.com>",
+			"ann@example.com",
+			"bob@example.com",
+		},
+	}
+
+	filtered, removed, err := filter.Filter(context.Background(), msg)
+
+	require.NoError(t, err)
+	assert.Equal(t, "John <john@example.com>", filtered.To)
+	assert.Equal(t, []string{"bob@example.com"}, filtered.AdditionalTo)
+	require.Len(t, removed, 2)
+	assert.Equal(t, msg.To, removed[0].Recipient)
+	assert.Equal(t, models.EmailSuppressionBounce, removed[0].Suppression.Type)
+	assert.Equal(t, "ann@example.com", removed[1].Recipient)
+	assert.Equal(t, models.EmailSuppressionUnsubscribe, removed[1].Suppression.Type)
+	assert.Equal(t, 12, lists)
+
+	_, _, err = filter.Filter(context.Background(), msg)
+	require.NoError(t, err)
+	assert.Equal(t, 24, lists)
+}
+
+func TestSuppressionFilterCache(t *testing.T) {
+	lists := 0
+	client := newSuppressionServer(t, &lists, nil)
+	filter := NewSuppressionFilter(client, time.Minute)
+	now := time.Date(2022, 5, 5, 12, 0, 0, 0, time.UTC)
+	filter.now = func() time.Time { return now }
+	msg := models.EmailMsg{From: "shop@example.com", To: "jane@example.com", Subject: "Hello"}
+
+	for i := 0; i < 2; i++ {
+		filtered, removed, err := filter.Filter(context.Background(), msg)
+		require.NoError(t, err)
+		assert.Equal(t, msg, filtered)
+		assert.Empty(t, removed)
+	}
+	assert.Equal(t, 3, lists)
+
+	now = now.Add(time.Minute)
+	_, _, err := filter.Filter(context.Background(), msg)
+	require.NoError(t, err)
+	assert.Equal(t, 6, lists)
+
+	filter.Invalidate("Example.com")
+	_, _, err = filter.Filter(context.Background(), msg)
+	require.NoError(t, err)
+	assert.Equal(t, 9, lists)
+}
+
+func TestSuppressionFilterDefaultMaxAge(t *testing.T) {
+	lists := 0
+	filter := NewSuppressionFilter(newSuppressionServer(t, &lists, nil), 0)
+	now := time.Date(2022, 5, 5, 12, 0, 0, 0, time.UTC)
+	filter.now = func() time.Time { return now }
+	msg := models.EmailMsg{
+		From: "shop@example.com", To: "jane@example.com", AdditionalTo: []string{"john@example.com"}, Subject: "Hello",
+	}
+
+	for i := 0; i < 2; i++ {
+		_, _, err := filter.Filter(context.Background(), msg)
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 3, lists)
+
+	now = now.Add(defaultSuppressionsMaxAge)
+	_, _, err := filter.Filter(context.Background(), msg)
+	require.NoError(t, err)
+	assert.Equal(t, 6, lists)
+}
+
+func TestSuppressionFilterSend(t *testing.T) {
+	lists := 0
+	client := newSuppressionServer(t, &lists, map[string][]string{
+		models.EmailSuppressionComplaint: {"jane@example.com"},
+	})
+	filter := NewSuppressionFilter(client, 0)
+
+	resp, removed, respDetails, err := filter.Send(context.Background(), models.EmailMsg{
+		From: "shop@example.com", To: "jane@example.com", AdditionalTo: []string{"john@example.com"}, Subject: "Hi",
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "bulk-1", resp.BulkID)
+	assert.Equal(t, http.StatusOK, respDetails.HTTPResponse.StatusCode)
+	require.Len(t, removed, 1)
+	assert.Equal(t, "jane@example.com", removed[0].Recipient)
+
+	_, removed, _, err = filter.Send(context.Background(), models.EmailMsg{
+		From: "shop@example.com", To: "jane@example.com", Subject: "Hi",
+	})
+	assert.ErrorIs(t, err, ErrAllRecipientsSuppressed)
+	assert.Len(t, removed, 1)
+}
+
+func TestSuppressionFilterErrors(t *testing.T) {
+	lists := 0
+	filter := NewSuppressionFilter(newSuppressionServer(t, &lists, nil), 0)
+
+	_, _, err := filter.Filter(context.Background(), models.EmailMsg{From: "not an address", To: "jane@example.com"})
+	assert.Error(t, err)
+
+	_, _, err = filter.Filter(context.Background(), models.EmailMsg{From: "shop@other.com", To: "jane@example.com"})
+	var apiErr *models.APIError
+	require.ErrorAs(t, err, &apiErr)
+
+	filter.Client = struct{ Email }{filter.Client}
+	_, _, err = filter.Filter(context.Background(), models.EmailMsg{From: "shop@example.com", To: "jane@example.com"})
+	assert.ErrorIs(t, err, ErrUnsupportedClient)
+}
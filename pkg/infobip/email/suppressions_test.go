@@ -0,0 +1,121 @@
+package email
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/infobip-community/infobip-api-go-sdk/v3/internal"
+	"github.com/infobip-community/infobip-api-go-sdk/v3/pkg/infobip/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetSuppressionsValidReq(t *testing.T) {
+	apiKey := "apiKey"
+	rawJSONResp := []byte(`
+		{
+		  "results": [
+			{
+			  "domainName": "example.com",
+			  "emailAddress": "jane@example.com",
+			  "type": "BOUNCE",
+			  "createdDate": "2022-05-05T17:32:28.777+01:00",
+			  "reason": "550 5.1.1 Mailbox does not exist"
+			}
+		  ],
+		  "paging": {
+			"page": 1,
+			"size": 100
+		  }
+		}
+	`)
+
+	var expectedResp models.GetEmailSuppressionsResponse
+	err := json.Unmarshal(rawJSONResp, &expectedResp)
+	require.NoError(t, err)
+
+	serv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodGet, r.Method)
+		assert.True(t, strings.HasSuffix(r.URL.Path, suppressionsPath))
+		assert.Equal(t, fmt.Sprint("App ", apiKey), r.Header.Get("Authorization"))
+		assert.Equal(t, "domainName=example.com&page=1&size=100&type=BOUNCE", r.URL.RawQuery)
+
+		_, servErr := w.Write(rawJSONResp)
+		assert.Nil(t, servErr)
+	}))
+	defer serv.Close()
+
+	var email Suppressions = &Channel{ReqHandler: internal.HTTPHandler{
+		HTTPClient: http.Client{},
+		BaseURL:    serv.URL,
+		APIKey:     apiKey,
+	}}
+
+	resp, respDetails, err := email.GetSuppressions(context.Background(), models.GetEmailSuppressionsParams{
+		DomainName: "example.com",
+		Type:       models.EmailSuppressionBounce,
+		Size:       100,
+		Page:       1,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, expectedResp, resp)
+	assert.Equal(t, http.StatusOK, respDetails.HTTPResponse.StatusCode)
+	assert.Equal(t, models.ErrorDetails{}, respDetails.ErrorResponse)
+}
+
+func TestGetSuppressionsInvalidParams(t *testing.T) {
+	email := Channel{ReqHandler: internal.HTTPHandler{HTTPClient: http.Client{}, BaseURL: "nonexistent"}}
+
+	_, respDetails, err := email.GetSuppressions(context.Background(), models.GetEmailSuppressionsParams{
+		DomainName: "example.com",
+		Type:       "SPAM",
+	})
+
+	require.Error(t, err)
+	assert.Equal(t, models.ResponseDetails{}, respDetails)
+}
+
+func TestAddAndDeleteSuppressions(t *testing.T) {
+	req := models.EmailSuppressionsRequest{Suppressions: []models.EmailSuppressionsEntry{
+		{
+			DomainName:   "example.com",
+			EmailAddress: []string{"jane@example.com", "john@example.com"},
+			Type:         models.EmailSuppressionUnsubscribe,
+		},
+	}}
+
+	var methods []string
+	serv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		methods = append(methods, r.Method)
+		assert.True(t, strings.HasSuffix(r.URL.Path, suppressionsPath))
+		parsedBody, servErr := ioutil.ReadAll(r.Body)
+		assert.Nil(t, servErr)
+
+		var receivedReq models.EmailSuppressionsRequest
+		servErr = json.Unmarshal(parsedBody, &receivedReq)
+		assert.Nil(t, servErr)
+		assert.Equal(t, req, receivedReq)
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer serv.Close()
+
+	email := Channel{ReqHandler: internal.HTTPHandler{HTTPClient: http.Client{}, BaseURL: serv.URL}}
+
+	respDetails, err := email.AddSuppressions(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, respDetails.HTTPResponse.StatusCode)
+
+	respDetails, err = email.DeleteSuppressions(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, respDetails.HTTPResponse.StatusCode)
+
+	assert.Equal(t, []string{http.MethodPost, http.MethodDelete}, methods)
+}
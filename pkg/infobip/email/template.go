@@ -0,0 +1,108 @@
+package email
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/infobip-community/infobip-api-go-sdk/v3/pkg/infobip/models"
+)
+
+var (
+	ErrNoTemplate       = errors.New("message has no template ID")
+	ErrTemplateMismatch = errors.New("message uses another template")
+)
+
+// TemplatePlaceholders returns the sorted placeholders of the template: those it declares and those used in its
+// subject, HTML and text.
+func TemplatePlaceholders(template models.EmailTemplate) []string {
+	names := map[string]bool{}
+	for _, name := range template.Placeholders {
+		names[name] = true
+	}
+	for _, name := range Placeholders(template.Subject + template.HTML + template.Text) {
+		names[name] = true
+	}
+
+	sorted := make([]string, 0, len(names))
+	for name := range names {
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+	return sorted
+}
+
+// CheckTemplateMsg makes sure a message using the template supplies every placeholder of the template for each
+// recipient, from the placeholders of the recipient in To or AdditionalTo, or from DefaultPlaceholders.
+func CheckTemplateMsg(template models.EmailTemplate, msg models.EmailMsg) error {
+	if msg.TemplateID == 0 {
+		return ErrNoTemplate
+	}
+	if int64(msg.TemplateID) != template.ID {
+		return fmt.Errorf("%w: %d instead of %d", ErrTemplateMismatch, msg.TemplateID, template.ID)
+	}
+
+	defaults := map[string]interface{}{}
+	if msg.DefaultPlaceholders != "" {
+		if err := json.Unmarshal([]byte(msg.DefaultPlaceholders), &defaults); err != nil {
+			return fmt.Errorf("invalid default placeholders: %w", err)
+		}
+	}
+
+	used := TemplatePlaceholders(template)
+	for _, to := range append([]string{msg.To}, msg.AdditionalTo...) {
+		address, placeholders, err := parseRecipient(to)
+		if err != nil {
+			return err
+		}
+		var missing []string
+		for _, name := range used {
+			if _, ok := placeholders[name]; ok {
+				continue
+			}
+			if _, ok := defaults[name]; !ok {
+				missing = append(missing, name)
+			}
+		}
+		if len(missing) > 0 {
+			return &MissingPlaceholdersError{Recipient: address, Placeholders: missing}
+		}
+	}
+	return nil
+}
+
+// CheckMsgTemplate gets the template of the message and checks the message with CheckTemplateMsg. The client must
+// also implement Templates, as Channel does.
+func CheckMsgTemplate(ctx context.Context, client Email, msg models.EmailMsg) error {
+	if msg.TemplateID == 0 {
+		return ErrNoTemplate
+	}
+	templates, ok := client.(Templates)
+	if !ok {
+		return fmt.Errorf("%w: %T does not implement Templates", ErrUnsupportedClient, client)
+	}
+	template, respDetails, err := templates.GetTemplate(ctx, int64(msg.TemplateID))
+	if err = models.CheckResponse(respDetails, err); err != nil {
+		return err
+	}
+	return CheckTemplateMsg(template, msg)
+}
+
+// parseRecipient splits a To value, which is an address or a JSON object with the address and its placeholders.
+func parseRecipient(recipient string) (string, map[string]interface{}, error) {
+	recipient = strings.TrimSpace(recipient)
+	if !strings.HasPrefix(recipient, "{") {
+		return recipient, nil, nil
+	}
+	var withPlaceholders struct {
+		To           string                 `json:"to"`
+		Placeholders map[string]interface{} `json:"placeholders"`
+	}
+	if err := json.Unmarshal([]byte(recipient), &withPlaceholders); err != nil {
+		return "", nil, fmt.Errorf("invalid recipient %q: %w", recipient, err)
+	}
+	return withPlaceholders.To, withPlaceholders.Placeholders, nil
+}
@@ -0,0 +1,83 @@
+package email
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/infobip-community/infobip-api-go-sdk/v3/pkg/infobip/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemplatePlaceholders(t *testing.T) {
+	template := models.EmailTemplate{
+		Subject:      "Order {{order}} shipped",
+		HTML:         "<p>Hi {{ name }}</p>",
+		Text:         "Hi {{name}}, track it at {{trackingUrl}}",
+		Placeholders: []string{"order", "coupon"},
+	}
+
+	assert.Equal(t, []string{"coupon", "name", "order", "trackingUrl"}, TemplatePlaceholders(template))
+}
+
+func TestCheckTemplateMsg(t *testing.T) {
+	template := models.EmailTemplate{ID: 200, Subject: "Order {{order}}", Placeholders: []string{"name", "order"}}
+	msg := models.EmailMsg{
+		From:                "shop@example.com",
+		To:                  `{"to":"jane@example.com","placeholders":{"name":"Jane","order":42}}`,
+		AdditionalTo:        []string{`{"to":"john@example.com","placeholders":{"order":"43"}}`},
+		TemplateID:          200,
+		DefaultPlaceholders: `{"name":"there"}`,
+	}
+	require.NoError(t, CheckTemplateMsg(template, msg))
+
+	msg.DefaultPlaceholders = ""
+	err := CheckTemplateMsg(template, msg)
+	var missingErr *MissingPlaceholdersError
+	require.ErrorAs(t, err, &missingErr)
+	assert.Equal(t, "john@example.com", missingErr.Recipient)
+	assert.Equal(t, []string{"name"}, missingErr.Placeholders)
+
+	err = CheckTemplateMsg(template, models.EmailMsg{To: "jane@example.com", TemplateID: 200})
+	require.ErrorAs(t, err, &missingErr)
+	assert.Equal(t, "jane@example.com", missingErr.Recipient)
+	assert.Equal(t, []string{"name", "order"}, missingErr.Placeholders)
+}
+
+func TestCheckTemplateMsgErrors(t *testing.T) {
+	template := models.EmailTemplate{ID: 200, Subject: "Hi"}
+
+	assert.ErrorIs(t, CheckTemplateMsg(template, models.EmailMsg{To: "jane@example.com"}), ErrNoTemplate)
+	err := CheckTemplateMsg(template, models.EmailMsg{To: "jane@example.com", TemplateID: 201})
+	assert.ErrorIs(t, err, ErrTemplateMismatch)
+	assert.EqualError(t, err, "message uses another template: 201 instead of 200")
+	assert.Error(t, CheckTemplateMsg(template, models.EmailMsg{To: `{"to":`, TemplateID: 200}))
+	assert.Error(t, CheckTemplateMsg(template, models.EmailMsg{
+		To: "jane@example.com", TemplateID: 200, DefaultPlaceholders: "name=there",
+	}))
+}
+
+func TestCheckMsgTemplate(t *testing.T) {
+	email, _ := newTemplateServer(t, map[string]string{"GET /email/1/templates/200": testTemplateResp})
+	ctx := context.Background()
+
+	err := CheckMsgTemplate(ctx, email, models.EmailMsg{
+		To:         `{"to":"jane@example.com","placeholders":{"name":"Jane","order":"42"}}`,
+		TemplateID: 200,
+	})
+	require.NoError(t, err)
+
+	err = CheckMsgTemplate(ctx, email, models.EmailMsg{To: "jane@example.com", TemplateID: 200})
+	var missingErr *MissingPlaceholdersError
+	require.ErrorAs(t, err, &missingErr)
+
+	verified := 0
+	err = CheckMsgTemplate(ctx, newVerifierServer(t, &verified), models.EmailMsg{To: "jane@example.com", TemplateID: 1})
+	var apiErr *models.APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+
+	err = CheckMsgTemplate(ctx, struct{ Email }{email}, models.EmailMsg{To: "jane@example.com", TemplateID: 200})
+	assert.ErrorIs(t, err, ErrUnsupportedClient)
+}
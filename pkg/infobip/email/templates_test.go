@@ -0,0 +1,116 @@
+package email
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/infobip-community/infobip-api-go-sdk/v3/internal"
+	"github.com/infobip-community/infobip-api-go-sdk/v3/pkg/infobip/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testTemplateResp = `{
+	"id": 200,
+	"name": "Order shipped",
+	"from": "Shop <shop@example.com>",
+	"replyTo": "support@example.com",
+	"subject": "Order {{order}} shipped",
+	"html": "<p>Hi {{name}}</p>",
+	"placeholders": ["name", "order"]
+}`
+
+// newTemplateServer returns a channel whose server answers "METHOD /path" requests from responses, and 204 No
+// Content otherwise, and records the requests.
+func newTemplateServer(t *testing.T, responses map[string]string) (*Channel, *[]recordedReq) {
+	var reqs []recordedReq
+	serv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(r.Body)
+		assert.NoError(t, err)
+		reqs = append(reqs, recordedReq{method: r.Method, uri: r.URL.RequestURI(), body: string(body)})
+
+		resp, ok := responses[r.Method+" "+r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		_, err = w.Write([]byte(resp))
+		assert.NoError(t, err)
+	}))
+	t.Cleanup(serv.Close)
+	return &Channel{ReqHandler: internal.HTTPHandler{HTTPClient: http.Client{}, BaseURL: serv.URL}}, &reqs
+}
+
+func TestTemplatesCRUD(t *testing.T) {
+	email, reqs := newTemplateServer(t, map[string]string{
+		"GET /email/1/templates": `{"paging": {"page": 0, "size": 10, "totalPages": 1, "totalResults": 1}, ` +
+			`"results": [` + testTemplateResp + `]}`,
+		"GET /email/1/templates/200": testTemplateResp,
+		"POST /email/1/templates":    testTemplateResp,
+		"PUT /email/1/templates/200": testTemplateResp,
+	})
+	ctx := context.Background()
+	expected := models.EmailTemplate{
+		ID:           200,
+		Name:         "Order shipped",
+		From:         "Shop <shop@example.com>",
+		ReplyTo:      "support@example.com",
+		Subject:      "Order {{order}} shipped",
+		HTML:         "<p>Hi {{name}}</p>",
+		Placeholders: []string{"name", "order"},
+	}
+
+	templates, respDetails, err := email.GetTemplates(ctx, models.GetEmailTemplatesParams{Size: 10})
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, respDetails.HTTPResponse.StatusCode)
+	assert.Equal(t, 1, templates.Paging.TotalResults)
+	assert.Equal(t, []models.EmailTemplate{expected}, templates.Results)
+
+	template, _, err := email.GetTemplate(ctx, 200)
+	require.NoError(t, err)
+	assert.Equal(t, expected, template)
+
+	req := models.EmailTemplate{
+		Name: "Order shipped", From: "Shop <shop@example.com>", Subject: "Order {{order}} shipped",
+		HTML: "<p>Hi {{name}}</p>", Placeholders: []string{"name", "order"},
+	}
+	template, _, err = email.CreateTemplate(ctx, models.CreateEmailTemplateRequest(req))
+	require.NoError(t, err)
+	assert.Equal(t, int64(200), template.ID)
+
+	req.ReplyTo = "support@example.com"
+	template, _, err = email.UpdateTemplate(ctx, 200, models.UpdateEmailTemplateRequest(req))
+	require.NoError(t, err)
+	assert.Equal(t, "support@example.com", template.ReplyTo)
+
+	respDetails, err = email.DeleteTemplate(ctx, 200)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, respDetails.HTTPResponse.StatusCode)
+
+	body := `{"name":"Order shipped","from":"Shop \u003cshop@example.com\u003e","subject":"Order {{order}} shipped",` +
+		`"html":"\u003cp\u003eHi {{name}}\u003c/p\u003e","placeholders":["name","order"]}`
+	updateBody := `{"name":"Order shipped","from":"Shop \u003cshop@example.com\u003e","replyTo":"support@example.com",` +
+		`"subject":"Order {{order}} shipped","html":"\u003cp\u003eHi {{name}}\u003c/p\u003e","placeholders":["name","order"]}`
+	assert.Equal(t, []recordedReq{
+		{method: http.MethodGet, uri: "/email/1/templates?page=0&size=10"},
+		{method: http.MethodGet, uri: "/email/1/templates/200"},
+		{method: http.MethodPost, uri: "/email/1/templates", body: body},
+		{method: http.MethodPut, uri: "/email/1/templates/200", body: updateBody},
+		{method: http.MethodDelete, uri: "/email/1/templates/200"},
+	}, *reqs)
+}
+
+func TestTemplatesInvalidRequests(t *testing.T) {
+	email := Channel{ReqHandler: internal.HTTPHandler{HTTPClient: http.Client{}, BaseURL: "nonexistent"}}
+	ctx := context.Background()
+
+	_, _, err := email.GetTemplates(ctx, models.GetEmailTemplatesParams{Size: 101})
+	assert.Error(t, err)
+	_, _, err = email.CreateTemplate(ctx, models.CreateEmailTemplateRequest{Name: "No content", Subject: "Hi"})
+	assert.Error(t, err)
+	_, _, err = email.UpdateTemplate(ctx, 200, models.UpdateEmailTemplateRequest{Subject: "Hi", Text: "Hi"})
+	assert.Error(t, err)
+}
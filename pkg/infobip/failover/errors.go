@@ -0,0 +1,20 @@
+package failover
+
+import (
+	"fmt"
+	"strings"
+)
+
+// NotDeliveredError is returned by Send when the message was not delivered on any channel of the policy.
+type NotDeliveredError struct {
+	MessageID string
+	Attempts  []Attempt
+}
+
+func (e *NotDeliveredError) Error() string {
+	outcomes := make([]string, 0, len(e.Attempts))
+	for _, attempt := range e.Attempts {
+		outcomes = append(outcomes, fmt.Sprintf("%s: %s", attempt.Channel, attempt.Status))
+	}
+	return fmt.Sprintf("message %s not delivered (%s)", e.MessageID, strings.Join(outcomes, ", "))
+}
@@ -0,0 +1,349 @@
+// Package failover sends a message over an ordered list of channels, moving on to the next channel when the
+// delivery reports show that the message was not delivered in time. Unlike the SMS failover of WhatsApp template
+// and RCS messages, any channel can follow any other, and every attempt is recorded.
+package failover
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/infobip-community/infobip-api-go-sdk/v3/pkg/infobip/models"
+)
+
+type Channel string
+
+const (
+	WhatsApp Channel = "WHATSAPP"
+	RCS      Channel = "RCS"
+	SMS      Channel = "SMS"
+	Email    Channel = "EMAIL"
+)
+
+// AttemptStatus is the outcome of an attempt. Besides the final status groups of delivery reports, an attempt
+// can time out waiting for a final report, or fail to be sent at all.
+type AttemptStatus string
+
+const (
+	StatusDelivered     AttemptStatus = "DELIVERED"
+	StatusUndeliverable AttemptStatus = "UNDELIVERABLE"
+	StatusRejected      AttemptStatus = "REJECTED"
+	StatusExpired       AttemptStatus = "EXPIRED"
+	StatusTimedOut      AttemptStatus = "TIMED_OUT"
+	StatusFailed        AttemptStatus = "FAILED"
+)
+
+const (
+	defaultTimeout      = 5 * time.Minute
+	defaultPollInterval = 10 * time.Second
+	// earlyReportTTL is how long reports are kept when they arrive before the send call returned the ID of the
+	// message, or when they belong to messages of another sender.
+	earlyReportTTL = 10 * time.Minute
+	// defaultHistoryTTL is how long the attempts of a message are kept after its last attempt.
+	defaultHistoryTTL = time.Hour
+	messageIDBytes    = 16
+)
+
+// Message is a channel-neutral message. Each channel uses the destination it supports.
+type Message struct {
+	// ID identifies the message in the attempt history. A random ID is generated when empty.
+	ID string
+	// PhoneNumber is the destination on WhatsApp, RCS and SMS.
+	PhoneNumber string
+	// EmailAddress is the destination on email.
+	EmailAddress string
+	// Subject is the subject of emails.
+	Subject string
+	Text    string
+	// NotifyURL is the URL of the delivery report webhook, set on every attempt. Reports received there must be
+	// passed to HandleReport, e.g. with WebhookHandler.
+	NotifyURL string
+	// SMSRegional holds the regional parameters of SMS, such as the DLT parameters required for India.
+	SMSRegional *models.SMSRegional
+}
+
+// Step is a channel of a failover policy.
+type Step struct {
+	Channel Channel
+	// From is the sender on the channel: a WhatsApp or SMS number, an RCS agent or an email address.
+	From string
+	// Timeout is how long to wait for a final delivery report before moving on. Defaults to 5 minutes.
+	Timeout time.Duration
+}
+
+// Policy lists the channels to try, in order, e.g. WhatsApp, then RCS, then SMS, then email.
+type Policy []Step
+
+// Attempt is the sending of a message on one channel.
+type Attempt struct {
+	Channel Channel
+	From    string
+	// MessageID is the ID of the message sent on the channel, matched against delivery reports.
+	MessageID  string
+	SentAt     time.Time
+	FinishedAt time.Time
+	Status     AttemptStatus
+	// Report is the last delivery report received for the attempt, if any.
+	Report *Report
+	// Err is the error of FAILED attempts.
+	Err error
+}
+
+// Result is the outcome of Send. Channel is the channel on which the message was delivered, if any.
+type Result struct {
+	MessageID string
+	Delivered bool
+	Channel   Channel
+	Attempts  []Attempt
+}
+
+// Config configures a Sender.
+type Config struct {
+	Policy Policy
+	// Transports send messages on each channel of the policy, see ClientTransports.
+	Transports map[Channel]Transport
+	// Pollers fetch delivery reports when Run is used instead of, or along with, webhooks.
+	Pollers []Poller
+	// PollInterval is the time between two polls of Run. Defaults to 10 seconds.
+	PollInterval time.Duration
+	// OnAttempt is called after each attempt, e.g. to persist the attempt history.
+	OnAttempt func(messageID string, attempt Attempt)
+	// HistoryTTL is how long History keeps the attempts of a message after its last attempt. Defaults to 1 hour;
+	// use OnAttempt to keep attempts for longer.
+	HistoryTTL time.Duration
+	// OnPollError is called when a poller fails. Run keeps polling after errors.
+	OnPollError func(err error)
+}
+
+// Sender sends messages following the failover policy. Delivery reports are received with HandleReport, from
+// webhooks, or by Run from the pollers.
+type Sender struct {
+	config Config
+	now    func() time.Time
+
+	mu      sync.Mutex
+	waiters map[string]chan Report
+	early   map[string]earlyReport
+	history map[string]attemptHistory
+}
+
+type earlyReport struct {
+	report     Report
+	receivedAt time.Time
+}
+
+type attemptHistory struct {
+	attempts  []Attempt
+	updatedAt time.Time
+}
+
+func NewSender(config Config) *Sender {
+	if config.PollInterval <= 0 {
+		config.PollInterval = defaultPollInterval
+	}
+	if config.HistoryTTL <= 0 {
+		config.HistoryTTL = defaultHistoryTTL
+	}
+	return &Sender{
+		config:  config,
+		now:     time.Now,
+		waiters: map[string]chan Report{},
+		early:   map[string]earlyReport{},
+		history: map[string]attemptHistory{},
+	}
+}
+
+// Send tries the channels of the policy in order until the message is delivered. Each attempt waits for a final
+// delivery report until the timeout of the step. A NotDeliveredError is returned along with the result when the
+// message was not delivered on any channel.
+func (s *Sender) Send(ctx context.Context, msg Message) (Result, error) {
+	if len(s.config.Policy) == 0 {
+		return Result{}, errors.New("failover policy has no channels")
+	}
+	if msg.ID == "" {
+		id, err := newMessageID()
+		if err != nil {
+			return Result{}, err
+		}
+		msg.ID = id
+	}
+
+	result := Result{MessageID: msg.ID}
+	for _, step := range s.config.Policy {
+		attempt := s.attempt(ctx, step, msg)
+		result.Attempts = append(result.Attempts, attempt)
+		s.record(msg.ID, attempt)
+		if attempt.Status == StatusDelivered {
+			result.Delivered = true
+			result.Channel = step.Channel
+			return result, nil
+		}
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+	}
+	return result, &NotDeliveredError{MessageID: msg.ID, Attempts: result.Attempts}
+}
+
+// History returns the attempts made so far to deliver the message with the ID, unless they expired after
+// HistoryTTL.
+func (s *Sender) History(messageID string) []Attempt {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	history, ok := s.history[messageID]
+	if !ok || s.now().Sub(history.updatedAt) > s.config.HistoryTTL {
+		return nil
+	}
+	return append([]Attempt(nil), history.attempts...)
+}
+
+// HandleReport passes a delivery report to the attempt waiting for it.
+func (s *Sender) HandleReport(report Report) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if waiter, ok := s.waiters[report.MessageID]; ok {
+		select {
+		case waiter <- report:
+		case previous := <-waiter:
+			// The waiter has not read the previous report yet. Only the latest report matters, unless the
+			// previous one was final.
+			if _, final := finalStatus(previous.GroupName); final {
+				report = previous
+			}
+			waiter <- report
+		}
+		return
+	}
+
+	now := s.now()
+	for id, early := range s.early {
+		if now.Sub(early.receivedAt) > earlyReportTTL {
+			delete(s.early, id)
+		}
+	}
+	s.early[report.MessageID] = earlyReport{report: report, receivedAt: now}
+}
+
+// Run polls delivery reports until the context is done, passing them to HandleReport.
+func (s *Sender) Run(ctx context.Context) error {
+	ticker := time.NewTicker(s.config.PollInterval)
+	defer ticker.Stop()
+	for {
+		for _, poller := range s.config.Pollers {
+			reports, err := poller.Poll(ctx)
+			if err != nil && s.config.OnPollError != nil {
+				s.config.OnPollError(err)
+			}
+			for _, report := range reports {
+				s.HandleReport(report)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *Sender) attempt(ctx context.Context, step Step, msg Message) Attempt {
+	attempt := Attempt{Channel: step.Channel, From: step.From, SentAt: s.now()}
+	transport, ok := s.config.Transports[step.Channel]
+	if !ok {
+		return s.finish(attempt, StatusFailed, fmt.Errorf("no transport for channel %s", step.Channel))
+	}
+
+	messageID, err := transport.Send(ctx, step.From, msg)
+	if err != nil {
+		return s.finish(attempt, StatusFailed, err)
+	}
+	attempt.MessageID = messageID
+
+	reports := s.wait(messageID)
+	defer s.stopWaiting(messageID)
+
+	timeout := step.Timeout
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return s.finish(attempt, StatusFailed, ctx.Err())
+		case <-timer.C:
+			return s.finish(attempt, StatusTimedOut, nil)
+		case report := <-reports:
+			attempt.Report = &report
+			if status, final := finalStatus(report.GroupName); final {
+				return s.finish(attempt, status, nil)
+			}
+		}
+	}
+}
+
+func (s *Sender) finish(attempt Attempt, status AttemptStatus, err error) Attempt {
+	attempt.Status = status
+	attempt.Err = err
+	attempt.FinishedAt = s.now()
+	return attempt
+}
+
+// wait registers the attempt for the reports of the message, including a report received before.
+func (s *Sender) wait(messageID string) chan Report {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	reports := make(chan Report, 1)
+	if early, ok := s.early[messageID]; ok {
+		reports <- early.report
+		delete(s.early, messageID)
+	}
+	s.waiters[messageID] = reports
+	return reports
+}
+
+func (s *Sender) stopWaiting(messageID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.waiters, messageID)
+}
+
+func (s *Sender) record(messageID string, attempt Attempt) {
+	s.mu.Lock()
+	now := s.now()
+	for id, history := range s.history {
+		if now.Sub(history.updatedAt) > s.config.HistoryTTL {
+			delete(s.history, id)
+		}
+	}
+	history := s.history[messageID]
+	s.history[messageID] = attemptHistory{attempts: append(history.attempts, attempt), updatedAt: now}
+	s.mu.Unlock()
+	if s.config.OnAttempt != nil {
+		s.config.OnAttempt(messageID, attempt)
+	}
+}
+
+// finalStatus maps the status group of a report to the outcome of the attempt. PENDING and ACCEPTED reports are
+// not final.
+func finalStatus(groupName string) (AttemptStatus, bool) {
+	switch AttemptStatus(groupName) {
+	case StatusDelivered, StatusUndeliverable, StatusRejected, StatusExpired:
+		return AttemptStatus(groupName), true
+	}
+	return "", false
+}
+
+func newMessageID() (string, error) {
+	id := make([]byte, messageIDBytes)
+	if _, err := rand.Read(id); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(id), nil
+}
@@ -0,0 +1,168 @@
+package failover
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeTransport returns message IDs like SMS-1 and delivers the given reports right after sending.
+type fakeTransport struct {
+	channel Channel
+	sender  *Sender
+	reports []string
+	err     error
+
+	mu   sync.Mutex
+	sent []Message
+}
+
+func (f *fakeTransport) Send(_ context.Context, _ string, msg Message) (string, error) {
+	f.mu.Lock()
+	f.sent = append(f.sent, msg)
+	messageID := fmt.Sprintf("%s-%d", f.channel, len(f.sent))
+	f.mu.Unlock()
+	if f.err != nil {
+		return "", f.err
+	}
+	for _, groupName := range f.reports {
+		f.sender.HandleReport(Report{MessageID: messageID, GroupName: groupName})
+	}
+	return messageID, nil
+}
+
+func newTestSender(policy Policy, transports ...*fakeTransport) *Sender {
+	config := Config{Policy: policy, Transports: map[Channel]Transport{}}
+	sender := NewSender(config)
+	for _, transport := range transports {
+		transport.sender = sender
+		sender.config.Transports[transport.channel] = transport
+	}
+	return sender
+}
+
+func TestSendDeliveredOnFirstChannel(t *testing.T) {
+	whatsApp := &fakeTransport{channel: WhatsApp, reports: []string{"PENDING", "DELIVERED"}}
+	sms := &fakeTransport{channel: SMS}
+	sender := newTestSender(Policy{{Channel: WhatsApp}, {Channel: SMS}}, whatsApp, sms)
+
+	result, err := sender.Send(context.Background(), Message{ID: "order-1", PhoneNumber: "441134960001"})
+
+	require.NoError(t, err)
+	assert.True(t, result.Delivered)
+	assert.Equal(t, WhatsApp, result.Channel)
+	require.Len(t, result.Attempts, 1)
+	assert.Equal(t, StatusDelivered, result.Attempts[0].Status)
+	assert.Equal(t, "WHATSAPP-1", result.Attempts[0].MessageID)
+	assert.Empty(t, sms.sent)
+}
+
+func TestSendFailsOver(t *testing.T) {
+	whatsApp := &fakeTransport{channel: WhatsApp, reports: []string{"UNDELIVERABLE"}}
+	rcs := &fakeTransport{channel: RCS, err: errors.New("connection refused")}
+	sms := &fakeTransport{channel: SMS, reports: []string{"PENDING"}}
+	email := &fakeTransport{channel: Email, reports: []string{"DELIVERED"}}
+	policy := Policy{
+		{Channel: WhatsApp},
+		{Channel: RCS},
+		{Channel: SMS, Timeout: 10 * time.Millisecond},
+		{Channel: Email},
+	}
+	var recorded []Attempt
+	sender := newTestSender(policy, whatsApp, rcs, sms, email)
+	sender.config.OnAttempt = func(messageID string, attempt Attempt) {
+		assert.Equal(t, "order-2", messageID)
+		recorded = append(recorded, attempt)
+	}
+
+	result, err := sender.Send(context.Background(), Message{ID: "order-2", PhoneNumber: "441134960001"})
+
+	require.NoError(t, err)
+	assert.Equal(t, Email, result.Channel)
+	statuses := []AttemptStatus{}
+	for _, attempt := range result.Attempts {
+		statuses = append(statuses, attempt.Status)
+	}
+	assert.Equal(t, []AttemptStatus{StatusUndeliverable, StatusFailed, StatusTimedOut, StatusDelivered}, statuses)
+	assert.EqualError(t, result.Attempts[1].Err, "connection refused")
+	assert.Equal(t, "PENDING", result.Attempts[2].Report.GroupName)
+	assert.Equal(t, result.Attempts, sender.History("order-2"))
+	assert.Equal(t, result.Attempts, recorded)
+}
+
+func TestSendNotDelivered(t *testing.T) {
+	sms := &fakeTransport{channel: SMS, reports: []string{"REJECTED"}}
+	sender := newTestSender(Policy{{Channel: SMS}, {Channel: Email}}, sms)
+
+	result, err := sender.Send(context.Background(), Message{PhoneNumber: "441134960001"})
+
+	var notDelivered *NotDeliveredError
+	require.ErrorAs(t, err, &notDelivered)
+	assert.NotEmpty(t, result.MessageID)
+	assert.Equal(t, result.MessageID, notDelivered.MessageID)
+	require.Len(t, notDelivered.Attempts, 2)
+	assert.Equal(t, StatusRejected, notDelivered.Attempts[0].Status)
+	assert.Equal(t, StatusFailed, notDelivered.Attempts[1].Status)
+	assert.Contains(t, err.Error(), "SMS: REJECTED, EMAIL: FAILED")
+}
+
+func TestSendContextCanceled(t *testing.T) {
+	sms := &fakeTransport{channel: SMS}
+	email := &fakeTransport{channel: Email}
+	sender := newTestSender(Policy{{Channel: SMS}, {Channel: Email}}, sms, email)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	result, err := sender.Send(ctx, Message{PhoneNumber: "441134960001"})
+
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Len(t, result.Attempts, 1)
+	assert.Equal(t, StatusFailed, result.Attempts[0].Status)
+	assert.Empty(t, email.sent)
+}
+
+func TestHandleReportKeepsFinalReport(t *testing.T) {
+	sender := NewSender(Config{})
+	reports := sender.wait("SMS-1")
+	sender.HandleReport(Report{MessageID: "SMS-1", GroupName: "DELIVERED"})
+	sender.HandleReport(Report{MessageID: "SMS-1", GroupName: "PENDING"})
+
+	assert.Equal(t, "DELIVERED", (<-reports).GroupName)
+}
+
+func TestHandleReportDropsOldEarlyReports(t *testing.T) {
+	sender := NewSender(Config{})
+	now := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	sender.now = func() time.Time { return now }
+	sender.HandleReport(Report{MessageID: "SMS-1", GroupName: "DELIVERED"})
+	now = now.Add(time.Hour)
+	sender.HandleReport(Report{MessageID: "SMS-2", GroupName: "DELIVERED"})
+
+	assert.NotContains(t, sender.early, "SMS-1")
+	assert.Contains(t, sender.early, "SMS-2")
+}
+
+func TestHistoryExpires(t *testing.T) {
+	sender := NewSender(Config{HistoryTTL: time.Hour})
+	now := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	sender.now = func() time.Time { return now }
+	sender.record("order-1", Attempt{Channel: SMS, Status: StatusDelivered})
+	now = now.Add(30 * time.Minute)
+	sender.record("order-2", Attempt{Channel: SMS, Status: StatusDelivered})
+
+	assert.Len(t, sender.History("order-1"), 1)
+
+	now = now.Add(45 * time.Minute)
+	assert.Empty(t, sender.History("order-1"))
+	assert.Len(t, sender.History("order-2"), 1)
+
+	sender.record("order-3", Attempt{Channel: SMS, Status: StatusDelivered})
+	assert.NotContains(t, sender.history, "order-1")
+	assert.Contains(t, sender.history, "order-2")
+}
@@ -0,0 +1,135 @@
+package failover
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/infobip-community/infobip-api-go-sdk/v3/pkg/infobip"
+	"github.com/infobip-community/infobip-api-go-sdk/v3/pkg/infobip/email"
+	"github.com/infobip-community/infobip-api-go-sdk/v3/pkg/infobip/models"
+	"github.com/infobip-community/infobip-api-go-sdk/v3/pkg/infobip/sms"
+)
+
+// Report is a delivery report of a message sent on any channel.
+type Report struct {
+	MessageID string
+	// GroupName is the status group: PENDING, UNDELIVERABLE, DELIVERED, EXPIRED or REJECTED.
+	GroupName   string
+	Name        string
+	Description string
+	DoneAt      string
+}
+
+type reportPayload struct {
+	Results []struct {
+		MessageID string `json:"messageId"`
+		DoneAt    string `json:"doneAt"`
+		Status    struct {
+			GroupName   string `json:"groupName"`
+			Name        string `json:"name"`
+			Description string `json:"description"`
+		} `json:"status"`
+	} `json:"results"`
+}
+
+// ParseReports reads the payload of a delivery report webhook. WhatsApp, RCS, SMS and email reports share the
+// same format.
+func ParseReports(data []byte) ([]Report, error) {
+	var payload reportPayload
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return nil, err
+	}
+	reports := make([]Report, 0, len(payload.Results))
+	for _, result := range payload.Results {
+		reports = append(reports, Report{
+			MessageID:   result.MessageID,
+			GroupName:   result.Status.GroupName,
+			Name:        result.Status.Name,
+			Description: result.Status.Description,
+			DoneAt:      result.DoneAt,
+		})
+	}
+	return reports, nil
+}
+
+// WebhookHandler returns a handler for the delivery report webhooks of every channel, passing the reports to
+// HandleReport.
+func (s *Sender) WebhookHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		reports, err := ParseReports(body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		for _, report := range reports {
+			s.HandleReport(report)
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+}
+
+// Poller fetches new delivery reports. Each report is returned once.
+type Poller interface {
+	Poll(ctx context.Context) ([]Report, error)
+}
+
+// ClientPollers returns the pollers of the channels of the client with a delivery reports endpoint, SMS and
+// email. WhatsApp and RCS reports are only received with webhooks.
+func ClientPollers(client infobip.Client) []Poller {
+	return []Poller{&SMSPoller{Client: client.SMS}, &EmailPoller{Client: client.Email}}
+}
+
+type SMSPoller struct {
+	Client sms.SMS
+	// Limit is the maximum number of reports fetched by a poll.
+	Limit int
+}
+
+func (p *SMSPoller) Poll(ctx context.Context) ([]Report, error) {
+	resp, respDetails, err := p.Client.GetDeliveryReports(ctx, models.GetSMSDeliveryReportsParams{Limit: p.Limit})
+	if err = models.CheckResponse(respDetails, err); err != nil {
+		return nil, err
+	}
+	reports := make([]Report, 0, len(resp.Results))
+	for _, result := range resp.Results {
+		reports = append(reports, Report{
+			MessageID:   result.MessageID,
+			GroupName:   result.Status.GroupName,
+			Name:        result.Status.Name,
+			Description: result.Status.Description,
+			DoneAt:      result.DoneAt,
+		})
+	}
+	return reports, nil
+}
+
+type EmailPoller struct {
+	Client email.Email
+	// Limit is the maximum number of reports fetched by a poll.
+	Limit int
+}
+
+func (p *EmailPoller) Poll(ctx context.Context) ([]Report, error) {
+	resp, respDetails, err := p.Client.GetDeliveryReports(ctx, models.GetEmailDeliveryReportsParams{Limit: p.Limit})
+	if err = models.CheckResponse(respDetails, err); err != nil {
+		return nil, err
+	}
+	reports := make([]Report, 0, len(resp.Results))
+	for _, result := range resp.Results {
+		reports = append(reports, Report{
+			MessageID:   result.MessageID,
+			GroupName:   result.Status.GroupName,
+			Name:        result.Status.Name,
+			Description: result.Status.Description,
+			DoneAt:      result.DoneAt,
+		})
+	}
+	return reports, nil
+}
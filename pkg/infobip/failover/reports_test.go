@@ -0,0 +1,111 @@
+package failover
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/infobip-community/infobip-api-go-sdk/v3/internal"
+	"github.com/infobip-community/infobip-api-go-sdk/v3/pkg/infobip/sms"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const reportsPayload = `{
+	"results": [
+		{
+			"bulkId": "BULK-ID-123-xyz",
+			"messageId": "MESSAGE-ID-123-xyz",
+			"to": "441134960001",
+			"sentAt": "2022-03-01T10:00:00.000+0000",
+			"doneAt": "2022-03-01T10:00:01.000+0000",
+			"status": {
+				"groupId": 3,
+				"groupName": "DELIVERED",
+				"id": 5,
+				"name": "DELIVERED_TO_HANDSET",
+				"description": "Message delivered to handset"
+			}
+		}
+	]
+}`
+
+func TestParseReports(t *testing.T) {
+	reports, err := ParseReports([]byte(reportsPayload))
+
+	require.NoError(t, err)
+	assert.Equal(t, []Report{{
+		MessageID:   "MESSAGE-ID-123-xyz",
+		GroupName:   "DELIVERED",
+		Name:        "DELIVERED_TO_HANDSET",
+		Description: "Message delivered to handset",
+		DoneAt:      "2022-03-01T10:00:01.000+0000",
+	}}, reports)
+
+	_, err = ParseReports([]byte("not json"))
+	assert.Error(t, err)
+}
+
+func TestWebhookHandler(t *testing.T) {
+	sender := NewSender(Config{})
+	reports := sender.wait("MESSAGE-ID-123-xyz")
+
+	recorder := httptest.NewRecorder()
+	sender.WebhookHandler().ServeHTTP(recorder, httptest.NewRequest(
+		http.MethodPost, "/reports", strings.NewReader(reportsPayload)))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "DELIVERED", (<-reports).GroupName)
+
+	recorder = httptest.NewRecorder()
+	sender.WebhookHandler().ServeHTTP(recorder, httptest.NewRequest(
+		http.MethodPost, "/reports", strings.NewReader("{")))
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+}
+
+func TestRunPollsReports(t *testing.T) {
+	serv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.True(t, strings.HasSuffix(r.URL.Path, "sms/1/reports"))
+		_, err := w.Write([]byte(reportsPayload))
+		assert.NoError(t, err)
+	}))
+	defer serv.Close()
+	poller := &SMSPoller{Client: &sms.Channel{ReqHandler: internal.HTTPHandler{
+		HTTPClient: http.Client{},
+		BaseURL:    serv.URL,
+		APIKey:     "secret",
+	}}}
+	sender := NewSender(Config{Pollers: []Poller{poller}, PollInterval: time.Millisecond})
+	reports := sender.wait("MESSAGE-ID-123-xyz")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- sender.Run(ctx) }()
+
+	assert.Equal(t, "DELIVERED", (<-reports).GroupName)
+	cancel()
+	assert.ErrorIs(t, <-done, context.Canceled)
+}
+
+type failingPoller struct{}
+
+func (failingPoller) Poll(context.Context) ([]Report, error) {
+	return nil, errors.New("service unavailable")
+}
+
+func TestRunReportsPollErrors(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	var pollErr error
+	sender := NewSender(Config{Pollers: []Poller{failingPoller{}}, OnPollError: func(err error) {
+		pollErr = err
+		cancel()
+	}})
+
+	err := sender.Run(ctx)
+
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.EqualError(t, pollErr, "service unavailable")
+}
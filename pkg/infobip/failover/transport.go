@@ -0,0 +1,130 @@
+package failover
+
+import (
+	"context"
+	"errors"
+
+	"github.com/infobip-community/infobip-api-go-sdk/v3/pkg/infobip"
+	"github.com/infobip-community/infobip-api-go-sdk/v3/pkg/infobip/email"
+	"github.com/infobip-community/infobip-api-go-sdk/v3/pkg/infobip/models"
+	"github.com/infobip-community/infobip-api-go-sdk/v3/pkg/infobip/rcs"
+	"github.com/infobip-community/infobip-api-go-sdk/v3/pkg/infobip/sms"
+	"github.com/infobip-community/infobip-api-go-sdk/v3/pkg/infobip/whatsapp"
+)
+
+// Transport sends a message on one channel and returns the ID of the sent message, which identifies its delivery
+// reports.
+type Transport interface {
+	Send(ctx context.Context, from string, msg Message) (messageID string, err error)
+}
+
+var errNoMessageID = errors.New("response holds no message ID")
+
+// ClientTransports returns the transports of the WhatsApp, RCS, SMS and email channels of the client.
+func ClientTransports(client infobip.Client) map[Channel]Transport {
+	return map[Channel]Transport{
+		WhatsApp: &WhatsAppTransport{Client: client.WhatsApp},
+		RCS:      &RCSTransport{Client: client.RCS},
+		SMS:      &SMSTransport{Client: client.SMS},
+		Email:    &EmailTransport{Client: client.Email},
+	}
+}
+
+// WhatsAppTransport sends text messages, or the template when set. Text messages are only delivered within the
+// customer service window, so a template is usually needed when WhatsApp is the first channel.
+type WhatsAppTransport struct {
+	Client   whatsapp.WhatsApp
+	Template *models.TemplateMsgContent
+}
+
+func (t *WhatsAppTransport) Send(ctx context.Context, from string, msg Message) (string, error) {
+	common := models.MsgCommon{From: from, To: msg.PhoneNumber, CallbackData: msg.ID, NotifyURL: msg.NotifyURL}
+	if t.Template != nil {
+		resp, respDetails, err := t.Client.SendTemplate(ctx, models.WATemplateMsgs{
+			Messages: []models.TemplateMsg{{MsgCommon: common, Content: *t.Template}},
+		})
+		if err = models.CheckResponse(respDetails, err); err != nil {
+			return "", err
+		}
+		if len(resp.Messages) == 0 {
+			return "", errNoMessageID
+		}
+		return resp.Messages[0].MessageID, nil
+	}
+
+	resp, respDetails, err := t.Client.SendText(ctx, models.WATextMsg{
+		MsgCommon: common,
+		Content:   models.TextContent{Text: msg.Text},
+	})
+	if err = models.CheckResponse(respDetails, err); err != nil {
+		return "", err
+	}
+	return resp.MessageID, nil
+}
+
+type RCSTransport struct {
+	Client rcs.RCS
+}
+
+func (t *RCSTransport) Send(ctx context.Context, from string, msg Message) (string, error) {
+	resp, respDetails, err := t.Client.Send(ctx, models.RCSMsg{
+		From:         from,
+		To:           msg.PhoneNumber,
+		Content:      &models.RCSContent{Type: "TEXT", Text: msg.Text},
+		NotifyURL:    msg.NotifyURL,
+		CallbackData: msg.ID,
+	})
+	if err = models.CheckResponse(respDetails, err); err != nil {
+		return "", err
+	}
+	if len(resp.Messages) == 0 {
+		return "", errNoMessageID
+	}
+	return resp.Messages[0].MessageID, nil
+}
+
+type SMSTransport struct {
+	Client sms.SMS
+}
+
+func (t *SMSTransport) Send(ctx context.Context, from string, msg Message) (string, error) {
+	resp, respDetails, err := t.Client.Send(ctx, models.SendSMSRequest{
+		Messages: []models.SMSMsg{{
+			From:         from,
+			Destinations: []models.SMSDestination{{To: msg.PhoneNumber}},
+			Text:         msg.Text,
+			NotifyURL:    msg.NotifyURL,
+			CallbackData: msg.ID,
+			Regional:     msg.SMSRegional,
+		}},
+	})
+	if err = models.CheckResponse(respDetails, err); err != nil {
+		return "", err
+	}
+	if len(resp.Messages) == 0 {
+		return "", errNoMessageID
+	}
+	return resp.Messages[0].MessageID, nil
+}
+
+type EmailTransport struct {
+	Client email.Email
+}
+
+func (t *EmailTransport) Send(ctx context.Context, from string, msg Message) (string, error) {
+	resp, respDetails, err := t.Client.Send(ctx, models.EmailMsg{
+		From:         from,
+		To:           msg.EmailAddress,
+		Subject:      msg.Subject,
+		Text:         msg.Text,
+		NotifyURL:    msg.NotifyURL,
+		CallbackData: msg.ID,
+	})
+	if err = models.CheckResponse(respDetails, err); err != nil {
+		return "", err
+	}
+	if len(resp.Messages) == 0 {
+		return "", errNoMessageID
+	}
+	return resp.Messages[0].MessageID, nil
+}
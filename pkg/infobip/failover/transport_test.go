@@ -0,0 +1,98 @@
+package failover
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/infobip-community/infobip-api-go-sdk/v3/internal"
+	"github.com/infobip-community/infobip-api-go-sdk/v3/pkg/infobip/models"
+	"github.com/infobip-community/infobip-api-go-sdk/v3/pkg/infobip/sms"
+	"github.com/infobip-community/infobip-api-go-sdk/v3/pkg/infobip/whatsapp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestHandler(t *testing.T, path string, status int, resp string, check func(body []byte)) internal.HTTPHandler {
+	serv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.True(t, strings.HasSuffix(r.URL.Path, path))
+		body, err := ioutil.ReadAll(r.Body)
+		assert.NoError(t, err)
+		check(body)
+		w.WriteHeader(status)
+		_, err = w.Write([]byte(resp))
+		assert.NoError(t, err)
+	}))
+	t.Cleanup(serv.Close)
+	return internal.HTTPHandler{HTTPClient: http.Client{}, BaseURL: serv.URL, APIKey: "secret"}
+}
+
+func TestSMSTransport(t *testing.T) {
+	handler := newTestHandler(t, "sms/2/text/advanced", http.StatusOK,
+		`{"bulkId": "bulk", "messages": [{"messageId": "sms-id", "to": "441134960001"}]}`,
+		func(body []byte) {
+			var req models.SendSMSRequest
+			assert.NoError(t, json.Unmarshal(body, &req))
+			assert.Equal(t, "InfoSMS", req.Messages[0].From)
+			assert.Equal(t, "441134960001", req.Messages[0].Destinations[0].To)
+			assert.Equal(t, "order-1", req.Messages[0].CallbackData)
+			assert.Equal(t, "https://example.com/reports", req.Messages[0].NotifyURL)
+			require.NotNil(t, req.Messages[0].Regional)
+			assert.Equal(t, "some-template", req.Messages[0].Regional.IndiaDLT.ContentTemplateID)
+		})
+	transport := &SMSTransport{Client: &sms.Channel{ReqHandler: handler}}
+
+	messageID, err := transport.Send(context.Background(), "InfoSMS", Message{
+		ID: "order-1", PhoneNumber: "441134960001", Text: "Your order shipped", NotifyURL: "https://example.com/reports",
+		SMSRegional: &models.SMSRegional{
+			IndiaDLT: models.IndiaDLT{ContentTemplateID: "some-template", PrincipalEntityID: "some-entity"},
+		},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "sms-id", messageID)
+}
+
+func TestWhatsAppTransportTemplate(t *testing.T) {
+	handler := newTestHandler(t, "whatsapp/1/message/template", http.StatusOK,
+		`{"bulkId": "bulk", "messages": [{"messageId": "wa-id", "to": "441134960001"}]}`,
+		func(body []byte) {
+			var msgs models.WATemplateMsgs
+			assert.NoError(t, json.Unmarshal(body, &msgs))
+			assert.Equal(t, "order_shipped", msgs.Messages[0].Content.TemplateName)
+			assert.Equal(t, "order-1", msgs.Messages[0].CallbackData)
+		})
+	transport := &WhatsAppTransport{
+		Client: &whatsapp.Channel{ReqHandler: handler},
+		Template: &models.TemplateMsgContent{
+			TemplateName: "order_shipped",
+			TemplateData: models.TemplateData{Body: models.TemplateBody{Placeholders: []string{"42"}}},
+			Language:     "en",
+		},
+	}
+
+	messageID, err := transport.Send(context.Background(), "441134960000", Message{
+		ID: "order-1", PhoneNumber: "441134960001",
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "wa-id", messageID)
+}
+
+func TestTransportAPIError(t *testing.T) {
+	handler := newTestHandler(t, "whatsapp/1/message/text", http.StatusUnauthorized,
+		`{"requestError": {"serviceException": {"messageId": "UNAUTHORIZED", "text": "Invalid login details"}}}`,
+		func([]byte) {})
+	transport := &WhatsAppTransport{Client: &whatsapp.Channel{ReqHandler: handler}}
+
+	_, err := transport.Send(context.Background(), "441134960000", Message{PhoneNumber: "441134960001", Text: "Hi"})
+
+	var apiErr *models.APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusUnauthorized, apiErr.StatusCode)
+	assert.EqualError(t, err, "request failed with status 401: Invalid login details")
+}
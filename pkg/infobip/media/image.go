@@ -0,0 +1,188 @@
+package media
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/jpeg"
+	"image/png"
+
+	"github.com/infobip-community/infobip-api-go-sdk/v3/pkg/infobip/models"
+)
+
+const (
+	jpegType = "image/jpeg"
+	pngType  = "image/png"
+
+	imageProcessorName = "image"
+	// downscaleStep is the factor applied to the width and height of an image each time it is downscaled.
+	downscaleStep       = 0.75
+	defaultMinDimension = 64
+)
+
+// ImageProcessor fits JPEG and PNG images to a budget, in pure Go. It first recompresses the image at full size,
+// then downscales it step by step until it fits or its smaller side would go under MinDimension.
+type ImageProcessor struct {
+	// Qualities are the JPEG qualities tried at each size, in order. Default to 85, 75, 65 and 55.
+	Qualities []int
+	// MinDimension is the smallest width or height an image is downscaled to. Defaults to 64.
+	MinDimension int
+	// ConvertPNG allows converting PNG images to JPEG, over a white background, when recompressing them as PNG is
+	// not enough. Transparency is lost.
+	ConvertPNG bool
+}
+
+func NewImageProcessor() *ImageProcessor {
+	return &ImageProcessor{}
+}
+
+func (p *ImageProcessor) Supports(contentType string) bool {
+	return contentType == jpegType || contentType == pngType
+}
+
+func (p *ImageProcessor) Process(ctx context.Context, media models.Attachment, content []byte, budget int64) (
+	models.Attachment, models.MediaTransformation, error,
+) {
+	transformation := models.MediaTransformation{
+		Name:                media.Name,
+		Processor:           imageProcessorName,
+		OriginalContentType: media.ContentType,
+		OriginalSize:        int64(len(content)),
+	}
+	img, _, err := image.Decode(bytes.NewReader(content))
+	if err != nil {
+		return media, transformation, err
+	}
+	bounds := img.Bounds()
+	transformation.OriginalWidth, transformation.OriginalHeight = bounds.Dx(), bounds.Dy()
+
+	var best, encoded []byte
+	var contentType string
+	var quality int
+	for current := img; ; {
+		encoded, contentType, quality, err = p.encode(ctx, media.ContentType, current, budget)
+		if err != nil {
+			return media, transformation, err
+		}
+		if best == nil || len(encoded) < len(best) {
+			best = encoded
+			transformation.ContentType = contentType
+			transformation.Quality = quality
+			transformation.Width, transformation.Height = current.Bounds().Dx(), current.Bounds().Dy()
+		}
+		if int64(len(encoded)) <= budget {
+			break
+		}
+		next, ok := p.downscale(current)
+		if !ok {
+			break
+		}
+		current = next
+	}
+
+	transformation.Size = int64(len(best))
+	processed := models.NewBytesAttachment(
+		renamed(media.Name, transformation.ContentType), transformation.ContentType, best)
+	return processed, transformation, nil
+}
+
+// encode returns the smallest encoding of img tried, or the first one fitting in budget. PNG images stay PNG unless
+// ConvertPNG is set.
+func (p *ImageProcessor) encode(ctx context.Context, contentType string, img image.Image, budget int64) (
+	[]byte, string, int, error,
+) {
+	if err := ctx.Err(); err != nil {
+		return nil, "", 0, err
+	}
+	var best []byte
+	bestType, bestQuality := "", 0
+	if contentType == pngType {
+		var buf bytes.Buffer
+		encoder := png.Encoder{CompressionLevel: png.BestCompression}
+		if err := encoder.Encode(&buf, img); err != nil {
+			return nil, "", 0, err
+		}
+		best, bestType = buf.Bytes(), pngType
+		if int64(len(best)) <= budget || !p.ConvertPNG {
+			return best, bestType, bestQuality, nil
+		}
+		img = flatten(img)
+	}
+
+	for _, quality := range p.qualities() {
+		if err := ctx.Err(); err != nil {
+			return nil, "", 0, err
+		}
+		var buf bytes.Buffer
+		if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: quality}); err != nil {
+			return nil, "", 0, err
+		}
+		if best == nil || buf.Len() < len(best) {
+			best, bestType, bestQuality = buf.Bytes(), jpegType, quality
+		}
+		if int64(len(best)) <= budget {
+			break
+		}
+	}
+	return best, bestType, bestQuality, nil
+}
+
+func (p *ImageProcessor) qualities() []int {
+	if len(p.Qualities) > 0 {
+		return p.Qualities
+	}
+	return []int{85, 75, 65, 55}
+}
+
+// downscale returns img shrunk by downscaleStep with a box filter, or false when it would get under MinDimension.
+func (p *ImageProcessor) downscale(img image.Image) (image.Image, bool) {
+	minDimension := p.MinDimension
+	if minDimension <= 0 {
+		minDimension = defaultMinDimension
+	}
+	bounds := img.Bounds()
+	width := int(float64(bounds.Dx()) * downscaleStep)
+	height := int(float64(bounds.Dy()) * downscaleStep)
+	if width < minDimension || height < minDimension {
+		return nil, false
+	}
+
+	src := image.NewNRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(src, src.Bounds(), img, bounds.Min, draw.Src)
+	dst := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		y0, y1 := y*bounds.Dy()/height, (y+1)*bounds.Dy()/height
+		for x := 0; x < width; x++ {
+			x0, x1 := x*bounds.Dx()/width, (x+1)*bounds.Dx()/width
+			dst.SetNRGBA(x, y, average(src, x0, y0, x1, y1))
+		}
+	}
+	return dst, true
+}
+
+// average returns the mean color of the pixels of src in [x0, x1) x [y0, y1).
+func average(src *image.NRGBA, x0, y0, x1, y1 int) color.NRGBA {
+	var r, g, b, a, n int
+	for y := y0; y < y1; y++ {
+		for x := x0; x < x1; x++ {
+			c := src.NRGBAAt(x, y)
+			r, g, b, a = r+int(c.R), g+int(c.G), b+int(c.B), a+int(c.A)
+			n++
+		}
+	}
+	if n == 0 {
+		return color.NRGBA{}
+	}
+	return color.NRGBA{R: uint8(r / n), G: uint8(g / n), B: uint8(b / n), A: uint8(a / n)}
+}
+
+// flatten draws img over a white background, for encodings without transparency.
+func flatten(img image.Image) image.Image {
+	bounds := img.Bounds()
+	flat := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(flat, flat.Bounds(), image.White, image.Point{}, draw.Src)
+	draw.Draw(flat, flat.Bounds(), img, bounds.Min, draw.Over)
+	return flat
+}
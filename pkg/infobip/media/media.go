@@ -0,0 +1,138 @@
+// Package media fits media to the size limits of channels before it is sent, e.g. the carrier limits of MMS and
+// the media limits of WhatsApp. A Pipeline hands oversized media to the first Processor supporting its content
+// type: ImageProcessor downscales and recompresses JPEG and PNG images, and TranscoderProcessor runs external tools
+// for video and audio.
+package media
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"mime"
+	"path"
+	"strings"
+
+	"github.com/infobip-community/infobip-api-go-sdk/v3/pkg/infobip/models"
+)
+
+// Processor transforms media of the content types it supports so that it fits in budget bytes.
+type Processor interface {
+	Supports(contentType string) bool
+	// Process returns the transformed media and the transformation applied. The result may still exceed budget
+	// when the processor can't reduce it further.
+	Process(ctx context.Context, media models.Attachment, content []byte, budget int64) (
+		models.Attachment, models.MediaTransformation, error)
+}
+
+// MediaTooLargeError is returned when media exceeds its budget and no processor could fit it.
+type MediaTooLargeError struct {
+	Name   string
+	Size   int64
+	Budget int64
+}
+
+func (e *MediaTooLargeError) Error() string {
+	return fmt.Sprintf("%s is %d bytes, over the budget of %d bytes", e.Name, e.Size, e.Budget)
+}
+
+// Pipeline fits media to a budget with the first of its processors supporting the content type of the media.
+type Pipeline struct {
+	Processors []Processor
+}
+
+func NewPipeline(processors ...Processor) *Pipeline {
+	return &Pipeline{Processors: processors}
+}
+
+// Supports reports whether a processor of the pipeline supports the content type.
+func (p *Pipeline) Supports(contentType string) bool {
+	return p.processor(contentType) != nil
+}
+
+// Fit reads the media and returns it unchanged, with a nil transformation, when it fits in budget bytes. Otherwise,
+// it returns the media transformed by a processor, or a MediaTooLargeError.
+func (p *Pipeline) Fit(ctx context.Context, media models.Attachment, budget int64) (
+	models.Attachment, *models.MediaTransformation, error,
+) {
+	content, err := readAll(media)
+	if err != nil {
+		return media, nil, err
+	}
+	return p.FitContent(ctx, media, content, budget)
+}
+
+// FitContent is Fit for media already read into content.
+func (p *Pipeline) FitContent(ctx context.Context, media models.Attachment, content []byte, budget int64) (
+	models.Attachment, *models.MediaTransformation, error,
+) {
+	size := int64(len(content))
+	if size <= budget {
+		return media, nil, nil
+	}
+	contentType := ContentType(media)
+	processor := p.processor(contentType)
+	if processor == nil {
+		return media, nil, &MediaTooLargeError{Name: media.Name, Size: size, Budget: budget}
+	}
+
+	media.ContentType = contentType
+	processed, transformation, err := processor.Process(ctx, media, content, budget)
+	if err != nil {
+		return media, nil, err
+	}
+	if transformation.Size > budget {
+		return media, nil, &MediaTooLargeError{Name: media.Name, Size: transformation.Size, Budget: budget}
+	}
+	return processed, &transformation, nil
+}
+
+func (p *Pipeline) processor(contentType string) Processor {
+	for _, processor := range p.Processors {
+		if processor.Supports(contentType) {
+			return processor
+		}
+	}
+	return nil
+}
+
+// ContentType returns the media type of the attachment, without parameters, guessed from its name when not set.
+func ContentType(media models.Attachment) string {
+	contentType := media.ContentType
+	if contentType == "" {
+		contentType = mime.TypeByExtension(strings.ToLower(path.Ext(media.Name)))
+	}
+	if mediaType, _, err := mime.ParseMediaType(contentType); err == nil {
+		return mediaType
+	}
+	return contentType
+}
+
+func readAll(media models.Attachment) ([]byte, error) {
+	reader, err := media.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	return ioutil.ReadAll(reader)
+}
+
+// renamed returns name with the extension of the content type, when it changed, e.g. photo.png to photo.jpg.
+func renamed(name string, contentType string) string {
+	extensions, err := mime.ExtensionsByType(contentType)
+	if err != nil || len(extensions) == 0 {
+		return name
+	}
+	ext := path.Ext(name)
+	for _, candidate := range extensions {
+		if strings.EqualFold(ext, candidate) {
+			return name
+		}
+	}
+	preferred := extensions[0]
+	for _, candidate := range extensions {
+		if candidate == ".jpg" || candidate == ".png" {
+			preferred = candidate
+		}
+	}
+	return strings.TrimSuffix(name, ext) + preferred
+}
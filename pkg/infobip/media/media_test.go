@@ -0,0 +1,237 @@
+package media
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/infobip-community/infobip-api-go-sdk/v3/pkg/infobip/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// noise returns an image that compresses badly, so that it needs to be downscaled to fit small budgets.
+func noise(width, height int) image.Image {
+	random := rand.New(rand.NewSource(1))
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.SetNRGBA(x, y, color.NRGBA{
+				R: uint8(random.Intn(256)), G: uint8(random.Intn(256)), B: uint8(random.Intn(256)), A: 255,
+			})
+		}
+	}
+	return img
+}
+
+func encodePNG(t *testing.T, img image.Image) []byte {
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
+
+func encodeJPEG(t *testing.T, img image.Image) []byte {
+	var buf bytes.Buffer
+	require.NoError(t, jpeg.Encode(&buf, img, &jpeg.Options{Quality: 100}))
+	return buf.Bytes()
+}
+
+func readContent(t *testing.T, attachment models.Attachment) []byte {
+	reader, err := attachment.Open()
+	require.NoError(t, err)
+	defer reader.Close()
+	content, err := ioutil.ReadAll(reader)
+	require.NoError(t, err)
+	return content
+}
+
+func TestPipelineFitsJPEG(t *testing.T) {
+	content := encodeJPEG(t, noise(400, 300))
+	pipeline := NewPipeline(NewImageProcessor())
+	const budget = 20 * 1024
+
+	fitted, transformation, err := pipeline.Fit(
+		context.Background(), models.NewBytesAttachment("photo.jpg", "", content), budget)
+
+	require.NoError(t, err)
+	require.NotNil(t, transformation)
+	fittedContent := readContent(t, fitted)
+	assert.LessOrEqual(t, int64(len(fittedContent)), int64(budget))
+	assert.Equal(t, int64(len(fittedContent)), transformation.Size)
+	assert.Equal(t, "photo.jpg", fitted.Name)
+	assert.Equal(t, "image/jpeg", fitted.ContentType)
+
+	config, format, err := image.DecodeConfig(bytes.NewReader(fittedContent))
+	require.NoError(t, err)
+	assert.Equal(t, "jpeg", format)
+	assert.Equal(t, models.MediaTransformation{
+		Name:                "photo.jpg",
+		Processor:           "image",
+		OriginalContentType: "image/jpeg",
+		ContentType:         "image/jpeg",
+		OriginalSize:        int64(len(content)),
+		Size:                int64(len(fittedContent)),
+		OriginalWidth:       400,
+		OriginalHeight:      300,
+		Width:               config.Width,
+		Height:              config.Height,
+		Quality:             transformation.Quality,
+	}, *transformation)
+	assert.Less(t, config.Width, 400)
+	assert.Contains(t, []int{85, 75, 65, 55}, transformation.Quality)
+}
+
+func TestPipelineRecompressesWithoutDownscaling(t *testing.T) {
+	img := image.NewGray(image.Rect(0, 0, 200, 200))
+	for i := range img.Pix {
+		img.Pix[i] = uint8(i % 7 * 30)
+	}
+	content := encodeJPEG(t, img)
+	pipeline := NewPipeline(NewImageProcessor())
+
+	_, transformation, err := pipeline.Fit(
+		context.Background(), models.NewBytesAttachment("photo.jpeg", "image/jpeg", content), int64(len(content)-1))
+
+	require.NoError(t, err)
+	require.NotNil(t, transformation)
+	assert.Equal(t, 200, transformation.Width)
+	assert.Equal(t, 85, transformation.Quality)
+}
+
+func TestPipelineFitsPNG(t *testing.T) {
+	content := encodePNG(t, noise(256, 256))
+	const budget = 8 * 1024
+
+	_, _, err := NewPipeline(NewImageProcessor()).Fit(
+		context.Background(), models.NewBytesAttachment("chart.png", "image/png", content), budget)
+	var tooLarge *MediaTooLargeError
+	require.ErrorAs(t, err, &tooLarge)
+
+	converting := &ImageProcessor{ConvertPNG: true}
+	fitted, transformation, err := NewPipeline(converting).Fit(
+		context.Background(), models.NewBytesAttachment("chart.png", "image/png", content), budget)
+	require.NoError(t, err)
+	assert.Equal(t, "chart.jpg", fitted.Name)
+	assert.Equal(t, "image/png", transformation.OriginalContentType)
+	assert.Equal(t, "image/jpeg", transformation.ContentType)
+	assert.LessOrEqual(t, transformation.Size, int64(budget))
+}
+
+func TestPipelineFitsPNGByDownscaling(t *testing.T) {
+	content := encodePNG(t, noise(256, 256))
+
+	fitted, transformation, err := NewPipeline(NewImageProcessor()).Fit(
+		context.Background(), models.NewBytesAttachment("chart.png", "", content), int64(len(content)/2))
+
+	require.NoError(t, err)
+	assert.Equal(t, "chart.png", fitted.Name)
+	assert.Equal(t, "image/png", transformation.ContentType)
+	assert.Equal(t, 0, transformation.Quality)
+	assert.Less(t, transformation.Width, 256)
+	_, format, err := image.DecodeConfig(bytes.NewReader(readContent(t, fitted)))
+	require.NoError(t, err)
+	assert.Equal(t, "png", format)
+}
+
+func TestPipelineWithinBudget(t *testing.T) {
+	original := models.NewBytesAttachment("photo.jpg", "image/jpeg", []byte("small"))
+
+	fitted, transformation, err := NewPipeline(NewImageProcessor()).Fit(context.Background(), original, 5)
+
+	require.NoError(t, err)
+	assert.Nil(t, transformation)
+	assert.Equal(t, "photo.jpg", fitted.Name)
+	assert.Equal(t, []byte("small"), readContent(t, fitted))
+}
+
+func TestPipelineTooLarge(t *testing.T) {
+	pipeline := NewPipeline(NewImageProcessor())
+
+	_, _, err := pipeline.Fit(
+		context.Background(), models.NewBytesAttachment("clip.mp4", "", []byte("0123456789")), 4)
+	assert.EqualError(t, err, "clip.mp4 is 10 bytes, over the budget of 4 bytes")
+
+	_, _, err = pipeline.Fit(
+		context.Background(), models.NewBytesAttachment("tiny.png", "", encodePNG(t, noise(64, 64))), 100)
+	var tooLarge *MediaTooLargeError
+	require.ErrorAs(t, err, &tooLarge)
+	assert.Equal(t, "tiny.png", tooLarge.Name)
+	assert.Equal(t, int64(100), tooLarge.Budget)
+}
+
+func TestPipelineInvalidImage(t *testing.T) {
+	_, _, err := NewPipeline(NewImageProcessor()).Fit(
+		context.Background(), models.NewBytesAttachment("photo.jpg", "", []byte("not an image")), 4)
+
+	assert.ErrorIs(t, err, image.ErrFormat)
+}
+
+func TestPipelineCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, _, err := NewPipeline(NewImageProcessor()).Fit(
+		ctx, models.NewBytesAttachment("photo.jpg", "", encodeJPEG(t, noise(100, 100))), 100)
+
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+type truncatingTranscoder struct{}
+
+func (truncatingTranscoder) Transcode(
+	_ context.Context, input io.Reader, _ string, budget int64, output io.Writer,
+) (string, error) {
+	_, err := io.Copy(output, io.LimitReader(input, budget))
+	return "video/mp4", err
+}
+
+func TestTranscoderProcessor(t *testing.T) {
+	processor := NewTranscoderProcessor("truncate", truncatingTranscoder{}, "video/", "audio/mpeg")
+	assert.True(t, processor.Supports("video/mp4"))
+	assert.True(t, processor.Supports("audio/mpeg"))
+	assert.False(t, processor.Supports("audio/ogg"))
+	assert.False(t, processor.Supports("image/png"))
+
+	fitted, transformation, err := NewPipeline(NewImageProcessor(), processor).Fit(
+		context.Background(), models.NewBytesAttachment("clip.mp4", "", []byte("0123456789")), 4)
+
+	require.NoError(t, err)
+	assert.Equal(t, []byte("0123"), readContent(t, fitted))
+	assert.Equal(t, "clip.mp4", fitted.Name)
+	assert.Equal(t, models.MediaTransformation{
+		Name: "clip.mp4", Processor: "truncate", OriginalContentType: "video/mp4", ContentType: "video/mp4",
+		OriginalSize: 10, Size: 4,
+	}, *transformation)
+}
+
+func TestCommandTranscoder(t *testing.T) {
+	transcoder := &CommandTranscoder{Path: "head", Args: []string{"-c", "{budget}"}}
+	var output bytes.Buffer
+
+	contentType, err := transcoder.Transcode(
+		context.Background(), strings.NewReader("0123456789"), "audio/mpeg", 3, &output)
+
+	require.NoError(t, err)
+	assert.Equal(t, "audio/mpeg", contentType)
+	assert.Equal(t, "012", output.String())
+}
+
+func TestCommandTranscoderFails(t *testing.T) {
+	transcoder := &CommandTranscoder{Path: "sh", Args: []string{"-c", "echo unsupported {contentType} >&2; exit 1"}}
+
+	_, err := transcoder.Transcode(
+		context.Background(), strings.NewReader(""), "audio/amr", 3, ioutil.Discard)
+
+	var exitErr interface{ ExitCode() int }
+	require.True(t, errors.As(err, &exitErr))
+	assert.EqualError(t, err, "exit status 1: unsupported audio/amr")
+}
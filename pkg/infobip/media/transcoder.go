@@ -0,0 +1,100 @@
+package media
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"github.com/infobip-community/infobip-api-go-sdk/v3/pkg/infobip/models"
+)
+
+// Transcoder reduces video or audio to a budget, e.g. by running ffmpeg. It reads the media from input, writes the
+// result to output and returns the content type of the result.
+type Transcoder interface {
+	Transcode(ctx context.Context, input io.Reader, contentType string, budget int64, output io.Writer) (string, error)
+}
+
+// TranscoderProcessor is a Processor handing media to a Transcoder. The SDK ships no video or audio codec: plug one
+// in with a Transcoder, such as a CommandTranscoder.
+type TranscoderProcessor struct {
+	// Name is recorded as the processor of the transformations.
+	Name string
+	// ContentTypes are the supported content types or prefixes of content types, e.g. "video/" or "audio/mpeg".
+	ContentTypes []string
+	Transcoder   Transcoder
+}
+
+func NewTranscoderProcessor(name string, transcoder Transcoder, contentTypes ...string) *TranscoderProcessor {
+	return &TranscoderProcessor{Name: name, ContentTypes: contentTypes, Transcoder: transcoder}
+}
+
+func (p *TranscoderProcessor) Supports(contentType string) bool {
+	for _, supported := range p.ContentTypes {
+		if contentType == supported || strings.HasSuffix(supported, "/") && strings.HasPrefix(contentType, supported) {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *TranscoderProcessor) Process(ctx context.Context, media models.Attachment, content []byte, budget int64) (
+	models.Attachment, models.MediaTransformation, error,
+) {
+	transformation := models.MediaTransformation{
+		Name:                media.Name,
+		Processor:           p.Name,
+		OriginalContentType: media.ContentType,
+		OriginalSize:        int64(len(content)),
+	}
+	var output bytes.Buffer
+	contentType, err := p.Transcoder.Transcode(ctx, bytes.NewReader(content), media.ContentType, budget, &output)
+	if err != nil {
+		return media, transformation, fmt.Errorf("%s: %w", p.Name, err)
+	}
+	if contentType == "" {
+		contentType = media.ContentType
+	}
+	transformation.ContentType = contentType
+	transformation.Size = int64(output.Len())
+	processed := models.NewBytesAttachment(renamed(media.Name, contentType), contentType, output.Bytes())
+	return processed, transformation, nil
+}
+
+// CommandTranscoder runs a command reading the media on its standard input and writing the result on its standard
+// output. Arguments equal to {budget} are replaced by the budget in bytes, and those equal to {contentType} by the
+// content type of the input.
+type CommandTranscoder struct {
+	Path string
+	Args []string
+	// ContentType is the content type of the output. Defaults to the content type of the input.
+	ContentType string
+}
+
+func (t *CommandTranscoder) Transcode(
+	ctx context.Context, input io.Reader, contentType string, budget int64, output io.Writer,
+) (string, error) {
+	args := make([]string, len(t.Args))
+	for i, arg := range t.Args {
+		arg = strings.ReplaceAll(arg, "{budget}", strconv.FormatInt(budget, 10))
+		args[i] = strings.ReplaceAll(arg, "{contentType}", contentType)
+	}
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, t.Path, args...)
+	cmd.Stdin = input
+	cmd.Stdout = output
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if message := strings.TrimSpace(stderr.String()); message != "" {
+			return "", fmt.Errorf("%w: %s", err, message)
+		}
+		return "", err
+	}
+	if t.ContentType != "" {
+		return t.ContentType, nil
+	}
+	return contentType, nil
+}
@@ -0,0 +1,370 @@
+package messaging
+
+import (
+	"context"
+	"mime"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/infobip-community/infobip-api-go-sdk/v3/pkg/infobip/email"
+	"github.com/infobip-community/infobip-api-go-sdk/v3/pkg/infobip/mms"
+	"github.com/infobip-community/infobip-api-go-sdk/v3/pkg/infobip/models"
+	"github.com/infobip-community/infobip-api-go-sdk/v3/pkg/infobip/rcs"
+	"github.com/infobip-community/infobip-api-go-sdk/v3/pkg/infobip/sms"
+	"github.com/infobip-community/infobip-api-go-sdk/v3/pkg/infobip/whatsapp"
+)
+
+const defaultMediaContentID = "media"
+
+// SMSAdapter sends text messages with the SMS regional parameters. Media, subjects and HTML are unsupported.
+type SMSAdapter struct {
+	Client sms.SMS
+}
+
+func (a *SMSAdapter) Send(ctx context.Context, msg Message) (Result, models.ResponseDetails, error) {
+	req, err := a.render(msg)
+	if err != nil {
+		return Result{}, models.ResponseDetails{}, err
+	}
+	resp, respDetails, err := a.Client.Send(ctx, req)
+	result := Result{Channel: SMS, BulkID: resp.BulkID}
+	if len(resp.Messages) > 0 {
+		sent := resp.Messages[0]
+		result.MessageID = sent.MessageID
+		result.To = sent.To
+		if sent.Status != nil {
+			result.Status = Status{
+				GroupID:     sent.Status.GroupID,
+				GroupName:   sent.Status.GroupName,
+				ID:          sent.Status.ID,
+				Name:        sent.Status.Name,
+				Description: sent.Status.Description,
+			}
+		}
+	}
+	return result, respDetails, err
+}
+
+func (a *SMSAdapter) render(msg Message) (models.SendSMSRequest, error) {
+	if err := checkUnsupported(SMS, msg, FeatureMedia, FeatureSubject, FeatureHTML); err != nil {
+		return models.SendSMSRequest{}, err
+	}
+	data, err := callbackData(msg)
+	if err != nil {
+		return models.SendSMSRequest{}, err
+	}
+	return models.SendSMSRequest{
+		Messages: []models.SMSMsg{{
+			From:         msg.From,
+			Destinations: []models.SMSDestination{{To: msg.To}},
+			Text:         msg.Text,
+			CallbackData: data,
+			NotifyURL:    msg.NotifyURL,
+			Regional:     msg.SMSRegional,
+		}},
+	}, nil
+}
+
+// WhatsAppAdapter sends text messages, or image, video, audio and document messages depending on the content
+// type of the media, with the text as caption. Subjects, HTML, uploaded media and captions of audio are
+// unsupported. Free-form messages are only delivered within the customer service window.
+type WhatsAppAdapter struct {
+	Client whatsapp.WhatsApp
+}
+
+func (a *WhatsAppAdapter) Send(ctx context.Context, msg Message) (Result, models.ResponseDetails, error) {
+	err := checkUnsupported(WhatsApp, msg, FeatureSubject, FeatureHTML, FeatureUploadedMedia, FeatureSMSRegional)
+	if err != nil {
+		return Result{}, models.ResponseDetails{}, err
+	}
+	data, err := callbackData(msg)
+	if err != nil {
+		return Result{}, models.ResponseDetails{}, err
+	}
+	common := models.MsgCommon{From: msg.From, To: msg.To, CallbackData: data, NotifyURL: msg.NotifyURL}
+
+	var resp models.SendWAMsgResponse
+	var respDetails models.ResponseDetails
+	switch mediaKind(msg.Media) {
+	case "":
+		resp, respDetails, err = a.Client.SendText(ctx, models.WATextMsg{
+			MsgCommon: common, Content: models.TextContent{Text: msg.Text},
+		})
+	case "image":
+		resp, respDetails, err = a.Client.SendImage(ctx, models.WAImageMsg{
+			MsgCommon: common, Content: models.ImageContent{MediaURL: msg.Media.URL, Caption: msg.Text},
+		})
+	case "video":
+		resp, respDetails, err = a.Client.SendVideo(ctx, models.WAVideoMsg{
+			MsgCommon: common, Content: models.VideoContent{MediaURL: msg.Media.URL, Caption: msg.Text},
+		})
+	case "audio":
+		if msg.Text != "" {
+			return Result{}, models.ResponseDetails{}, unsupported(WhatsApp, FeatureCaption)
+		}
+		resp, respDetails, err = a.Client.SendAudio(ctx, models.WAAudioMsg{
+			MsgCommon: common, Content: models.AudioContent{MediaURL: msg.Media.URL},
+		})
+	default:
+		resp, respDetails, err = a.Client.SendDocument(ctx, models.WADocumentMsg{
+			MsgCommon: common,
+			Content: models.DocumentContent{
+				MediaURL: msg.Media.URL, Caption: msg.Text, Filename: msg.Media.Filename,
+			},
+		})
+	}
+
+	return Result{
+		Channel:   WhatsApp,
+		MessageID: resp.MessageID,
+		To:        resp.To,
+		Status: Status{
+			GroupID:     int(resp.Status.GroupID),
+			GroupName:   resp.Status.GroupName,
+			ID:          int(resp.Status.ID),
+			Name:        resp.Status.Name,
+			Description: resp.Status.Description,
+		},
+	}, respDetails, err
+}
+
+// EmailAdapter sends emails with a text and an HTML body, and the media content as attachment. Linked media is
+// unsupported, as attachments are uploaded rather than linked.
+type EmailAdapter struct {
+	Client email.Email
+}
+
+func (a *EmailAdapter) Send(ctx context.Context, msg Message) (Result, models.ResponseDetails, error) {
+	emailMsg, err := a.render(msg)
+	if err != nil {
+		return Result{}, models.ResponseDetails{}, err
+	}
+	resp, respDetails, err := a.Client.Send(ctx, emailMsg)
+	result := Result{Channel: Email, BulkID: resp.BulkID}
+	if len(resp.Messages) > 0 {
+		sent := resp.Messages[0]
+		result.MessageID = sent.MessageID
+		result.To = sent.To
+		result.Status = Status{
+			GroupID:     sent.Status.GroupID,
+			GroupName:   sent.Status.GroupName,
+			ID:          sent.Status.ID,
+			Name:        sent.Status.Name,
+			Description: sent.Status.Description,
+		}
+	}
+	return result, respDetails, err
+}
+
+func (a *EmailAdapter) render(msg Message) (models.EmailMsg, error) {
+	if err := checkUnsupported(Email, msg, FeatureLinkedMedia, FeatureSMSRegional); err != nil {
+		return models.EmailMsg{}, err
+	}
+	data, err := callbackData(msg)
+	if err != nil {
+		return models.EmailMsg{}, err
+	}
+	emailMsg := models.EmailMsg{
+		From:         msg.From,
+		To:           msg.To,
+		Subject:      msg.Subject,
+		Text:         msg.Text,
+		HTML:         msg.HTML,
+		CallbackData: data,
+		NotifyURL:    msg.NotifyURL,
+	}
+	if msg.Media != nil {
+		emailMsg.AttachmentSources = []models.Attachment{mediaContent(msg.Media)}
+	}
+	return emailMsg, nil
+}
+
+// MMSAdapter sends MMS messages with a subject, text and media, either uploaded or externally hosted. HTML is
+// unsupported.
+type MMSAdapter struct {
+	Client mms.MMS
+}
+
+func (a *MMSAdapter) Send(ctx context.Context, msg Message) (Result, models.ResponseDetails, error) {
+	mmsMsg, err := a.render(msg)
+	if err != nil {
+		return Result{}, models.ResponseDetails{}, err
+	}
+	resp, respDetails, err := a.Client.Send(ctx, mmsMsg)
+	result := Result{Channel: MMS, BulkID: resp.BulkID}
+	if len(resp.Messages) > 0 {
+		sent := resp.Messages[0]
+		result.MessageID = sent.MessageID
+		result.To = sent.To
+		result.Status = Status{
+			GroupID:     int(sent.Status.GroupID),
+			GroupName:   sent.Status.GroupName,
+			ID:          int(sent.Status.ID),
+			Name:        sent.Status.Name,
+			Description: sent.Status.Description,
+		}
+	}
+	return result, respDetails, err
+}
+
+func (a *MMSAdapter) render(msg Message) (models.MMSMsg, error) {
+	if err := checkUnsupported(MMS, msg, FeatureHTML, FeatureSMSRegional); err != nil {
+		return models.MMSMsg{}, err
+	}
+	data, err := callbackData(msg)
+	if err != nil {
+		return models.MMSMsg{}, err
+	}
+	mmsMsg := models.MMSMsg{
+		Head: models.MMSHead{
+			From:         msg.From,
+			To:           msg.To,
+			Subject:      msg.Subject,
+			CallbackData: data,
+			NotifyURL:    msg.NotifyURL,
+		},
+		Text: msg.Text,
+	}
+	if msg.Media != nil {
+		contentID := msg.Media.Filename
+		if contentID == "" {
+			contentID = defaultMediaContentID
+		}
+		if msg.Media.Content != nil {
+			mmsMsg.MediaParts = []models.MMSMediaPart{{ContentID: contentID, Media: mediaContent(msg.Media)}}
+			return mmsMsg, nil
+		}
+		mmsMsg.ExternallyHostedMedia = []models.ExternallyHostedMedia{{
+			ContentType: contentType(msg.Media),
+			ContentID:   contentID,
+			ContentURL:  msg.Media.URL,
+		}}
+	}
+	return mmsMsg, nil
+}
+
+// RCSAdapter sends text messages, or file messages when the message has media. Subjects, HTML, uploaded media and
+// text together with media are unsupported.
+type RCSAdapter struct {
+	Client rcs.RCS
+}
+
+func (a *RCSAdapter) Send(ctx context.Context, msg Message) (Result, models.ResponseDetails, error) {
+	rcsMsg, err := a.render(msg)
+	if err != nil {
+		return Result{}, models.ResponseDetails{}, err
+	}
+	resp, respDetails, err := a.Client.Send(ctx, rcsMsg)
+	result := Result{Channel: RCS}
+	if len(resp.Messages) > 0 {
+		sent := resp.Messages[0]
+		result.MessageID = sent.MessageID
+		result.To = sent.To
+		result.Status = Status{
+			GroupID:     sent.Status.GroupID,
+			GroupName:   sent.Status.GroupName,
+			ID:          sent.Status.ID,
+			Name:        sent.Status.Name,
+			Description: sent.Status.Description,
+		}
+	}
+	return result, respDetails, err
+}
+
+func (a *RCSAdapter) render(msg Message) (models.RCSMsg, error) {
+	err := checkUnsupported(RCS, msg, FeatureSubject, FeatureHTML, FeatureUploadedMedia, FeatureSMSRegional)
+	if err != nil {
+		return models.RCSMsg{}, err
+	}
+	data, err := callbackData(msg)
+	if err != nil {
+		return models.RCSMsg{}, err
+	}
+	content := &models.RCSContent{Type: "TEXT", Text: msg.Text}
+	if msg.Media != nil {
+		if msg.Text != "" {
+			return models.RCSMsg{}, unsupported(RCS, FeatureCaption)
+		}
+		content = &models.RCSContent{Type: "FILE", File: &models.RCSFile{URL: msg.Media.URL}}
+	}
+	return models.RCSMsg{
+		From:         msg.From,
+		To:           msg.To,
+		Content:      content,
+		CallbackData: data,
+		NotifyURL:    msg.NotifyURL,
+	}, nil
+}
+
+// checkUnsupported returns an UnsupportedFeatureError for the first of the features the message uses.
+func checkUnsupported(channel Channel, msg Message, features ...string) error {
+	for _, feature := range features {
+		used := false
+		switch feature {
+		case FeatureMedia:
+			used = msg.Media != nil
+		case FeatureSubject:
+			used = msg.Subject != ""
+		case FeatureHTML:
+			used = msg.HTML != ""
+		case FeatureLinkedMedia:
+			used = msg.Media != nil && msg.Media.Content == nil
+		case FeatureUploadedMedia:
+			used = msg.Media != nil && msg.Media.URL == ""
+		case FeatureSMSRegional:
+			used = msg.SMSRegional != nil
+		}
+		if used {
+			return unsupported(channel, feature)
+		}
+	}
+	return nil
+}
+
+// contentType returns the content type of the media, guessed from the extension of the URL, or of the content
+// name, when not set.
+func contentType(media *Media) string {
+	if media.ContentType != "" {
+		return media.ContentType
+	}
+	if media.URL == "" && media.Content != nil {
+		if media.Content.ContentType != "" {
+			return media.Content.ContentType
+		}
+		return mime.TypeByExtension(path.Ext(media.Content.Name))
+	}
+	mediaPath := media.URL
+	if parsed, err := url.Parse(media.URL); err == nil {
+		mediaPath = parsed.Path
+	}
+	return mime.TypeByExtension(path.Ext(mediaPath))
+}
+
+// mediaContent returns the content of the media, named after the filename and with the content type of the
+// media when set.
+func mediaContent(media *Media) models.Attachment {
+	content := *media.Content
+	if media.Filename != "" {
+		content.Name = media.Filename
+	}
+	if media.ContentType != "" {
+		content.ContentType = media.ContentType
+	}
+	return content
+}
+
+// mediaKind returns the top-level type of the media content type, e.g. "image", or an empty string without media.
+func mediaKind(media *Media) string {
+	if media == nil {
+		return ""
+	}
+	kind := contentType(media)
+	if i := strings.IndexByte(kind, '/'); i >= 0 {
+		kind = kind[:i]
+	}
+	if kind == "" {
+		return "application"
+	}
+	return kind
+}
@@ -0,0 +1,281 @@
+package messaging
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/infobip-community/infobip-api-go-sdk/v3/internal"
+	"github.com/infobip-community/infobip-api-go-sdk/v3/pkg/infobip/models"
+	"github.com/infobip-community/infobip-api-go-sdk/v3/pkg/infobip/rcs"
+	"github.com/infobip-community/infobip-api-go-sdk/v3/pkg/infobip/sms"
+	"github.com/infobip-community/infobip-api-go-sdk/v3/pkg/infobip/whatsapp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestHandler(t *testing.T, path string, status int, resp string, check func(body []byte)) internal.HTTPHandler {
+	serv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.True(t, strings.HasSuffix(r.URL.Path, path), r.URL.Path)
+		body, err := ioutil.ReadAll(r.Body)
+		assert.NoError(t, err)
+		check(body)
+		w.WriteHeader(status)
+		_, err = w.Write([]byte(resp))
+		assert.NoError(t, err)
+	}))
+	t.Cleanup(serv.Close)
+	return internal.HTTPHandler{HTTPClient: http.Client{}, BaseURL: serv.URL, APIKey: "secret"}
+}
+
+func TestSMSAdapterSend(t *testing.T) {
+	handler := newTestHandler(t, "sms/2/text/advanced", http.StatusOK,
+		`{"bulkId": "bulk", "messages": [{"messageId": "sms-id", "to": "441134960001",
+		"status": {"groupId": 1, "groupName": "PENDING", "id": 26, "name": "PENDING_ACCEPTED"}}]}`,
+		func(body []byte) {
+			var req models.SendSMSRequest
+			assert.NoError(t, json.Unmarshal(body, &req))
+			assert.Equal(t, "InfoSMS", req.Messages[0].From)
+			assert.Equal(t, "441134960001", req.Messages[0].Destinations[0].To)
+			assert.Equal(t, "Your order shipped", req.Messages[0].Text)
+			assert.Equal(t, `{"order":"42"}`, req.Messages[0].CallbackData)
+			require.NotNil(t, req.Messages[0].Regional)
+			assert.Equal(t, "some-entity", req.Messages[0].Regional.IndiaDLT.PrincipalEntityID)
+		})
+	adapter := &SMSAdapter{Client: &sms.Channel{ReqHandler: handler}}
+
+	result, respDetails, err := adapter.Send(context.Background(), Message{
+		From: "InfoSMS", To: "441134960001", Text: "Your order shipped", Metadata: map[string]string{"order": "42"},
+		SMSRegional: &models.SMSRegional{
+			IndiaDLT: models.IndiaDLT{ContentTemplateID: "some-template", PrincipalEntityID: "some-entity"},
+		},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, respDetails.HTTPResponse.StatusCode)
+	assert.Equal(t, Result{
+		Channel:   SMS,
+		BulkID:    "bulk",
+		MessageID: "sms-id",
+		To:        "441134960001",
+		Status:    Status{GroupID: 1, GroupName: "PENDING", ID: 26, Name: "PENDING_ACCEPTED"},
+	}, result)
+}
+
+func TestSMSAdapterUnsupported(t *testing.T) {
+	adapter := &SMSAdapter{}
+	tests := []struct {
+		name    string
+		msg     Message
+		feature string
+	}{
+		{name: "media", msg: Message{Text: "hi", Media: &Media{URL: "https://example.com/a.png"}}, feature: FeatureMedia},
+		{name: "subject", msg: Message{Text: "hi", Subject: "Hello"}, feature: FeatureSubject},
+		{name: "html", msg: Message{Text: "hi", HTML: "<p>hi</p>"}, feature: FeatureHTML},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, _, err := adapter.Send(context.Background(), tc.msg)
+
+			var featureErr *UnsupportedFeatureError
+			require.ErrorAs(t, err, &featureErr)
+			assert.Equal(t, SMS, featureErr.Channel)
+			assert.Equal(t, tc.feature, featureErr.Feature)
+		})
+	}
+}
+
+func TestWhatsAppAdapterSend(t *testing.T) {
+	tests := []struct {
+		name  string
+		path  string
+		media *Media
+		check func(t *testing.T, content map[string]interface{})
+	}{
+		{
+			name: "text",
+			path: "whatsapp/1/message/text",
+			check: func(t *testing.T, content map[string]interface{}) {
+				assert.Equal(t, "Your order shipped", content["text"])
+			},
+		},
+		{
+			name:  "image by extension",
+			path:  "whatsapp/1/message/image",
+			media: &Media{URL: "https://example.com/parcel.png?size=large"},
+			check: func(t *testing.T, content map[string]interface{}) {
+				assert.Equal(t, "https://example.com/parcel.png?size=large", content["mediaUrl"])
+				assert.Equal(t, "Your order shipped", content["caption"])
+			},
+		},
+		{
+			name:  "video",
+			path:  "whatsapp/1/message/video",
+			media: &Media{URL: "https://example.com/clip", ContentType: "video/mp4"},
+			check: func(t *testing.T, content map[string]interface{}) {
+				assert.Equal(t, "https://example.com/clip", content["mediaUrl"])
+			},
+		},
+		{
+			name:  "document",
+			path:  "whatsapp/1/message/document",
+			media: &Media{URL: "https://example.com/invoice", Filename: "invoice.pdf"},
+			check: func(t *testing.T, content map[string]interface{}) {
+				assert.Equal(t, "invoice.pdf", content["filename"])
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			handler := newTestHandler(t, tc.path, http.StatusOK,
+				`{"to": "441134960001", "messageCount": 1, "messageId": "wa-id",
+				"status": {"groupId": 1, "groupName": "PENDING", "id": 7, "name": "PENDING_ENROUTE"}}`,
+				func(body []byte) {
+					var req struct {
+						From         string                 `json:"from"`
+						CallbackData string                 `json:"callbackData"`
+						Content      map[string]interface{} `json:"content"`
+					}
+					assert.NoError(t, json.Unmarshal(body, &req))
+					assert.Equal(t, "441134960000", req.From)
+					assert.Equal(t, "order-42", req.CallbackData)
+					tc.check(t, req.Content)
+				})
+			adapter := &WhatsAppAdapter{Client: &whatsapp.Channel{ReqHandler: handler}}
+
+			result, _, err := adapter.Send(context.Background(), Message{
+				From: "441134960000", To: "441134960001", Text: "Your order shipped", Media: tc.media,
+				CallbackData: "order-42",
+			})
+
+			require.NoError(t, err)
+			assert.Equal(t, WhatsApp, result.Channel)
+			assert.Equal(t, "wa-id", result.MessageID)
+			assert.Equal(t, "PENDING_ENROUTE", result.Status.Name)
+		})
+	}
+}
+
+func TestWhatsAppAdapterAudioCaption(t *testing.T) {
+	adapter := &WhatsAppAdapter{}
+
+	_, _, err := adapter.Send(context.Background(), Message{
+		To: "441134960001", Text: "Listen", Media: &Media{URL: "https://example.com/note.mp3"},
+	})
+
+	var featureErr *UnsupportedFeatureError
+	require.ErrorAs(t, err, &featureErr)
+	assert.Equal(t, FeatureCaption, featureErr.Feature)
+}
+
+func TestEmailAdapterRender(t *testing.T) {
+	adapter := &EmailAdapter{}
+
+	emailMsg, err := adapter.render(Message{
+		From: "shop@example.com", To: "jane@example.com", Subject: "Shipped", Text: "Your order shipped",
+		HTML: "<p>Your order shipped</p>", NotifyURL: "https://example.com/reports",
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, models.EmailMsg{
+		From: "shop@example.com", To: "jane@example.com", Subject: "Shipped", Text: "Your order shipped",
+		HTML: "<p>Your order shipped</p>", NotifyURL: "https://example.com/reports",
+	}, emailMsg)
+
+	content := models.NewBytesAttachment("invoice.pdf", "", []byte("%PDF"))
+	emailMsg, err = adapter.render(Message{
+		To: "jane@example.com", Media: &Media{Content: &content, Filename: "order-42.pdf"},
+	})
+	require.NoError(t, err)
+	require.Len(t, emailMsg.AttachmentSources, 1)
+	assert.Equal(t, "order-42.pdf", emailMsg.AttachmentSources[0].Name)
+
+	_, err = adapter.render(Message{To: "jane@example.com", Media: &Media{URL: "https://example.com/a.pdf"}})
+	var featureErr *UnsupportedFeatureError
+	require.ErrorAs(t, err, &featureErr)
+	assert.Equal(t, FeatureLinkedMedia, featureErr.Feature)
+
+	_, err = adapter.render(Message{To: "jane@example.com", SMSRegional: &models.SMSRegional{}})
+	require.ErrorAs(t, err, &featureErr)
+	assert.Equal(t, FeatureSMSRegional, featureErr.Feature)
+}
+
+func TestMMSAdapterRender(t *testing.T) {
+	adapter := &MMSAdapter{}
+
+	mmsMsg, err := adapter.render(Message{
+		From: "InfoMMS", To: "441134960001", Subject: "Shipped", Text: "Your order shipped",
+		Media: &Media{URL: "https://example.com/parcel.jpg"},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, models.MMSHead{From: "InfoMMS", To: "441134960001", Subject: "Shipped"}, mmsMsg.Head)
+	assert.Equal(t, "Your order shipped", mmsMsg.Text)
+	assert.Equal(t, []models.ExternallyHostedMedia{{
+		ContentType: "image/jpeg", ContentID: defaultMediaContentID, ContentURL: "https://example.com/parcel.jpg",
+	}}, mmsMsg.ExternallyHostedMedia)
+	assert.NoError(t, mmsMsg.Validate())
+
+	content := models.NewBytesAttachment("parcel.jpg", "", []byte("jpeg"))
+	mmsMsg, err = adapter.render(Message{
+		From: "InfoMMS", To: "441134960001", Media: &Media{Content: &content, URL: "https://example.com/parcel.jpg"},
+	})
+
+	require.NoError(t, err)
+	assert.Empty(t, mmsMsg.ExternallyHostedMedia)
+	require.Len(t, mmsMsg.MediaParts, 1)
+	assert.Equal(t, defaultMediaContentID, mmsMsg.MediaParts[0].ContentID)
+	assert.Equal(t, "parcel.jpg", mmsMsg.MediaParts[0].Media.Name)
+	assert.NoError(t, mmsMsg.Validate())
+}
+
+func TestRCSAdapterSend(t *testing.T) {
+	handler := newTestHandler(t, "ott/rcs/1/message", http.StatusOK,
+		`{"messages": [{"to": "441134960001", "messageCount": 1, "messageId": "rcs-id",
+		"status": {"groupId": 1, "groupName": "PENDING", "id": 7, "name": "PENDING_ENROUTE"}}]}`,
+		func(body []byte) {
+			var req models.RCSMsg
+			assert.NoError(t, json.Unmarshal(body, &req))
+			assert.Equal(t, "FILE", req.Content.Type)
+			assert.Equal(t, "https://example.com/parcel.jpg", req.Content.File.URL)
+		})
+	adapter := &RCSAdapter{Client: &rcs.Channel{ReqHandler: handler}}
+
+	result, _, err := adapter.Send(context.Background(), Message{
+		From: "myRcsAgent", To: "441134960001", Media: &Media{URL: "https://example.com/parcel.jpg"},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, Result{
+		Channel:   RCS,
+		MessageID: "rcs-id",
+		To:        "441134960001",
+		Status:    Status{GroupID: 1, GroupName: "PENDING", ID: 7, Name: "PENDING_ENROUTE"},
+	}, result)
+}
+
+func TestRCSAdapterCaption(t *testing.T) {
+	adapter := &RCSAdapter{}
+
+	_, err := adapter.render(Message{To: "441134960001", Text: "Look", Media: &Media{URL: "https://example.com/a.jpg"}})
+
+	var featureErr *UnsupportedFeatureError
+	require.ErrorAs(t, err, &featureErr)
+	assert.Equal(t, RCS, featureErr.Channel)
+	assert.Equal(t, FeatureCaption, featureErr.Feature)
+}
+
+func TestRCSAdapterUploadedMedia(t *testing.T) {
+	adapter := &RCSAdapter{}
+	content := models.NewBytesAttachment("parcel.jpg", "image/jpeg", []byte("jpeg"))
+
+	_, err := adapter.render(Message{To: "441134960001", Media: &Media{Content: &content}})
+
+	var featureErr *UnsupportedFeatureError
+	require.ErrorAs(t, err, &featureErr)
+	assert.Equal(t, FeatureUploadedMedia, featureErr.Feature)
+}
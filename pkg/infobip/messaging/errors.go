@@ -0,0 +1,45 @@
+package messaging
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Features of a Message that not every channel supports.
+const (
+	FeatureMedia   = "media"
+	FeatureSubject = "subject"
+	FeatureHTML    = "html"
+	// FeatureCaption is text sent together with media on channels that can't caption that kind of media.
+	FeatureCaption = "caption"
+	// FeatureLinkedMedia is media with a URL only, on channels that upload media content.
+	FeatureLinkedMedia = "linked media"
+	// FeatureUploadedMedia is media with content only, on channels that link media URLs.
+	FeatureUploadedMedia = "uploaded media"
+	FeatureSMSRegional   = "SMS regional parameters"
+)
+
+var ErrMetadataConflict = errors.New("metadata and callback data can't be combined")
+
+// UnsupportedFeatureError is returned when a message uses a feature the channel can't render.
+type UnsupportedFeatureError struct {
+	Channel Channel
+	Feature string
+}
+
+func (e *UnsupportedFeatureError) Error() string {
+	return fmt.Sprintf("%s messages don't support %s", e.Channel, e.Feature)
+}
+
+// UnsupportedChannelError is returned when no adapter is registered for the channel.
+type UnsupportedChannelError struct {
+	Channel Channel
+}
+
+func (e *UnsupportedChannelError) Error() string {
+	return fmt.Sprintf("unsupported channel %q", e.Channel)
+}
+
+func unsupported(channel Channel, feature string) error {
+	return &UnsupportedFeatureError{Channel: channel, Feature: feature}
+}
@@ -0,0 +1,148 @@
+// Package messaging sends a channel-agnostic Message over any of the SMS, WhatsApp, email, MMS and RCS channels.
+// Adapters render the message to the model of each channel and normalize the responses, so callers don't need
+// to handle every request and response type.
+package messaging
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/infobip-community/infobip-api-go-sdk/v3/pkg/infobip/email"
+	"github.com/infobip-community/infobip-api-go-sdk/v3/pkg/infobip/mms"
+	"github.com/infobip-community/infobip-api-go-sdk/v3/pkg/infobip/models"
+	"github.com/infobip-community/infobip-api-go-sdk/v3/pkg/infobip/rcs"
+	"github.com/infobip-community/infobip-api-go-sdk/v3/pkg/infobip/sms"
+	"github.com/infobip-community/infobip-api-go-sdk/v3/pkg/infobip/whatsapp"
+)
+
+type Channel string
+
+const (
+	SMS      Channel = "SMS"
+	WhatsApp Channel = "WHATSAPP"
+	Email    Channel = "EMAIL"
+	MMS      Channel = "MMS"
+	RCS      Channel = "RCS"
+)
+
+// Message is a channel-agnostic message. Fields a channel can't render are reported as UnsupportedFeatureError
+// instead of being dropped.
+type Message struct {
+	// From is the sender: a phone number or alphanumeric sender ID, or an email address.
+	From string
+	// To is the recipient: a phone number, or an email address.
+	To   string
+	Text string
+	// Media is an optional media file, sent as an attachment or as the media of the message.
+	Media *Media
+	// Subject is the subject of emails and MMS messages.
+	Subject string
+	// HTML is the HTML body of emails.
+	HTML string
+	// Metadata is encoded as a JSON object into the callback data of the message, so it is returned in delivery
+	// reports. It can't be combined with CallbackData.
+	Metadata     map[string]string
+	CallbackData string
+	NotifyURL    string
+	// SMSRegional holds the regional parameters of SMS, such as the DLT parameters required for India.
+	SMSRegional *models.SMSRegional
+}
+
+// Media is a media file, publicly reachable at URL or uploaded from Content. WhatsApp and RCS messages link the
+// URL, emails attach the content, and MMS messages send the content when set and link the URL otherwise. The
+// content type selects the kind of WhatsApp message; it is guessed from the extension of the URL, or of the
+// content name, when empty.
+type Media struct {
+	URL         string
+	Content     *models.Attachment
+	ContentType string
+	// Filename is shown for WhatsApp documents and identifies the MMS media part.
+	Filename string
+}
+
+// Result is the normalized response to a sent message.
+type Result struct {
+	Channel   Channel
+	BulkID    string
+	MessageID string
+	To        string
+	Status    Status
+}
+
+type Status struct {
+	GroupID     int
+	GroupName   string
+	ID          int
+	Name        string
+	Description string
+}
+
+// Adapter renders a Message to the model of a channel, sends it and normalizes the response.
+type Adapter interface {
+	Send(ctx context.Context, msg Message) (Result, models.ResponseDetails, error)
+}
+
+// Messages sends a Message over the given channel.
+type Messages interface {
+	Send(ctx context.Context, channel Channel, msg Message) (Result, models.ResponseDetails, error)
+}
+
+// Channels holds the channel clients used by NewService. Nil clients leave their channel unsupported.
+type Channels struct {
+	SMS      sms.SMS
+	WhatsApp whatsapp.WhatsApp
+	Email    email.Email
+	MMS      mms.MMS
+	RCS      rcs.RCS
+}
+
+// Service implements Messages with an adapter per channel. Adapters can be replaced or added to customize how
+// messages are rendered.
+type Service struct {
+	Adapters map[Channel]Adapter
+}
+
+// NewService returns a Service with the adapters of the given channel clients.
+func NewService(channels Channels) *Service {
+	adapters := make(map[Channel]Adapter)
+	if channels.SMS != nil {
+		adapters[SMS] = &SMSAdapter{Client: channels.SMS}
+	}
+	if channels.WhatsApp != nil {
+		adapters[WhatsApp] = &WhatsAppAdapter{Client: channels.WhatsApp}
+	}
+	if channels.Email != nil {
+		adapters[Email] = &EmailAdapter{Client: channels.Email}
+	}
+	if channels.MMS != nil {
+		adapters[MMS] = &MMSAdapter{Client: channels.MMS}
+	}
+	if channels.RCS != nil {
+		adapters[RCS] = &RCSAdapter{Client: channels.RCS}
+	}
+	return &Service{Adapters: adapters}
+}
+
+// Send renders the message for the channel and sends it. Capability gaps are reported before sending; as with
+// the channel clients, unsuccessful HTTP responses are returned in the response details without an error.
+func (s *Service) Send(ctx context.Context, channel Channel, msg Message) (Result, models.ResponseDetails, error) {
+	adapter, ok := s.Adapters[channel]
+	if !ok {
+		return Result{}, models.ResponseDetails{}, &UnsupportedChannelError{Channel: channel}
+	}
+	return adapter.Send(ctx, msg)
+}
+
+func callbackData(msg Message) (string, error) {
+	if len(msg.Metadata) == 0 {
+		return msg.CallbackData, nil
+	}
+	if msg.CallbackData != "" {
+		return "", ErrMetadataConflict
+	}
+	data, err := json.Marshal(msg.Metadata)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
@@ -0,0 +1,78 @@
+package messaging
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/infobip-community/infobip-api-go-sdk/v3/pkg/infobip/models"
+	"github.com/infobip-community/infobip-api-go-sdk/v3/pkg/infobip/sms"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeAdapter struct {
+	sent []Message
+}
+
+func (a *fakeAdapter) Send(_ context.Context, msg Message) (Result, models.ResponseDetails, error) {
+	a.sent = append(a.sent, msg)
+	return Result{Channel: "FAKE", MessageID: "fake-id"}, models.ResponseDetails{}, nil
+}
+
+func TestNewService(t *testing.T) {
+	smsChannel := &sms.Channel{}
+
+	service := NewService(Channels{SMS: smsChannel})
+
+	assert.Equal(t, map[Channel]Adapter{SMS: &SMSAdapter{Client: smsChannel}}, service.Adapters)
+}
+
+func TestServiceSend(t *testing.T) {
+	adapter := &fakeAdapter{}
+	service := &Service{Adapters: map[Channel]Adapter{"FAKE": adapter}}
+	msg := Message{To: "441134960001", Text: "hi"}
+
+	result, _, err := service.Send(context.Background(), "FAKE", msg)
+
+	require.NoError(t, err)
+	assert.Equal(t, "fake-id", result.MessageID)
+	assert.Equal(t, []Message{msg}, adapter.sent)
+}
+
+func TestServiceSendUnsupportedChannel(t *testing.T) {
+	service := NewService(Channels{})
+
+	_, _, err := service.Send(context.Background(), SMS, Message{To: "441134960001", Text: "hi"})
+
+	var channelErr *UnsupportedChannelError
+	require.ErrorAs(t, err, &channelErr)
+	assert.Equal(t, SMS, channelErr.Channel)
+}
+
+func TestServiceSendUnsuccessfulStatus(t *testing.T) {
+	handler := newTestHandler(t, "sms/2/text/advanced", http.StatusBadRequest,
+		`{"requestError": {"serviceException": {"messageId": "BAD_REQUEST", "text": "Bad request"}}}`,
+		func(body []byte) {})
+	service := NewService(Channels{SMS: &sms.Channel{ReqHandler: handler}})
+
+	result, respDetails, err := service.Send(context.Background(), SMS, Message{To: "441134960001", Text: "hi"})
+
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, respDetails.HTTPResponse.StatusCode)
+	assert.Equal(t, "Bad request", respDetails.ErrorResponse.RequestError.ServiceException.Text)
+	assert.Empty(t, result.MessageID)
+}
+
+func TestCallbackData(t *testing.T) {
+	data, err := callbackData(Message{CallbackData: "order-42"})
+	require.NoError(t, err)
+	assert.Equal(t, "order-42", data)
+
+	data, err = callbackData(Message{Metadata: map[string]string{"order": "42", "tenant": "acme"}})
+	require.NoError(t, err)
+	assert.Equal(t, `{"order":"42","tenant":"acme"}`, data)
+
+	_, err = callbackData(Message{CallbackData: "order-42", Metadata: map[string]string{"order": "42"}})
+	assert.ErrorIs(t, err, ErrMetadataConflict)
+}
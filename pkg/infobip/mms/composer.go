@@ -0,0 +1,299 @@
+package mms
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/infobip-community/infobip-api-go-sdk/v3/pkg/infobip/models"
+)
+
+// SizeProfile is the largest message, in bytes, that a carrier accepts.
+type SizeProfile int64
+
+const (
+	CarrierProfile300KB SizeProfile = 300 * 1024
+	CarrierProfile600KB SizeProfile = 600 * 1024
+)
+
+const (
+	defaultSlideDuration = 5 * time.Second
+	defaultLayoutWidth   = 320
+	defaultLayoutHeight  = 480
+	// imageRegionShare is the share of the layout height used by the image region, above the text region.
+	imageRegionShare = 0.75
+	textContentType  = "text/plain; charset=utf-8"
+)
+
+var (
+	ErrNoSlides   = errors.New("at least one slide is required")
+	ErrEmptySlide = errors.New("slide has no media and no text")
+)
+
+// MessageTooLargeError is returned when the media, text and SMIL of a message, with the headers of their parts, exceed
+// the size profile.
+type MessageTooLargeError struct {
+	Size  int64
+	Limit SizeProfile
+}
+
+func (e *MessageTooLargeError) Error() string {
+	return fmt.Sprintf("message is %d bytes, over the limit of %d bytes", e.Size, e.Limit)
+}
+
+// SlideMediaError is returned when the media of a slide does not have the expected kind.
+type SlideMediaError struct {
+	Slide       int
+	Name        string
+	ContentType string
+	Expected    string
+}
+
+func (e *SlideMediaError) Error() string {
+	return fmt.Sprintf("slide %d: %s is %s, expected %s", e.Slide, e.Name, e.ContentType, e.Expected)
+}
+
+// Slide is a page of the message, shown for Duration: an image or a video, text below it, and audio played along.
+type Slide struct {
+	// Visual is an image or a video.
+	Visual *models.Attachment
+	Audio  *models.Attachment
+	Text   string
+	// Duration defaults to 5 seconds.
+	Duration time.Duration
+}
+
+// Composer builds an MMSMsg from slides. Each media and text of a slide is sent as a part with its own content ID,
+// and the SMIL presenting the slides in order is generated.
+type Composer struct {
+	Head   models.MMSHead
+	Slides []Slide
+	// Profile is the size limit checked by Compose. Defaults to CarrierProfile300KB.
+	Profile SizeProfile
+	// Width and Height are the size of the SMIL root layout. Default to 320x480.
+	Width  int
+	Height int
+}
+
+// Compose returns the message with its media parts and SMIL, after checking with MessageSize that it fits the size
+// profile.
+func (c *Composer) Compose() (models.MMSMsg, error) {
+	if len(c.Slides) == 0 {
+		return models.MMSMsg{}, ErrNoSlides
+	}
+
+	var parts []models.MMSMediaPart
+	body := smilBody{}
+	for i, slide := range c.Slides {
+		par, slideParts, err := composeSlide(i+1, slide)
+		if err != nil {
+			return models.MMSMsg{}, err
+		}
+		parts = append(parts, slideParts...)
+		body.Pars = append(body.Pars, par)
+	}
+
+	smil, err := xml.Marshal(smilDocument{Head: c.layout(), Body: body})
+	if err != nil {
+		return models.MMSMsg{}, err
+	}
+	msg := models.MMSMsg{Head: c.Head, MediaParts: parts, SMIL: string(smil)}
+	size, err := MessageSize(&msg)
+	if err != nil {
+		return models.MMSMsg{}, err
+	}
+
+	limit := c.Profile
+	if limit <= 0 {
+		limit = CarrierProfile300KB
+	}
+	if size > int64(limit) {
+		return models.MMSMsg{}, &MessageTooLargeError{Size: size, Limit: limit}
+	}
+	return msg, nil
+}
+
+// MessageSize returns the size of the multipart body of a message: its text, media and SMIL with the headers of
+// their parts. Media are buffered in msg while they are measured, so that media read from readers that can't seek,
+// see models.NewReaderAttachment, can still be sent. Externally hosted media are not counted.
+func MessageSize(msg *models.MMSMsg) (int64, error) {
+	for i := range msg.MediaParts {
+		if _, err := bufferAttachment(&msg.MediaParts[i].Media); err != nil {
+			return 0, err
+		}
+	}
+	if msg.MediaSource == nil && msg.Media != nil {
+		media := models.NewOSFileAttachment(msg.Media)
+		msg.MediaSource = &media
+	}
+	if msg.MediaSource != nil {
+		if _, err := bufferAttachment(msg.MediaSource); err != nil {
+			return 0, err
+		}
+	}
+
+	var counter byteCounter
+	writer := multipart.NewWriter(&counter)
+	if err := msg.WriteMultipart(writer); err != nil {
+		return 0, err
+	}
+	if err := writer.Close(); err != nil {
+		return 0, err
+	}
+	return int64(counter), nil
+}
+
+// byteCounter is an io.Writer counting the bytes written to it.
+type byteCounter int64
+
+func (c *byteCounter) Write(p []byte) (int, error) {
+	*c += byteCounter(len(p))
+	return len(p), nil
+}
+
+// bufferAttachment reads the content of attachment and replaces it with an attachment holding that content, which
+// can be read again.
+func bufferAttachment(attachment *models.Attachment) ([]byte, error) {
+	reader, err := attachment.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	content, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+	*attachment = models.NewBytesAttachment(attachment.Name, attachment.ContentType, content)
+	return content, nil
+}
+
+func composeSlide(number int, slide Slide) (smilPar, []models.MMSMediaPart, error) {
+	duration := slide.Duration
+	if duration <= 0 {
+		duration = defaultSlideDuration
+	}
+	par := smilPar{Duration: fmt.Sprintf("%dms", duration.Milliseconds())}
+	var parts []models.MMSMediaPart
+
+	if slide.Visual != nil {
+		contentType := mediaContentType(*slide.Visual)
+		contentID := fmt.Sprintf("slide%d-visual%s", number, path.Ext(slide.Visual.Name))
+		element := &smilMedia{Src: contentID, Region: "Image"}
+		switch {
+		case strings.HasPrefix(contentType, "image/"):
+			par.Image = element
+		case strings.HasPrefix(contentType, "video/"):
+			par.Video = element
+		default:
+			return par, nil, &SlideMediaError{
+				Slide: number, Name: slide.Visual.Name, ContentType: contentType, Expected: "an image or a video",
+			}
+		}
+		parts = append(parts, mediaPart(contentID, *slide.Visual, contentType))
+	}
+
+	if slide.Text != "" {
+		contentID := fmt.Sprintf("slide%d-text.txt", number)
+		par.Text = &smilMedia{Src: contentID, Region: "Text"}
+		parts = append(parts, models.MMSMediaPart{
+			ContentID: contentID,
+			Media:     models.NewBytesAttachment(contentID, textContentType, []byte(slide.Text)),
+		})
+	}
+
+	if slide.Audio != nil {
+		contentType := mediaContentType(*slide.Audio)
+		if !strings.HasPrefix(contentType, "audio/") {
+			return par, nil, &SlideMediaError{
+				Slide: number, Name: slide.Audio.Name, ContentType: contentType, Expected: "audio",
+			}
+		}
+		contentID := fmt.Sprintf("slide%d-audio%s", number, path.Ext(slide.Audio.Name))
+		par.Audio = &smilMedia{Src: contentID}
+		parts = append(parts, mediaPart(contentID, *slide.Audio, contentType))
+	}
+
+	if len(parts) == 0 {
+		return par, nil, fmt.Errorf("slide %d: %w", number, ErrEmptySlide)
+	}
+	return par, parts, nil
+}
+
+func mediaPart(contentID string, media models.Attachment, contentType string) models.MMSMediaPart {
+	media.ContentType = contentType
+	return models.MMSMediaPart{ContentID: contentID, Media: media}
+}
+
+func mediaContentType(media models.Attachment) string {
+	if media.ContentType != "" {
+		return media.ContentType
+	}
+	return mime.TypeByExtension(strings.ToLower(path.Ext(media.Name)))
+}
+
+func (c *Composer) layout() smilHead {
+	width, height := c.Width, c.Height
+	if width <= 0 || height <= 0 {
+		width, height = defaultLayoutWidth, defaultLayoutHeight
+	}
+	imageHeight := int(float64(height) * imageRegionShare)
+	return smilHead{Layout: smilLayout{
+		Root: smilRootLayout{Width: width, Height: height},
+		Regions: []smilRegion{
+			{ID: "Image", Top: 0, Width: width, Height: imageHeight, Fit: "meet"},
+			{ID: "Text", Top: imageHeight, Width: width, Height: height - imageHeight, Fit: "scroll"},
+		},
+	}}
+}
+
+type smilDocument struct {
+	XMLName xml.Name `xml:"smil"`
+	Head    smilHead `xml:"head"`
+	Body    smilBody `xml:"body"`
+}
+
+type smilHead struct {
+	Layout smilLayout `xml:"layout"`
+}
+
+type smilLayout struct {
+	Root    smilRootLayout `xml:"root-layout"`
+	Regions []smilRegion   `xml:"region"`
+}
+
+type smilRootLayout struct {
+	Width  int `xml:"width,attr"`
+	Height int `xml:"height,attr"`
+}
+
+type smilRegion struct {
+	ID     string `xml:"id,attr"`
+	Top    int    `xml:"top,attr"`
+	Left   int    `xml:"left,attr"`
+	Width  int    `xml:"width,attr"`
+	Height int    `xml:"height,attr"`
+	Fit    string `xml:"fit,attr"`
+}
+
+type smilBody struct {
+	Pars []smilPar `xml:"par"`
+}
+
+type smilPar struct {
+	Duration string     `xml:"dur,attr"`
+	Image    *smilMedia `xml:"img"`
+	Video    *smilMedia `xml:"video"`
+	Text     *smilMedia `xml:"text"`
+	Audio    *smilMedia `xml:"audio"`
+}
+
+type smilMedia struct {
+	Src    string `xml:"src,attr"`
+	Region string `xml:"region,attr,omitempty"`
+}
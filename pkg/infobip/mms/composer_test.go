@@ -0,0 +1,164 @@
+package mms
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/infobip-community/infobip-api-go-sdk/v3/pkg/infobip/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func attachment(name string, contentType string, size int) *models.Attachment {
+	media := models.NewBytesAttachment(name, contentType, bytes.Repeat([]byte("x"), size))
+	return &media
+}
+
+func TestComposerCompose(t *testing.T) {
+	composer := Composer{
+		Head: models.MMSHead{From: "16175551213", To: "16175551212", Subject: "Your order"},
+		Slides: []Slide{
+			{Visual: attachment("parcel.JPG", "", 1000), Text: "Your parcel <#42> is on its way"},
+			{
+				Visual:   attachment("unboxing.mp4", "video/mp4", 2000),
+				Audio:    attachment("jingle.mp3", "audio/mpeg", 500),
+				Duration: 12500 * time.Millisecond,
+			},
+		},
+	}
+
+	msg, err := composer.Compose()
+
+	require.NoError(t, err)
+	require.NoError(t, msg.Validate())
+	assert.Equal(t, composer.Head, msg.Head)
+	assert.Equal(t, `<smil><head><layout><root-layout width="320" height="480"></root-layout>`+
+		`<region id="Image" top="0" left="0" width="320" height="360" fit="meet"></region>`+
+		`<region id="Text" top="360" left="0" width="320" height="120" fit="scroll"></region></layout></head>`+
+		`<body><par dur="5000ms"><img src="slide1-visual.JPG" region="Image"></img>`+
+		`<text src="slide1-text.txt" region="Text"></text></par>`+
+		`<par dur="12500ms"><video src="slide2-visual.mp4" region="Image"></video>`+
+		`<audio src="slide2-audio.mp3"></audio></par></body></smil>`, msg.SMIL)
+
+	expected := []struct{ contentID, contentType, content string }{
+		{"slide1-visual.JPG", "image/jpeg", strings.Repeat("x", 1000)},
+		{"slide1-text.txt", "text/plain; charset=utf-8", "Your parcel <#42> is on its way"},
+		{"slide2-visual.mp4", "video/mp4", strings.Repeat("x", 2000)},
+		{"slide2-audio.mp3", "audio/mpeg", strings.Repeat("x", 500)},
+	}
+	require.Len(t, msg.MediaParts, len(expected))
+	for i, e := range expected {
+		part := msg.MediaParts[i]
+		assert.Equal(t, e.contentID, part.ContentID)
+		assert.Equal(t, e.contentType, part.Media.ContentType)
+		reader, openErr := part.Media.Open()
+		require.NoError(t, openErr)
+		content, readErr := ioutil.ReadAll(reader)
+		require.NoError(t, readErr)
+		assert.Equal(t, e.content, string(content))
+	}
+
+	size, err := MessageSize(&msg)
+	require.NoError(t, err)
+	body, err := msg.Marshal()
+	require.NoError(t, err)
+	assert.Equal(t, int64(body.Len()), size)
+	assert.Greater(t, size, int64(3500+len("Your parcel <#42> is on its way")+len(msg.SMIL)))
+}
+
+func TestMessageSizeStreamedMedia(t *testing.T) {
+	media := models.NewReaderAttachment("photo.png", "image/png", io.MultiReader(strings.NewReader("png bytes")))
+	msg := models.MMSMsg{
+		Head:       models.MMSHead{From: "16175551213", To: "16175551212"},
+		MediaParts: []models.MMSMediaPart{{ContentID: "photo.png", Media: media}},
+	}
+
+	size, err := MessageSize(&msg)
+	require.NoError(t, err)
+
+	body, err := msg.Marshal()
+	require.NoError(t, err)
+	assert.Contains(t, body.String(), "png bytes")
+	assert.Equal(t, int64(body.Len()), size)
+}
+
+func TestComposerComposeStreamedMedia(t *testing.T) {
+	visual := models.NewReaderAttachment("photo.png", "image/png", io.MultiReader(strings.NewReader("png bytes")))
+	composer := Composer{
+		Head:   models.MMSHead{From: "16175551213", To: "16175551212"},
+		Slides: []Slide{{Visual: &visual}},
+	}
+
+	msg, err := composer.Compose()
+	require.NoError(t, err)
+
+	for i := 0; i < 2; i++ {
+		body, marshalErr := msg.Marshal()
+		require.NoError(t, marshalErr)
+		assert.Contains(t, body.String(), "png bytes")
+	}
+}
+
+func TestComposerLayout(t *testing.T) {
+	composer := Composer{
+		Head:   models.MMSHead{From: "16175551213", To: "16175551212"},
+		Slides: []Slide{{Text: "Only text"}},
+		Width:  640,
+		Height: 400,
+	}
+
+	msg, err := composer.Compose()
+
+	require.NoError(t, err)
+	assert.Contains(t, msg.SMIL, `<root-layout width="640" height="400">`)
+	assert.Contains(t, msg.SMIL, `<region id="Text" top="300" left="0" width="640" height="100" fit="scroll">`)
+	assert.Len(t, msg.MediaParts, 1)
+}
+
+func TestComposerSizeProfiles(t *testing.T) {
+	composer := Composer{
+		Head:   models.MMSHead{From: "16175551213", To: "16175551212"},
+		Slides: []Slide{{Visual: attachment("photo.png", "", 400*1024)}},
+	}
+
+	_, err := composer.Compose()
+	var tooLarge *MessageTooLargeError
+	require.ErrorAs(t, err, &tooLarge)
+	assert.Equal(t, CarrierProfile300KB, tooLarge.Limit)
+	assert.Greater(t, tooLarge.Size, int64(400*1024))
+
+	composer.Profile = CarrierProfile600KB
+	_, err = composer.Compose()
+	require.NoError(t, err)
+}
+
+func TestComposerErrors(t *testing.T) {
+	head := models.MMSHead{From: "16175551213", To: "16175551212"}
+
+	_, err := (&Composer{Head: head}).Compose()
+	assert.ErrorIs(t, err, ErrNoSlides)
+
+	_, err = (&Composer{Head: head, Slides: []Slide{{Text: "Hi"}, {Duration: time.Second}}}).Compose()
+	assert.ErrorIs(t, err, ErrEmptySlide)
+	assert.EqualError(t, err, "slide 2: slide has no media and no text")
+
+	_, err = (&Composer{Head: head, Slides: []Slide{{Visual: attachment("song.mp3", "audio/mpeg", 1)}}}).Compose()
+	var mediaErr *SlideMediaError
+	require.ErrorAs(t, err, &mediaErr)
+	assert.EqualError(t, err, "slide 1: song.mp3 is audio/mpeg, expected an image or a video")
+
+	_, err = (&Composer{Head: head, Slides: []Slide{{Audio: attachment("photo.png", "", 1)}}}).Compose()
+	require.ErrorAs(t, err, &mediaErr)
+	assert.Equal(t, "audio", mediaErr.Expected)
+
+	failing := models.Attachment{Name: "photo.png", Open: func() (io.ReadCloser, error) {
+		return nil, errors.New("open failed")
+	}}
+	_, err = (&Composer{Head: head, Slides: []Slide{{Visual: &failing}}}).Compose()
+	assert.EqualError(t, err, "open failed")
+}
@@ -0,0 +1,105 @@
+package mms
+
+import (
+	"context"
+
+	"github.com/infobip-community/infobip-api-go-sdk/v3/pkg/infobip/media"
+	"github.com/infobip-community/infobip-api-go-sdk/v3/pkg/infobip/models"
+)
+
+type fitPart struct {
+	media   *models.Attachment
+	content []byte
+	budget  int64
+	kept    bool
+}
+
+// fitMedia fits the media of a message exceeding the size profile with the pipeline. Media the pipeline does not
+// support, the text and the SMIL are kept as they are; the rest of the profile is shared equally between the other
+// media, and media smaller than their share are kept as well, leaving more to the others. Media are read once and
+// sent from memory, whether they are fitted or not.
+func fitMedia(ctx context.Context, pipeline *media.Pipeline, profile SizeProfile, msg models.MMSMsg) (
+	models.MMSMsg, []models.MediaTransformation, error,
+) {
+	if profile <= 0 {
+		profile = CarrierProfile300KB
+	}
+	if msg.MediaSource == nil && msg.Media != nil {
+		source := models.NewOSFileAttachment(msg.Media)
+		msg.MediaSource = &source
+	}
+	if msg.MediaSource != nil {
+		source := *msg.MediaSource
+		msg.MediaSource = &source
+	}
+	msg.MediaParts = append([]models.MMSMediaPart(nil), msg.MediaParts...)
+
+	attachments := make([]*models.Attachment, 0, len(msg.MediaParts)+1)
+	if msg.MediaSource != nil {
+		attachments = append(attachments, msg.MediaSource)
+	}
+	for i := range msg.MediaParts {
+		attachments = append(attachments, &msg.MediaParts[i].Media)
+	}
+
+	size := int64(len(msg.Text) + len(msg.SMIL))
+	fixed := size
+	var parts []*fitPart
+	for _, attachment := range attachments {
+		content, err := bufferAttachment(attachment)
+		if err != nil {
+			return msg, nil, err
+		}
+		size += int64(len(content))
+		if !pipeline.Supports(media.ContentType(*attachment)) {
+			fixed += int64(len(content))
+			continue
+		}
+		parts = append(parts, &fitPart{media: attachment, content: content})
+	}
+	if size <= int64(profile) {
+		return msg, nil, nil
+	}
+	if len(parts) == 0 || fixed >= int64(profile) {
+		return msg, nil, &MessageTooLargeError{Size: size, Limit: profile}
+	}
+
+	shareBudget(parts, int64(profile)-fixed)
+	var transformations []models.MediaTransformation
+	for _, part := range parts {
+		if part.kept {
+			continue
+		}
+		fitted, transformation, err := pipeline.FitContent(ctx, *part.media, part.content, part.budget)
+		if err != nil {
+			return msg, nil, err
+		}
+		*part.media = fitted
+		if transformation != nil {
+			transformations = append(transformations, *transformation)
+		}
+	}
+	return msg, transformations, nil
+}
+
+// shareBudget shares available equally between the parts. Parts fitting in their share are kept, and what they
+// leave is shared again between the others.
+func shareBudget(parts []*fitPart, available int64) {
+	pending := int64(len(parts))
+	for kept := true; kept && pending > 0; {
+		kept = false
+		share := available / pending
+		for _, part := range parts {
+			if part.kept {
+				continue
+			}
+			part.budget = share
+			if size := int64(len(part.content)); size <= share {
+				part.kept = true
+				available -= size
+				pending--
+				kept = true
+			}
+		}
+	}
+}
@@ -0,0 +1,159 @@
+package mms
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/infobip-community/infobip-api-go-sdk/v3/internal"
+	"github.com/infobip-community/infobip-api-go-sdk/v3/pkg/infobip/media"
+	"github.com/infobip-community/infobip-api-go-sdk/v3/pkg/infobip/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type truncatingProcessor struct{}
+
+func (truncatingProcessor) Supports(contentType string) bool {
+	return strings.HasPrefix(contentType, "image/")
+}
+
+func (truncatingProcessor) Process(_ context.Context, attachment models.Attachment, content []byte, budget int64) (
+	models.Attachment, models.MediaTransformation, error,
+) {
+	return models.NewBytesAttachment(attachment.Name, attachment.ContentType, content[:budget]),
+		models.MediaTransformation{
+			Name: attachment.Name, Processor: "truncate", OriginalContentType: attachment.ContentType,
+			ContentType: attachment.ContentType, OriginalSize: int64(len(content)), Size: budget,
+		}, nil
+}
+
+// newMediaServer returns a channel fitting media with truncatingProcessor, and records the sizes of the media parts
+// received by the server.
+func newMediaServer(t *testing.T, sizes *[]int64) *Channel {
+	serv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseMultipartForm(int64(CarrierProfile600KB)))
+		for _, header := range r.MultipartForm.File["media"] {
+			*sizes = append(*sizes, header.Size)
+		}
+		_, err := w.Write([]byte(`{"bulkId": "1", "messages": [{"to": "16175551212", "messageId": "1"}]}`))
+		assert.NoError(t, err)
+	}))
+	t.Cleanup(serv.Close)
+	return &Channel{
+		ReqHandler:    internal.HTTPHandler{HTTPClient: http.Client{}, BaseURL: serv.URL, APIKey: "secret"},
+		MediaPipeline: media.NewPipeline(truncatingProcessor{}),
+	}
+}
+
+func TestSendFitsMediaParts(t *testing.T) {
+	var sizes []int64
+	channel := newMediaServer(t, &sizes)
+	msg := models.MMSMsg{
+		Head: models.MMSHead{From: "16175551213", To: "16175551212"},
+		Text: "Hi",
+		MediaParts: []models.MMSMediaPart{
+			{ContentID: "photo.jpg", Media: *attachment("photo.jpg", "image/jpeg", 250000)},
+			{ContentID: "banner.png", Media: *attachment("banner.png", "", 200000)},
+			{ContentID: "logo.png", Media: *attachment("logo.png", "image/png", 20000)},
+			{ContentID: "jingle.mp3", Media: *attachment("jingle.mp3", "audio/mpeg", 50000)},
+		},
+	}
+
+	resp, _, err := channel.Send(context.Background(), msg)
+
+	require.NoError(t, err)
+	assert.Equal(t, "1", resp.BulkID)
+	// The logo and the audio are kept, and the rest of the 300 KB is shared between the photo and the banner.
+	share := (int64(CarrierProfile300KB) - 2 - 20000 - 50000) / 2
+	assert.Equal(t, []int64{share, share, 20000, 50000}, sizes)
+	assert.Equal(t, []models.MediaTransformation{
+		{
+			Name: "photo.jpg", Processor: "truncate", OriginalContentType: "image/jpeg", ContentType: "image/jpeg",
+			OriginalSize: 250000, Size: share,
+		},
+		{
+			Name: "banner.png", Processor: "truncate", OriginalContentType: "image/png", ContentType: "image/png",
+			OriginalSize: 200000, Size: share,
+		},
+	}, resp.MediaTransformations)
+	assert.Equal(t, int64(200000), int64(len(readAll(t, msg.MediaParts[1].Media))))
+}
+
+func TestSendFitsMediaFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "photo.jpg")
+	require.NoError(t, ioutil.WriteFile(path, make([]byte, 400000), 0o600))
+	file, err := os.Open(path)
+	require.NoError(t, err)
+	defer file.Close()
+	var sizes []int64
+	channel := newMediaServer(t, &sizes)
+	channel.SizeProfile = CarrierProfile600KB
+
+	resp, _, err := channel.Send(context.Background(), models.MMSMsg{
+		Head: models.MMSHead{From: "16175551213", To: "16175551212"}, Media: file,
+	})
+	require.NoError(t, err)
+	assert.Empty(t, resp.MediaTransformations)
+	assert.Equal(t, []int64{400000}, sizes)
+
+	channel.SizeProfile = CarrierProfile300KB
+	resp, _, err = channel.Send(context.Background(), models.MMSMsg{
+		Head: models.MMSHead{From: "16175551213", To: "16175551212"}, Media: file,
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.MediaTransformations, 1)
+	assert.Equal(t, "photo.jpg", resp.MediaTransformations[0].Name)
+	assert.Equal(t, []int64{400000, int64(CarrierProfile300KB)}, sizes)
+}
+
+func TestSendStreamedMedia(t *testing.T) {
+	var sizes []int64
+	channel := newMediaServer(t, &sizes)
+	msg := models.MMSMsg{
+		Head: models.MMSHead{From: "16175551213", To: "16175551212"},
+		MediaParts: []models.MMSMediaPart{{
+			ContentID: "logo.png",
+			Media: models.NewReaderAttachment(
+				"logo.png", "image/png", io.MultiReader(bytes.NewReader(make([]byte, 20000)))),
+		}},
+	}
+
+	resp, _, err := channel.Send(context.Background(), msg)
+
+	require.NoError(t, err)
+	assert.Empty(t, resp.MediaTransformations)
+	assert.Equal(t, []int64{20000}, sizes)
+}
+
+func TestSendMediaTooLarge(t *testing.T) {
+	var sizes []int64
+	channel := newMediaServer(t, &sizes)
+
+	_, _, err := channel.Send(context.Background(), models.MMSMsg{
+		Head: models.MMSHead{From: "16175551213", To: "16175551212"},
+		MediaParts: []models.MMSMediaPart{
+			{ContentID: "photo.jpg", Media: *attachment("photo.jpg", "image/jpeg", 1000)},
+			{ContentID: "clip.mp4", Media: *attachment("clip.mp4", "video/mp4", int(CarrierProfile300KB))},
+		},
+	})
+
+	assert.Equal(t, &MessageTooLargeError{Size: int64(CarrierProfile300KB) + 1000, Limit: CarrierProfile300KB}, err)
+	assert.Empty(t, sizes)
+}
+
+func readAll(t *testing.T, attachment models.Attachment) []byte {
+	reader, err := attachment.Open()
+	require.NoError(t, err)
+	defer reader.Close()
+	content, err := ioutil.ReadAll(reader)
+	require.NoError(t, err)
+	return content
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/infobip-community/infobip-api-go-sdk/v3/internal"
+	"github.com/infobip-community/infobip-api-go-sdk/v3/pkg/infobip/media"
 	"github.com/infobip-community/infobip-api-go-sdk/v3/pkg/infobip/models"
 )
 
@@ -20,6 +21,11 @@ type MMS interface {
 
 type Channel struct {
 	ReqHandler internal.HTTPHandler
+	// MediaPipeline, when set, fits the media of messages exceeding SizeProfile before they are sent. The applied
+	// transformations are listed in SendMMSResponse.MediaTransformations.
+	MediaPipeline *media.Pipeline
+	// SizeProfile defaults to CarrierProfile300KB.
+	SizeProfile SizeProfile
 }
 
 const (
@@ -32,7 +38,15 @@ func (mms *Channel) Send(
 	ctx context.Context,
 	msg models.MMSMsg,
 ) (msgResp models.SendMMSResponse, respDetails models.ResponseDetails, err error) {
+	var transformations []models.MediaTransformation
+	if mms.MediaPipeline != nil {
+		msg, transformations, err = fitMedia(ctx, mms.MediaPipeline, mms.SizeProfile, msg)
+		if err != nil {
+			return msgResp, respDetails, err
+		}
+	}
 	respDetails, err = mms.ReqHandler.PostMultipartReq(ctx, &msg, &msgResp, sendMessagePath)
+	msgResp.MediaTransformations = transformations
 	return msgResp, respDetails, err
 }
 
@@ -0,0 +1,200 @@
+package models
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"io/ioutil"
+	"math"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+)
+
+const defaultAttachmentContentType = "application/octet-stream"
+
+// ErrAttachmentDownload is returned when an attachment created with NewURLAttachment can't be downloaded.
+var ErrAttachmentDownload = errors.New("attachment could not be downloaded")
+
+// Attachment is a file sent in a multipart request, such as an email attachment or MMS media. Open is called every
+// time the request body is written, so the content can be sent again when the request is retried; the returned
+// reader is closed once copied. Resources owned by the caller are never closed.
+type Attachment struct {
+	Name string `validate:"required"`
+	// ContentType is guessed from the extension of Name when empty.
+	ContentType string
+	Open        func() (io.ReadCloser, error) `validate:"required"`
+}
+
+// NewBytesAttachment returns an attachment with in-memory content.
+func NewBytesAttachment(name string, contentType string, content []byte) Attachment {
+	return Attachment{
+		Name:        name,
+		ContentType: contentType,
+		Open: func() (io.ReadCloser, error) {
+			return ioutil.NopCloser(bytes.NewReader(content)), nil
+		},
+	}
+}
+
+// NewReaderAttachment returns an attachment read from reader, which is never closed. Readers implementing
+// io.Seeker are rewound when the content is read again; other readers can only be read once.
+func NewReaderAttachment(name string, contentType string, reader io.Reader) Attachment {
+	return Attachment{Name: name, ContentType: contentType, Open: rewindableOpener(reader)}
+}
+
+// NewFileAttachment returns an attachment opened from the file at filePath whenever it is read. Relative paths are
+// resolved when the attachment is created, so later changes of the working directory don't affect it.
+func NewFileAttachment(filePath string) Attachment {
+	absPath := absolutePath(filePath)
+	return Attachment{
+		Name: filepath.Base(filePath),
+		Open: func() (io.ReadCloser, error) {
+			return os.Open(absPath)
+		},
+	}
+}
+
+// NewFSAttachment returns an attachment opened from fsys whenever it is read, e.g. from an embed.FS or a file
+// system backed by object storage.
+func NewFSAttachment(fsys fs.FS, name string) Attachment {
+	return Attachment{
+		Name: path.Base(name),
+		Open: func() (io.ReadCloser, error) {
+			return fsys.Open(name)
+		},
+	}
+}
+
+// NewURLAttachment returns an attachment downloaded from rawURL with client whenever it is read, or with
+// http.DefaultClient when client is nil. It is named after the last element of the URL path; set ContentType on the
+// returned attachment when the name has no meaningful extension.
+func NewURLAttachment(client *http.Client, rawURL string) Attachment {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	name := rawURL
+	if parsed, err := url.Parse(rawURL); err == nil {
+		name = path.Base(parsed.Path)
+		if name == "." || name == "/" {
+			name = parsed.Host
+		}
+	}
+	return Attachment{
+		Name: name,
+		Open: func() (io.ReadCloser, error) {
+			req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, rawURL, nil)
+			if err != nil {
+				return nil, err
+			}
+			resp, err := client.Do(req)
+			if err != nil {
+				return nil, err
+			}
+			if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+				resp.Body.Close()
+				return nil, fmt.Errorf("%w: %s returned %s", ErrAttachmentDownload, rawURL, resp.Status)
+			}
+			return resp.Body, nil
+		},
+	}
+}
+
+// NewOSFileAttachment adapts an open file, which is never closed. Seekable files are read from their offset when the
+// attachment is created, with ReadAt so that the offset is left unchanged, as many times as needed. Other files,
+// such as pipes, are read once; ErrMediaNotRewindable is returned when they are read again.
+func NewOSFileAttachment(file *os.File) Attachment {
+	attachment := Attachment{Name: filepath.Base(file.Name())}
+	offset, err := file.Seek(0, io.SeekCurrent)
+	if err != nil {
+		attachment.Open = rewindableOpener(file)
+		return attachment
+	}
+	attachment.Open = func() (io.ReadCloser, error) {
+		return ioutil.NopCloser(io.NewSectionReader(file, offset, math.MaxInt64-offset)), nil
+	}
+	return attachment
+}
+
+func absolutePath(filePath string) string {
+	if absPath, err := filepath.Abs(filePath); err == nil {
+		return absPath
+	}
+	return filePath
+}
+
+// rewindableOpener returns an Open function reading reader without closing it. Seekable readers are rewound to the
+// offset of the first read; ErrMediaNotRewindable is returned when other readers, such as pipes, are read again.
+func rewindableOpener(reader io.Reader) func() (io.ReadCloser, error) {
+	read, seekable := false, false
+	var offset int64
+	return func() (io.ReadCloser, error) {
+		seeker, ok := reader.(io.Seeker)
+		if !read {
+			if ok {
+				current, err := seeker.Seek(0, io.SeekCurrent)
+				seekable, offset = err == nil, current
+			}
+		} else {
+			if !seekable {
+				return nil, ErrMediaNotRewindable
+			}
+			if _, err := seeker.Seek(offset, io.SeekStart); err != nil {
+				return nil, err
+			}
+		}
+		read = true
+		return ioutil.NopCloser(reader), nil
+	}
+}
+
+func osFileAttachments(files []*os.File) []Attachment {
+	attachments := make([]Attachment, 0, len(files))
+	for _, file := range files {
+		attachments = append(attachments, NewOSFileAttachment(file))
+	}
+	return attachments
+}
+
+func (a Attachment) contentType() string {
+	if a.ContentType != "" {
+		return a.ContentType
+	}
+	if guessed := mime.TypeByExtension(filepath.Ext(a.Name)); guessed != "" {
+		return guessed
+	}
+	return defaultAttachmentContentType
+}
+
+func writeMultipartAttachment(writer *multipart.Writer, fieldName string, attachment Attachment) error {
+	return writeMultipartAttachmentHeader(writer, fieldName, attachment, textproto.MIMEHeader{})
+}
+
+// writeMultipartAttachmentHeader writes the attachment as a file part with additional headers, e.g. Content-ID.
+func writeMultipartAttachmentHeader(
+	writer *multipart.Writer, fieldName string, attachment Attachment, header textproto.MIMEHeader,
+) error {
+	reader, err := attachment.Open()
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	header.Set("Content-Type", attachment.contentType())
+	header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="%s"; filename="%s"`,
+		escapeQuotes(fieldName), escapeQuotes(attachment.Name)))
+	part, err := writer.CreatePart(header)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(part, reader)
+	return err
+}
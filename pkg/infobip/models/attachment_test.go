@@ -0,0 +1,166 @@
+package models
+
+import (
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func readAttachment(t *testing.T, attachment Attachment) string {
+	reader, err := attachment.Open()
+	require.NoError(t, err)
+	defer reader.Close()
+	content, err := ioutil.ReadAll(reader)
+	require.NoError(t, err)
+	return string(content)
+}
+
+func TestNewBytesAttachment(t *testing.T) {
+	attachment := NewBytesAttachment("invoice.pdf", "", []byte("%PDF"))
+
+	assert.Equal(t, "invoice.pdf", attachment.Name)
+	assert.Equal(t, "application/pdf", attachment.contentType())
+	assert.Equal(t, "%PDF", readAttachment(t, attachment))
+	assert.Equal(t, "%PDF", readAttachment(t, attachment))
+}
+
+func TestNewReaderAttachment(t *testing.T) {
+	seekable := strings.NewReader("header,content")
+	_, err := seekable.Seek(7, io.SeekStart)
+	require.NoError(t, err)
+	attachment := NewReaderAttachment("report", "text/csv", seekable)
+
+	assert.Equal(t, "text/csv", attachment.contentType())
+	assert.Equal(t, "content", readAttachment(t, attachment))
+	assert.Equal(t, "content", readAttachment(t, attachment))
+
+	streamed := NewReaderAttachment("report", "", io.MultiReader(strings.NewReader("content")))
+	assert.Equal(t, defaultAttachmentContentType, streamed.contentType())
+	assert.Equal(t, "content", readAttachment(t, streamed))
+	_, err = streamed.Open()
+	assert.ErrorIs(t, err, ErrMediaNotRewindable)
+}
+
+func TestNewFileAttachment(t *testing.T) {
+	attachment := NewFileAttachment("testdata/image.png")
+
+	assert.Equal(t, "image.png", attachment.Name)
+	assert.Equal(t, "image/png", attachment.contentType())
+	assert.NotEmpty(t, readAttachment(t, attachment))
+
+	_, err := NewFileAttachment("testdata/missing.png").Open()
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestNewFSAttachment(t *testing.T) {
+	fsys := fstest.MapFS{"assets/logo.svg": &fstest.MapFile{Data: []byte("<svg/>")}}
+
+	attachment := NewFSAttachment(fsys, "assets/logo.svg")
+
+	assert.Equal(t, "logo.svg", attachment.Name)
+	assert.Equal(t, "image/svg+xml", attachment.contentType())
+	assert.Equal(t, "<svg/>", readAttachment(t, attachment))
+}
+
+func TestNewOSFileAttachment(t *testing.T) {
+	file, err := ioutil.TempFile(t.TempDir(), "attachment*.txt")
+	require.NoError(t, err)
+	defer file.Close()
+	_, err = file.WriteString("temporary file's content")
+	require.NoError(t, err)
+	_, err = file.Seek(0, io.SeekStart)
+	require.NoError(t, err)
+
+	attachment := NewOSFileAttachment(file)
+
+	assert.Equal(t, "text/plain; charset=utf-8", attachment.contentType())
+	assert.Equal(t, "temporary file's content", readAttachment(t, attachment))
+	assert.Equal(t, "temporary file's content", readAttachment(t, attachment))
+	_, err = file.Seek(0, io.SeekStart)
+	assert.NoError(t, err, "the file must not be closed")
+}
+
+func TestNewOSFileAttachmentRelativePath(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "notes.txt"), []byte("notes"), 0o600))
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(dir))
+	file, err := os.Open("notes.txt")
+	require.NoError(t, err)
+	defer file.Close()
+	attachment := NewOSFileAttachment(file)
+	named := NewFileAttachment("notes.txt")
+	require.NoError(t, os.Chdir(wd))
+
+	assert.Equal(t, "notes", readAttachment(t, attachment))
+	assert.Equal(t, "notes", readAttachment(t, named))
+}
+
+func TestNewOSFileAttachmentOffset(t *testing.T) {
+	file, err := ioutil.TempFile(t.TempDir(), "attachment*.csv")
+	require.NoError(t, err)
+	defer file.Close()
+	_, err = file.WriteString("header,content")
+	require.NoError(t, err)
+	_, err = file.Seek(7, io.SeekStart)
+	require.NoError(t, err)
+
+	attachment := NewOSFileAttachment(file)
+	require.NoError(t, os.Remove(file.Name()))
+	require.NoError(t, ioutil.WriteFile(file.Name(), []byte("replaced"), 0o600))
+
+	assert.Equal(t, "content", readAttachment(t, attachment))
+	assert.Equal(t, "content", readAttachment(t, attachment))
+	offset, err := file.Seek(0, io.SeekCurrent)
+	require.NoError(t, err)
+	assert.Equal(t, int64(7), offset)
+}
+
+func TestNewOSFileAttachmentPipe(t *testing.T) {
+	reader, writer, err := os.Pipe()
+	require.NoError(t, err)
+	defer reader.Close()
+	go func() {
+		_, _ = writer.WriteString("piped content")
+		writer.Close()
+	}()
+
+	attachment := NewOSFileAttachment(reader)
+
+	assert.Equal(t, "piped content", readAttachment(t, attachment))
+	_, err = attachment.Open()
+	assert.ErrorIs(t, err, ErrMediaNotRewindable)
+}
+
+func TestNewURLAttachment(t *testing.T) {
+	serv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/media/logo.png" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, err := w.Write([]byte("png bytes"))
+		assert.NoError(t, err)
+	}))
+	defer serv.Close()
+
+	attachment := NewURLAttachment(serv.Client(), serv.URL+"/media/logo.png?size=small")
+
+	assert.Equal(t, "logo.png", attachment.Name)
+	assert.Equal(t, "image/png", attachment.contentType())
+	assert.Equal(t, "png bytes", readAttachment(t, attachment))
+	assert.Equal(t, "png bytes", readAttachment(t, attachment))
+
+	_, err := NewURLAttachment(nil, serv.URL+"/media/missing.png").Open()
+	assert.ErrorIs(t, err, ErrAttachmentDownload)
+	assert.Contains(t, err.Error(), "404 Not Found")
+}
@@ -29,6 +29,7 @@ func SetupValidation() {
 	validate = validator.New()
 	setupWhatsAppValidations()
 	setupMMSValidations()
+	setupSMSValidations()
 }
 
 // Validatable should be implemented by all models which represent request payloads.
@@ -38,11 +39,44 @@ type Validatable interface {
 	Marshal() (*bytes.Buffer, error)
 }
 
+// MultipartValidatable should be implemented by models sent as multipart/form-data, with the body returned by
+// Marshal. Models implementing MultipartWritable are streamed instead.
 type MultipartValidatable interface {
 	Validatable
 	GetMultipartBoundary() string
 }
 
+// MultipartWritable should be implemented by models sent as multipart/form-data whose body is streamed instead of
+// buffered. The body written by WriteMultipart is written again when the request is resent, e.g. on a redirect, so
+// it must read its sources from the start on every call.
+type MultipartWritable interface {
+	Validatable
+	WriteMultipart(writer *multipart.Writer) error
+}
+
+// MultipartPreparer is implemented by multipart models with *os.File fields. PrepareMultipart is called once per
+// request, before the body is first written, and returns the model to write with its files adapted by
+// NewOSFileAttachment, so that every write of the request shares the same adapters.
+type MultipartPreparer interface {
+	PrepareMultipart() MultipartWritable
+}
+
+// marshalMultipart buffers the whole body written by WriteMultipart and returns it with its boundary.
+func marshalMultipart(resource MultipartWritable) (*bytes.Buffer, string, error) {
+	if preparer, ok := resource.(MultipartPreparer); ok {
+		resource = preparer.PrepareMultipart()
+	}
+	buf := bytes.Buffer{}
+	multipartWriter := multipart.NewWriter(&buf)
+	if err := resource.WriteMultipart(multipartWriter); err != nil {
+		return nil, "", err
+	}
+	if err := multipartWriter.Close(); err != nil {
+		return nil, "", err
+	}
+	return &buf, multipartWriter.Boundary(), nil
+}
+
 func marshalJSON(t interface{}) (*bytes.Buffer, error) {
 	payload, err := json.Marshal(t)
 	if err != nil {
@@ -108,3 +142,30 @@ type ServiceException struct {
 	Text             string                 `json:"text"`
 	ValidationErrors map[string]interface{} `json:"validationErrors"`
 }
+
+// APIError is returned by the helpers built on top of the channels, such as tfa.Verifier, when the API responds
+// with an unsuccessful HTTP status code. The channel methods themselves return the response details instead.
+type APIError struct {
+	StatusCode int
+	Details    ErrorDetails
+}
+
+func (e *APIError) Error() string {
+	exception := e.Details.RequestError.ServiceException
+	if exception.Text != "" {
+		return fmt.Sprintf("request failed with status %d: %s", e.StatusCode, exception.Text)
+	}
+	return fmt.Sprintf("request failed with status %d", e.StatusCode)
+}
+
+// CheckResponse returns err, or an APIError when the response has an unsuccessful HTTP status code.
+func CheckResponse(respDetails ResponseDetails, err error) error {
+	if err != nil {
+		return err
+	}
+	status := respDetails.HTTPResponse.StatusCode
+	if status < http.StatusOK || status >= http.StatusMultipleChoices {
+		return &APIError{StatusCode: status, Details: respDetails.ErrorResponse}
+	}
+	return nil
+}
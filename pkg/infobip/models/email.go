@@ -3,14 +3,17 @@ package models
 import (
 	"bytes"
 	"fmt"
-	"io"
 	"mime/multipart"
 	"os"
 )
 
+// EmailMsg is sent as multipart/form-data. To and AdditionalTo each hold an address, or a JSON object with the
+// address and its placeholders, e.g. as built by email.Composer. Attachments and inline images are either files,
+// which are never closed, or Attachment sources such as in-memory content and fs.FS files.
 type EmailMsg struct {
 	From                    string `validate:"required"`
 	To                      string `validate:"required"`
+	AdditionalTo            []string
 	Cc                      string
 	Bcc                     string
 	Subject                 string `validate:"required"`
@@ -22,6 +25,8 @@ type EmailMsg struct {
 	Attachments             []*os.File
 	InlineImage             *os.File
 	InlineImages            []*os.File
+	AttachmentSources       []Attachment `validate:"dive"`
+	InlineImageSources      []Attachment `validate:"dive"`
 	HTML                    string
 	ReplyTo                 string
 	DefaultPlaceholders     string
@@ -56,223 +61,244 @@ type SendEmailResponse struct {
 	} `json:"messages"`
 }
 
+// PrepareMultipart returns a copy of the message with its files adapted by NewOSFileAttachment and moved to
+// AttachmentSources and InlineImageSources, ahead of the sources already set.
+func (e *EmailMsg) PrepareMultipart() MultipartWritable {
+	prepared := *e
+	prepared.Attachment, prepared.Attachments = nil, nil
+	prepared.AttachmentSources = append(osFileAttachments(e.attachmentFiles()), e.AttachmentSources...)
+	prepared.InlineImage, prepared.InlineImages = nil, nil
+	prepared.InlineImageSources = append(osFileAttachments(e.inlineImageFiles()), e.InlineImageSources...)
+	return &prepared
+}
+
+func (e *EmailMsg) attachmentFiles() []*os.File {
+	if e.Attachment == nil {
+		return e.Attachments
+	}
+	return append(e.Attachments[:len(e.Attachments):len(e.Attachments)], e.Attachment)
+}
+
+func (e *EmailMsg) inlineImageFiles() []*os.File {
+	if e.InlineImage == nil {
+		return e.InlineImages
+	}
+	return append(e.InlineImages[:len(e.InlineImages):len(e.InlineImages)], e.InlineImage)
+}
+
+// WriteMultipart writes the message as form fields. Files are adapted by NewOSFileAttachment on every call and never
+// closed; requests write the message returned by PrepareMultipart instead, so that files are adapted once.
+//
 //nolint:cyclop,funlen,gocognit,gocyclo // Because the EmailMsg has too many fields.
-func (e *EmailMsg) Marshal() (*bytes.Buffer, error) {
-	buf := bytes.Buffer{}
-	multipartWriter := multipart.NewWriter(&buf)
-	multipartWriter.Boundary()
-	var partWriter io.Writer
+func (e *EmailMsg) WriteMultipart(multipartWriter *multipart.Writer) error {
 	var err error
 
 	if e.From != "" {
 		err = writeMultipartText(multipartWriter, "from", e.From)
 		if err != nil {
-			return nil, err
+			return err
 		}
 	}
 
 	if e.To != "" {
 		err = writeMultipartText(multipartWriter, "to", e.To)
 		if err != nil {
-			return nil, err
+			return err
+		}
+	}
+
+	for _, to := range e.AdditionalTo {
+		err = writeMultipartText(multipartWriter, "to", to)
+		if err != nil {
+			return err
 		}
 	}
 
 	if e.Cc != "" {
 		err = writeMultipartText(multipartWriter, "cc", e.Cc)
 		if err != nil {
-			return nil, err
+			return err
 		}
 	}
 
 	if e.Bcc != "" {
 		err = writeMultipartText(multipartWriter, "bcc", e.Bcc)
 		if err != nil {
-			return nil, err
+			return err
 		}
 	}
 
 	if e.Subject != "" {
 		err = writeMultipartText(multipartWriter, "subject", e.Subject)
 		if err != nil {
-			return nil, err
+			return err
 		}
 	}
 
 	if e.Text != "" {
 		err = writeMultipartText(multipartWriter, "text", e.Text)
 		if err != nil {
-			return nil, err
+			return err
 		}
 	}
 
 	if e.BulkID != "" {
 		err = writeMultipartText(multipartWriter, "bulkId", e.BulkID)
 		if err != nil {
-			return nil, err
+			return err
 		}
 	}
 
 	if e.MessageID != "" {
 		err = writeMultipartText(multipartWriter, "messageId", e.MessageID)
 		if err != nil {
-			return nil, err
+			return err
 		}
 	}
 
 	if e.TemplateID != 0 {
 		err = writeMultipartText(multipartWriter, "templateid", fmt.Sprint(e.TemplateID))
 		if err != nil {
-			return nil, err
+			return err
 		}
 	}
 
-	if e.Attachment != nil {
-		e.Attachments = append(e.Attachments, e.Attachment)
-	}
-
-	if e.Attachments != nil {
-		for _, attachment := range e.Attachments {
-			defer attachment.Close()
-			partWriter, err = multipartWriter.CreateFormFile("attachment", attachment.Name())
-			if err != nil {
-				return nil, err
-			}
-			_, err = io.Copy(partWriter, attachment)
-			if err != nil {
-				return nil, err
-			}
+	attachments := append(osFileAttachments(e.attachmentFiles()), e.AttachmentSources...)
+	for _, attachment := range attachments {
+		if err = writeMultipartAttachment(multipartWriter, "attachment", attachment); err != nil {
+			return err
 		}
 	}
 
-	if e.InlineImage != nil {
-		e.InlineImages = append(e.InlineImages, e.InlineImage)
-	}
-
-	if e.InlineImages != nil {
-		for _, image := range e.InlineImages {
-			defer image.Close()
-			partWriter, err = multipartWriter.CreateFormFile("inlineImage", image.Name())
-			if err != nil {
-				return nil, err
-			}
-			_, err = io.Copy(partWriter, image)
-			if err != nil {
-				return nil, err
-			}
+	inlineImages := append(osFileAttachments(e.inlineImageFiles()), e.InlineImageSources...)
+	for _, image := range inlineImages {
+		if err = writeMultipartAttachment(multipartWriter, "inlineImage", image); err != nil {
+			return err
 		}
 	}
 
 	if e.HTML != "" {
 		err = writeMultipartText(multipartWriter, "HTML", e.HTML)
 		if err != nil {
-			return nil, err
+			return err
 		}
 	}
 
 	if e.ReplyTo != "" {
 		err = writeMultipartText(multipartWriter, "replyto", e.ReplyTo)
 		if err != nil {
-			return nil, err
+			return err
 		}
 	}
 
 	if e.DefaultPlaceholders != "" {
 		err = writeMultipartText(multipartWriter, "defaultplaceholders", e.DefaultPlaceholders)
 		if err != nil {
-			return nil, err
+			return err
 		}
 	}
 
 	if e.PreserveRecipients {
 		err = writeMultipartText(multipartWriter, "preserverecipients", "true")
 		if err != nil {
-			return nil, err
+			return err
 		}
 	}
 
 	if e.TrackingURL != "" {
 		err = writeMultipartText(multipartWriter, "trackingUrl", e.TrackingURL)
 		if err != nil {
-			return nil, err
+			return err
 		}
 	}
 
 	if e.TrackClicks {
 		err = writeMultipartText(multipartWriter, "trackclicks", "true")
 		if err != nil {
-			return nil, err
+			return err
 		}
 	}
 
 	if e.TrackOpens {
 		err = writeMultipartText(multipartWriter, "trackopens", "true")
 		if err != nil {
-			return nil, err
+			return err
 		}
 	}
 
 	if e.Track {
 		err = writeMultipartText(multipartWriter, "track", "true")
 		if err != nil {
-			return nil, err
+			return err
 		}
 	}
 
 	if e.CallbackData != "" {
 		err = writeMultipartText(multipartWriter, "callbackData", e.CallbackData)
 		if err != nil {
-			return nil, err
+			return err
 		}
 	}
 
 	if e.IntermediateReport {
 		err = writeMultipartText(multipartWriter, "intermediateReport", "true")
 		if err != nil {
-			return nil, err
+			return err
 		}
 	}
 
 	if e.NotifyURL != "" {
 		err = writeMultipartText(multipartWriter, "notifyUrl", e.NotifyURL)
 		if err != nil {
-			return nil, err
+			return err
 		}
 	}
 
 	if e.NotifyContentType != "" {
 		err = writeMultipartText(multipartWriter, "notifyContentType", e.NotifyContentType)
 		if err != nil {
-			return nil, err
+			return err
 		}
 	}
 
 	if e.SendAt != "" {
 		err = writeMultipartText(multipartWriter, "sendAt", e.SendAt)
 		if err != nil {
-			return nil, err
+			return err
 		}
 	}
 
 	if e.LandingPagePlaceholders != "" {
 		err = writeMultipartText(multipartWriter, "landingPagePlaceholders", e.LandingPagePlaceholders)
 		if err != nil {
-			return nil, err
+			return err
 		}
 	}
 
 	if e.LandingPageID != "" {
 		err = writeMultipartText(multipartWriter, "landingPageId", e.LandingPageID)
 		if err != nil {
-			return nil, err
+			return err
 		}
 	}
 
-	multipartWriter.Close()
-	e.boundary = multipartWriter.Boundary()
-	return &buf, nil
+	return nil
+}
+
+// Marshal buffers the whole multipart body. Requests stream the body instead.
+func (e *EmailMsg) Marshal() (*bytes.Buffer, error) {
+	buf, boundary, err := marshalMultipart(e)
+	if err != nil {
+		return nil, err
+	}
+	e.boundary = boundary
+	return buf, nil
 }
 
+// GetMultipartBoundary returns the boundary of the body last buffered by Marshal.
+//
+// Deprecated: requests stream the body written by WriteMultipart, with a boundary of their own, so this boundary
+// never matches a sent request.
 func (e *EmailMsg) GetMultipartBoundary() string {
 	return e.boundary
 }
@@ -495,14 +521,17 @@ type EmailDomain struct {
 		Opens       bool `json:"opens"`
 		Unsubscribe bool `json:"unsubscribe"`
 	} `json:"tracking"`
-	DNSRecords []struct {
-		RecordType    string `json:"recordType"`
-		Name          string `json:"name"`
-		ExpectedValue string `json:"expectedValue"`
-		Verified      bool   `json:"verified"`
-	} `json:"dnsRecords"`
-	Blocked   bool   `json:"blocked"`
-	CreatedAt string `json:"createdAt"`
+	DNSRecords []EmailDNSRecord `json:"dnsRecords"`
+	Blocked    bool             `json:"blocked"`
+	CreatedAt  string           `json:"createdAt"`
+}
+
+// EmailDNSRecord is a DNS record the domain must publish before it can be verified.
+type EmailDNSRecord struct {
+	RecordType    string `json:"recordType"`
+	Name          string `json:"name"`
+	ExpectedValue string `json:"expectedValue"`
+	Verified      bool   `json:"verified"`
 }
 
 type AddEmailDomainResponse EmailDomain
@@ -524,3 +553,224 @@ func (u *UpdateEmailDomainTrackingRequest) Marshal() (*bytes.Buffer, error) {
 }
 
 type UpdateEmailDomainTrackingResponse EmailDomain
+
+const (
+	EmailSuppressionBounce      = "BOUNCE"
+	EmailSuppressionComplaint   = "COMPLAINT"
+	EmailSuppressionUnsubscribe = "UNSUBSCRIBE"
+)
+
+type GetEmailSuppressionsParams struct {
+	DomainName      string `validate:"required"`
+	Type            string `validate:"required,oneof=BOUNCE COMPLAINT UNSUBSCRIBE"`
+	EmailAddress    string
+	CreatedDateFrom string
+	CreatedDateTo   string
+	Size            int `validate:"omitempty,min=1,max=1000"`
+	Page            int `validate:"omitempty,min=0"`
+}
+
+func (p *GetEmailSuppressionsParams) Validate() error {
+	return validate.Struct(p)
+}
+
+type GetEmailSuppressionsResponse struct {
+	Paging struct {
+		Page int `json:"page"`
+		Size int `json:"size"`
+	} `json:"paging"`
+	Results []EmailSuppression `json:"results"`
+}
+
+type EmailSuppression struct {
+	DomainName   string `json:"domainName"`
+	EmailAddress string `json:"emailAddress"`
+	Type         string `json:"type"`
+	CreatedDate  string `json:"createdDate"`
+	Reason       string `json:"reason"`
+}
+
+// EmailSuppressionsRequest adds or deletes suppressed addresses, grouped by domain and suppression type.
+type EmailSuppressionsRequest struct {
+	Suppressions []EmailSuppressionsEntry `json:"suppressions" validate:"required,min=1,dive"`
+}
+
+type EmailSuppressionsEntry struct {
+	DomainName   string   `json:"domainName" validate:"required"`
+	EmailAddress []string `json:"emailAddress" validate:"required,min=1,max=10000,dive,email"`
+	Type         string   `json:"type" validate:"required,oneof=BOUNCE COMPLAINT UNSUBSCRIBE"`
+}
+
+func (r *EmailSuppressionsRequest) Validate() error {
+	return validate.Struct(r)
+}
+
+func (r *EmailSuppressionsRequest) Marshal() (*bytes.Buffer, error) {
+	return marshalJSON(r)
+}
+
+type GetEmailIPsResponse struct {
+	Result []EmailIP `json:"result"`
+}
+
+// EmailIP is an IP address of the account, as listed for the account or for a domain.
+type EmailIP struct {
+	IPAddress           string `json:"ipAddress"`
+	Dedicated           bool   `json:"dedicated"`
+	AssignedDomainCount int    `json:"assignedDomainCount"`
+	Status              string `json:"status"`
+}
+
+type AssignEmailIPRequest struct {
+	DomainName string `json:"domainName" validate:"required"`
+	IPAddress  string `json:"ipAddress" validate:"required,ip"`
+}
+
+func (a *AssignEmailIPRequest) Validate() error {
+	return validate.Struct(a)
+}
+
+func (a *AssignEmailIPRequest) Marshal() (*bytes.Buffer, error) {
+	return marshalJSON(a)
+}
+
+type AssignEmailIPResponse struct {
+	Result string `json:"result"`
+}
+
+// EmailIPDetails is a dedicated IP with the pools it belongs to.
+type EmailIPDetails struct {
+	ID    string        `json:"id"`
+	IP    string        `json:"ip"`
+	Pools []EmailIPPool `json:"pools"`
+}
+
+type EmailIPPool struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// EmailIPPoolDetails is an IP pool with the IPs it contains.
+type EmailIPPoolDetails struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	IPs  []struct {
+		ID string `json:"id"`
+		IP string `json:"ip"`
+	} `json:"ips"`
+}
+
+type CreateEmailIPPoolRequest struct {
+	Name string `json:"name" validate:"required"`
+}
+
+func (c *CreateEmailIPPoolRequest) Validate() error {
+	return validate.Struct(c)
+}
+
+func (c *CreateEmailIPPoolRequest) Marshal() (*bytes.Buffer, error) {
+	return marshalJSON(c)
+}
+
+type AddEmailIPToPoolRequest struct {
+	IPID string `json:"ipId" validate:"required"`
+}
+
+func (a *AddEmailIPToPoolRequest) Validate() error {
+	return validate.Struct(a)
+}
+
+func (a *AddEmailIPToPoolRequest) Marshal() (*bytes.Buffer, error) {
+	return marshalJSON(a)
+}
+
+// EmailDomainIPPools lists the IP pools assigned to a domain. Messages from the domain are sent through the pool
+// with the lowest priority number, falling back to the next ones.
+type EmailDomainIPPools struct {
+	ID    int64  `json:"id"`
+	Name  string `json:"name"`
+	Pools []struct {
+		ID       string `json:"id"`
+		Name     string `json:"name"`
+		Priority int    `json:"priority"`
+	} `json:"pools"`
+}
+
+type AssignEmailIPPoolRequest struct {
+	PoolID   string `json:"poolId" validate:"required"`
+	Priority int    `json:"priority" validate:"min=0"`
+}
+
+func (a *AssignEmailIPPoolRequest) Validate() error {
+	return validate.Struct(a)
+}
+
+func (a *AssignEmailIPPoolRequest) Marshal() (*bytes.Buffer, error) {
+	return marshalJSON(a)
+}
+
+type UpdateEmailIPPoolPriorityRequest struct {
+	Priority int `json:"priority" validate:"min=0"`
+}
+
+func (u *UpdateEmailIPPoolPriorityRequest) Validate() error {
+	return validate.Struct(u)
+}
+
+func (u *UpdateEmailIPPoolPriorityRequest) Marshal() (*bytes.Buffer, error) {
+	return marshalJSON(u)
+}
+
+// EmailTemplate is a server-side template, referenced by EmailMsg.TemplateID. From and ReplyTo are the defaults of
+// messages using the template, and Placeholders lists the placeholders they must supply.
+type EmailTemplate struct {
+	ID           int64    `json:"id,omitempty"`
+	Name         string   `json:"name" validate:"required"`
+	From         string   `json:"from,omitempty"`
+	ReplyTo      string   `json:"replyTo,omitempty"`
+	Subject      string   `json:"subject" validate:"required"`
+	HTML         string   `json:"html,omitempty" validate:"required_without=Text"`
+	Text         string   `json:"text,omitempty" validate:"required_without=HTML"`
+	Placeholders []string `json:"placeholders,omitempty"`
+	CreatedAt    string   `json:"createdAt,omitempty"`
+	UpdatedAt    string   `json:"updatedAt,omitempty"`
+}
+
+type GetEmailTemplatesParams struct {
+	Size int `validate:"omitempty,min=1,max=100"`
+	Page int `validate:"omitempty,min=0"`
+}
+
+func (p *GetEmailTemplatesParams) Validate() error {
+	return validate.Struct(p)
+}
+
+type GetEmailTemplatesResponse struct {
+	Paging struct {
+		Page         int `json:"page"`
+		Size         int `json:"size"`
+		TotalPages   int `json:"totalPages"`
+		TotalResults int `json:"totalResults"`
+	} `json:"paging"`
+	Results []EmailTemplate `json:"results"`
+}
+
+type CreateEmailTemplateRequest EmailTemplate
+
+func (c *CreateEmailTemplateRequest) Validate() error {
+	return validate.Struct(c)
+}
+
+func (c *CreateEmailTemplateRequest) Marshal() (*bytes.Buffer, error) {
+	return marshalJSON(c)
+}
+
+type UpdateEmailTemplateRequest EmailTemplate
+
+func (u *UpdateEmailTemplateRequest) Validate() error {
+	return validate.Struct(u)
+}
+
+func (u *UpdateEmailTemplateRequest) Marshal() (*bytes.Buffer, error) {
+	return marshalJSON(u)
+}
@@ -0,0 +1,69 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmailIPManagementRequests(t *testing.T) {
+	tests := []struct {
+		name     string
+		instance Validatable
+		expected string
+	}{
+		{
+			name:     "assign IP",
+			instance: &AssignEmailIPRequest{DomainName: "example.com", IPAddress: "11.11.11.1"},
+			expected: `{"domainName":"example.com","ipAddress":"11.11.11.1"}`,
+		},
+		{
+			name:     "create pool",
+			instance: &CreateEmailIPPoolRequest{Name: "warmup"},
+			expected: `{"name":"warmup"}`,
+		},
+		{
+			name:     "add IP to pool",
+			instance: &AddEmailIPToPoolRequest{IPID: "ip-1"},
+			expected: `{"ipId":"ip-1"}`,
+		},
+		{
+			name:     "assign pool",
+			instance: &AssignEmailIPPoolRequest{PoolID: "pool-1"},
+			expected: `{"poolId":"pool-1","priority":0}`,
+		},
+		{
+			name:     "update priority",
+			instance: &UpdateEmailIPPoolPriorityRequest{Priority: 3},
+			expected: `{"priority":3}`,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			require.NoError(t, tc.instance.Validate())
+			marshalled, err := tc.instance.Marshal()
+			require.NoError(t, err)
+			assert.JSONEq(t, tc.expected, marshalled.String())
+		})
+	}
+}
+
+func TestInvalidEmailIPManagementRequests(t *testing.T) {
+	tests := []struct {
+		name     string
+		instance Validatable
+	}{
+		{name: "assign IP without domain", instance: &AssignEmailIPRequest{IPAddress: "11.11.11.1"}},
+		{name: "assign invalid IP", instance: &AssignEmailIPRequest{DomainName: "example.com", IPAddress: "11.11"}},
+		{name: "pool without name", instance: &CreateEmailIPPoolRequest{}},
+		{name: "add IP without ID", instance: &AddEmailIPToPoolRequest{}},
+		{name: "assign pool without ID", instance: &AssignEmailIPPoolRequest{Priority: 1}},
+		{name: "negative priority", instance: &UpdateEmailIPPoolPriorityRequest{Priority: -1}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Error(t, tc.instance.Validate())
+		})
+	}
+}
@@ -1,10 +1,15 @@
 package models
 
 import (
+	"errors"
+	"io"
 	"io/ioutil"
+	"mime/multipart"
 	"os"
+	"strings"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -96,3 +101,106 @@ func TestEmailMsgConstraints(t *testing.T) {
 		})
 	}
 }
+
+func TestEmailMsgMarshalTwice(t *testing.T) {
+	image, err := os.Open("testdata/image.png")
+	require.NoError(t, err)
+	msg := GenerateEmailMsg()
+	msg.InlineImage = image
+
+	first, err := msg.Marshal()
+	require.NoError(t, err)
+	firstBoundary := msg.GetMultipartBoundary()
+	second, err := msg.Marshal()
+	require.NoError(t, err)
+
+	expected := strings.ReplaceAll(first.String(), firstBoundary, msg.GetMultipartBoundary())
+	require.Equal(t, expected, second.String())
+	require.Equal(t, 1, strings.Count(second.String(), `name="inlineImage"`))
+}
+
+func TestEmailMsgPrepareMultipart(t *testing.T) {
+	reader, writer, err := os.Pipe()
+	require.NoError(t, err)
+	defer reader.Close()
+	go func() {
+		_, _ = writer.WriteString("piped content")
+		writer.Close()
+	}()
+	msg := GenerateEmailMsg()
+	msg.Attachments = []*os.File{reader}
+
+	prepared := msg.PrepareMultipart()
+	first, err := prepared.Marshal()
+	require.NoError(t, err)
+	assert.Contains(t, first.String(), "piped content")
+	assert.Equal(t, []*os.File{reader}, msg.Attachments)
+
+	_, err = prepared.Marshal()
+	assert.ErrorIs(t, err, ErrMediaNotRewindable)
+}
+
+func TestEmailMsgAttachmentSources(t *testing.T) {
+	image, err := os.Open("testdata/image.png")
+	require.NoError(t, err)
+	defer image.Close()
+	msg := EmailMsg{
+		From:               "someone@infobip.com",
+		To:                 "someone@outside.com",
+		Subject:            "Some subject",
+		Attachment:         image,
+		AttachmentSources:  []Attachment{NewBytesAttachment("invoice.pdf", "", []byte("%PDF"))},
+		InlineImageSources: []Attachment{NewFileAttachment("testdata/image.png")},
+	}
+	require.NoError(t, msg.Validate())
+
+	buf, err := msg.Marshal()
+	require.NoError(t, err)
+
+	reader := multipart.NewReader(buf, msg.GetMultipartBoundary())
+	var parts []string
+	for {
+		part, err := reader.NextPart()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		require.NoError(t, err)
+		if part.FileName() != "" {
+			parts = append(parts, part.FormName()+":"+part.FileName()+":"+part.Header.Get("Content-Type"))
+		}
+	}
+	assert.Equal(t, []string{
+		"attachment:image.png:image/png",
+		"attachment:invoice.pdf:application/pdf",
+		"inlineImage:image.png:image/png",
+	}, parts)
+	_, err = image.Seek(0, io.SeekStart)
+	assert.NoError(t, err, "the file must not be closed")
+}
+
+func TestEmailMsgInvalidAttachmentSource(t *testing.T) {
+	msg := EmailMsg{
+		From:              "someone@infobip.com",
+		To:                "someone@outside.com",
+		Subject:           "Some subject",
+		AttachmentSources: []Attachment{{Name: "missing-open.txt"}},
+	}
+
+	require.Error(t, msg.Validate())
+}
+
+func TestEmailMsgAdditionalTo(t *testing.T) {
+	msg := EmailMsg{
+		From:         "someone@infobip.com",
+		To:           `{"to":"jane@outside.com","placeholders":{"name":"Jane"}}`,
+		AdditionalTo: []string{"john@outside.com"},
+		Subject:      "Hi {{name}}",
+	}
+
+	buf, err := msg.Marshal()
+	require.NoError(t, err)
+
+	form, err := multipart.NewReader(buf, msg.GetMultipartBoundary()).ReadForm(1024)
+	require.NoError(t, err)
+	assert.Equal(t, []string{msg.To, "john@outside.com"}, form.Value["to"])
+}
@@ -0,0 +1,58 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidEmailSuppressionsRequest(t *testing.T) {
+	instance := EmailSuppressionsRequest{Suppressions: []EmailSuppressionsEntry{
+		{DomainName: "example.com", EmailAddress: []string{"jane@example.com"}, Type: EmailSuppressionBounce},
+		{DomainName: "example.com", EmailAddress: []string{"john@example.com"}, Type: EmailSuppressionUnsubscribe},
+	}}
+	err := instance.Validate()
+	require.NoError(t, err)
+
+	marshalled, err := instance.Marshal()
+	require.NoError(t, err)
+	assert.Contains(t, marshalled.String(), `"emailAddress":["jane@example.com"]`)
+
+	var unmarshalled EmailSuppressionsRequest
+	err = json.Unmarshal(marshalled.Bytes(), &unmarshalled)
+	require.NoError(t, err)
+	assert.Equal(t, instance, unmarshalled)
+}
+
+func TestInvalidEmailSuppressionsRequest(t *testing.T) {
+	tests := []struct {
+		name     string
+		instance EmailSuppressionsRequest
+	}{
+		{name: "empty request", instance: EmailSuppressionsRequest{}},
+		{name: "missing domain", instance: EmailSuppressionsRequest{Suppressions: []EmailSuppressionsEntry{
+			{EmailAddress: []string{"jane@example.com"}, Type: EmailSuppressionBounce},
+		}}},
+		{name: "invalid address", instance: EmailSuppressionsRequest{Suppressions: []EmailSuppressionsEntry{
+			{DomainName: "example.com", EmailAddress: []string{"jane"}, Type: EmailSuppressionBounce},
+		}}},
+		{name: "invalid type", instance: EmailSuppressionsRequest{Suppressions: []EmailSuppressionsEntry{
+			{DomainName: "example.com", EmailAddress: []string{"jane@example.com"}, Type: "SPAM"},
+		}}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Error(t, tc.instance.Validate())
+		})
+	}
+}
+
+func TestGetEmailSuppressionsParams(t *testing.T) {
+	valid := GetEmailSuppressionsParams{DomainName: "example.com", Type: EmailSuppressionComplaint, Size: 100}
+	require.NoError(t, valid.Validate())
+
+	require.Error(t, (&GetEmailSuppressionsParams{Type: EmailSuppressionBounce}).Validate())
+	require.Error(t, (&GetEmailSuppressionsParams{DomainName: "example.com", Type: "BOUNCE", Size: 1001}).Validate())
+}
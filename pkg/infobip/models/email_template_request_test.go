@@ -0,0 +1,48 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidEmailTemplateRequests(t *testing.T) {
+	template := EmailTemplate{
+		Name:         "Order shipped",
+		From:         "shop@example.com",
+		Subject:      "Order {{order}} shipped",
+		Text:         "Hi {{name}}",
+		Placeholders: []string{"name", "order"},
+	}
+
+	create := CreateEmailTemplateRequest(template)
+	require.NoError(t, create.Validate())
+	marshalled, err := create.Marshal()
+	require.NoError(t, err)
+	var unmarshalled CreateEmailTemplateRequest
+	require.NoError(t, json.Unmarshal(marshalled.Bytes(), &unmarshalled))
+	assert.Equal(t, create, unmarshalled)
+
+	update := UpdateEmailTemplateRequest(template)
+	update.Text = ""
+	update.HTML = "<p>Hi {{name}}</p>"
+	require.NoError(t, update.Validate())
+}
+
+func TestInvalidEmailTemplateRequests(t *testing.T) {
+	tests := []struct {
+		name     string
+		instance Validatable
+	}{
+		{name: "missing name", instance: &CreateEmailTemplateRequest{Subject: "Hi", Text: "Hi"}},
+		{name: "missing subject", instance: &CreateEmailTemplateRequest{Name: "Hi", Text: "Hi"}},
+		{name: "missing content", instance: &UpdateEmailTemplateRequest{Name: "Hi", Subject: "Hi"}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Error(t, tc.instance.Validate())
+		})
+	}
+}
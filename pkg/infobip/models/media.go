@@ -0,0 +1,18 @@
+package models
+
+// MediaTransformation records how media was changed to fit a size limit before it was sent, e.g. by a media.Pipeline.
+// Width and Height are only set for images.
+type MediaTransformation struct {
+	Name                string
+	Processor           string
+	OriginalContentType string
+	ContentType         string
+	OriginalSize        int64
+	Size                int64
+	OriginalWidth       int
+	OriginalHeight      int
+	Width               int
+	Height              int
+	// Quality is the JPEG quality used, if any.
+	Quality int
+}
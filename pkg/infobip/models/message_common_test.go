@@ -30,6 +30,14 @@ func TestMessageCommon(t *testing.T) {
 				NotifyURL:    "https://www.google.com",
 			},
 		},
+		{
+			name: "reply to a message",
+			instance: MsgCommon{
+				From:    "16175551213",
+				To:      "16175551212",
+				Context: &WAMsgContext{MessageID: "wamid.HBgLMTYxNzU1NTEyMTIVAgASGCA2QzJGRkM4QTc3RjY0RjM5QkE="},
+			},
+		},
 	}
 
 	for _, tc := range tests {
@@ -125,6 +133,30 @@ func TestMessageCommonConstraints(t *testing.T) {
 				NotifyURL:    "if only this was an url...",
 			},
 		},
+		{
+			name: "missing context MessageID",
+			instance: MsgCommon{
+				From:    "16175551213",
+				To:      "16175551212",
+				Context: &WAMsgContext{},
+			},
+		},
+		{
+			name: "invalid context MessageID",
+			instance: MsgCommon{
+				From:    "16175551213",
+				To:      "16175551212",
+				Context: &WAMsgContext{MessageID: "not a message id"},
+			},
+		},
+		{
+			name: "context MessageID too long",
+			instance: MsgCommon{
+				From:    "16175551213",
+				To:      "16175551212",
+				Context: &WAMsgContext{MessageID: strings.Repeat("a", 201)},
+			},
+		},
 	}
 
 	for _, tc := range tests {
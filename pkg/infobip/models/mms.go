@@ -2,8 +2,8 @@ package models
 
 import (
 	"bytes"
-	"io"
 	"mime/multipart"
+	"net/textproto"
 	"os"
 	"time"
 
@@ -19,10 +19,15 @@ func setupMMSValidations() {
 	validate.RegisterStructValidation(MMSHeadValidation, MMSHead{})
 }
 
+// MMSMsg is sent as multipart/form-data. Media is either a file, which is never closed, or an Attachment source
+// such as in-memory content; MediaSource is used when both are set. MediaParts are additional media parts, each
+// with a Content-ID that the SMIL refers to, e.g. as built by mms.Composer.
 type MMSMsg struct {
 	Head                  MMSHead `validate:"required"`
 	Text                  string
 	Media                 *os.File
+	MediaSource           *Attachment
+	MediaParts            []MMSMediaPart          `validate:"dive"`
 	ExternallyHostedMedia []ExternallyHostedMedia `validate:"dive"`
 	SMIL                  string
 	boundary              string
@@ -52,6 +57,13 @@ type MMSTime struct {
 	Minute int32 `json:"minute" validate:"lte=59"`
 }
 
+// MMSMediaPart is a media part sent with a Content-ID header. The part is also named after the content ID, so that
+// SMIL can refer to it either way.
+type MMSMediaPart struct {
+	ContentID string `validate:"required"`
+	Media     Attachment
+}
+
 type ExternallyHostedMedia struct {
 	ContentType string `json:"contentType" validate:"required"`
 	ContentID   string `json:"contentId" validate:"required"`
@@ -97,10 +109,13 @@ func deliveryWindowIsValid(from MMSTime, to MMSTime) bool {
 	return diff >= MinDeliveryWindow
 }
 
+// SendMMSResponse is the response of the API. MediaTransformations lists the media changed by the media pipeline of
+// the channel to fit the size limit, if any.
 type SendMMSResponse struct {
-	BulkID       string    `json:"bulkId"`
-	Messages     []SentMMS `json:"messages"`
-	ErrorMessage string    `json:"errorMessage"`
+	BulkID               string                `json:"bulkId"`
+	Messages             []SentMMS             `json:"messages"`
+	ErrorMessage         string                `json:"errorMessage"`
+	MediaTransformations []MediaTransformation `json:"-"`
 }
 
 type SentMMS struct {
@@ -121,52 +136,88 @@ func (t *MMSMsg) Validate() error {
 	return validate.Struct(t)
 }
 
-func (t *MMSMsg) Marshal() (*bytes.Buffer, error) {
-	buf := bytes.Buffer{}
-	multipartWriter := multipart.NewWriter(&buf)
-	multipartWriter.Boundary()
-	var partWriter io.Writer
+// PrepareMultipart returns a copy of the message with its media file adapted by NewOSFileAttachment as MediaSource,
+// unless MediaSource is already set.
+func (t *MMSMsg) PrepareMultipart() MultipartWritable {
+	prepared := *t
+	if prepared.MediaSource == nil && prepared.Media != nil {
+		media := NewOSFileAttachment(prepared.Media)
+		prepared.MediaSource = &media
+	}
+	prepared.Media = nil
+	return &prepared
+}
+
+// WriteMultipart writes the message as form parts. A media file is adapted by NewOSFileAttachment on every call;
+// requests write the message returned by PrepareMultipart instead, so that it is adapted once.
+func (t *MMSMsg) WriteMultipart(multipartWriter *multipart.Writer) error {
 	err := writeMultipartJSON(multipartWriter, "head", t.Head)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	if t.Text != "" {
 		err = writeMultipartText(multipartWriter, "text", t.Text)
 		if err != nil {
-			return nil, err
+			return err
+		}
+	}
+
+	media := t.MediaSource
+	if media == nil && t.Media != nil {
+		fileMedia := NewOSFileAttachment(t.Media)
+		media = &fileMedia
+	}
+	if media != nil {
+		if err = writeMultipartAttachment(multipartWriter, "media", *media); err != nil {
+			return err
 		}
 	}
 
-	if t.Media != nil {
-		defer t.Media.Close()
-		if partWriter, err = multipartWriter.CreateFormFile("media", t.Media.Name()); err != nil {
-			return nil, err
+	for _, part := range t.MediaParts {
+		header := textproto.MIMEHeader{}
+		header.Set("Content-ID", "<"+part.ContentID+">")
+		media := part.Media
+		media.Name = part.ContentID
+		if media.ContentType == "" {
+			media.ContentType = part.Media.contentType()
 		}
-		if _, err = io.Copy(partWriter, t.Media); err != nil {
-			return nil, err
+		if err = writeMultipartAttachmentHeader(multipartWriter, "media", media, header); err != nil {
+			return err
 		}
 	}
 
 	if len(t.ExternallyHostedMedia) > 0 {
 		err = writeMultipartJSON(multipartWriter, "externallyHostedMedia", t.ExternallyHostedMedia)
 		if err != nil {
-			return nil, err
+			return err
 		}
 	}
 
 	if t.SMIL != "" {
 		err = writeMultipartXMLString(multipartWriter, "smil", t.SMIL)
 		if err != nil {
-			return nil, err
+			return err
 		}
 	}
 
-	multipartWriter.Close()
-	t.boundary = multipartWriter.Boundary()
-	return &buf, nil
+	return nil
+}
+
+// Marshal buffers the whole multipart body. Requests stream the body instead.
+func (t *MMSMsg) Marshal() (*bytes.Buffer, error) {
+	buf, boundary, err := marshalMultipart(t)
+	if err != nil {
+		return nil, err
+	}
+	t.boundary = boundary
+	return buf, nil
 }
 
+// GetMultipartBoundary returns the boundary of the body last buffered by Marshal.
+//
+// Deprecated: requests stream the body written by WriteMultipart, with a boundary of their own, so this boundary
+// never matches a sent request.
 func (t *MMSMsg) GetMultipartBoundary() string {
 	return t.boundary
 }
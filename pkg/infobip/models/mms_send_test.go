@@ -2,10 +2,12 @@ package models
 
 import (
 	"io/ioutil"
+	"mime/multipart"
 	"strings"
 	"testing"
 
 	"github.com/infobip-community/infobip-api-go-sdk/v3/pkg/infobip/utils"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -224,3 +226,64 @@ func TestMMSMsgConstraints(t *testing.T) {
 		})
 	}
 }
+
+func TestMMSMsgMediaSource(t *testing.T) {
+	media := NewBytesAttachment("parcel.jpg", "", []byte("jpeg bytes"))
+	msg := MMSMsg{Head: MMSHead{From: "16175551213", To: "16175551212"}, MediaSource: &media}
+	require.NoError(t, msg.Validate())
+
+	buf, err := msg.Marshal()
+	require.NoError(t, err)
+
+	reader := multipart.NewReader(buf, msg.GetMultipartBoundary())
+	_, err = reader.NextPart()
+	require.NoError(t, err)
+	part, err := reader.NextPart()
+	require.NoError(t, err)
+	content, err := ioutil.ReadAll(part)
+	require.NoError(t, err)
+	assert.Equal(t, "media", part.FormName())
+	assert.Equal(t, "parcel.jpg", part.FileName())
+	assert.Equal(t, "image/jpeg", part.Header.Get("Content-Type"))
+	assert.Equal(t, "jpeg bytes", string(content))
+}
+
+func TestMMSMsgMediaParts(t *testing.T) {
+	msg := MMSMsg{
+		Head: MMSHead{From: "16175551213", To: "16175551212"},
+		MediaParts: []MMSMediaPart{
+			{ContentID: "slide1.jpg", Media: NewBytesAttachment("parcel.jpg", "", []byte("jpeg bytes"))},
+			{ContentID: "slide1.txt", Media: NewBytesAttachment("text", "text/plain; charset=utf-8", []byte("Hi"))},
+		},
+		SMIL: "<smil/>",
+	}
+	require.NoError(t, msg.Validate())
+
+	buf, err := msg.Marshal()
+	require.NoError(t, err)
+
+	reader := multipart.NewReader(buf, msg.GetMultipartBoundary())
+	_, err = reader.NextPart()
+	require.NoError(t, err)
+	expected := []struct{ contentID, fileName, contentType, content string }{
+		{"<slide1.jpg>", "slide1.jpg", "image/jpeg", "jpeg bytes"},
+		{"<slide1.txt>", "slide1.txt", "text/plain; charset=utf-8", "Hi"},
+	}
+	for _, e := range expected {
+		part, partErr := reader.NextPart()
+		require.NoError(t, partErr)
+		content, readErr := ioutil.ReadAll(part)
+		require.NoError(t, readErr)
+		assert.Equal(t, "media", part.FormName())
+		assert.Equal(t, e.contentID, part.Header.Get("Content-ID"))
+		assert.Equal(t, e.fileName, part.FileName())
+		assert.Equal(t, e.contentType, part.Header.Get("Content-Type"))
+		assert.Equal(t, e.content, string(content))
+	}
+	part, err := reader.NextPart()
+	require.NoError(t, err)
+	assert.Equal(t, "smil", part.FormName())
+
+	msg.MediaParts = append(msg.MediaParts, MMSMediaPart{Media: NewBytesAttachment("a.jpg", "", nil)})
+	assert.Error(t, msg.Validate())
+}
@@ -0,0 +1,33 @@
+package models
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckResponse(t *testing.T) {
+	ok := ResponseDetails{HTTPResponse: http.Response{StatusCode: http.StatusOK}}
+	assert.NoError(t, CheckResponse(ok, nil))
+
+	sendErr := errors.New("connection refused")
+	assert.Equal(t, sendErr, CheckResponse(ok, sendErr))
+
+	failed := ResponseDetails{
+		HTTPResponse: http.Response{StatusCode: http.StatusNotFound},
+		ErrorResponse: ErrorDetails{RequestError: RequestError{
+			ServiceException: ServiceException{Text: "Domain not found"},
+		}},
+	}
+	err := CheckResponse(failed, nil)
+	var apiErr *APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+	assert.EqualError(t, err, "request failed with status 404: Domain not found")
+
+	err = CheckResponse(ResponseDetails{HTTPResponse: http.Response{StatusCode: http.StatusBadGateway}}, nil)
+	assert.EqualError(t, err, "request failed with status 502")
+}
@@ -2,8 +2,31 @@ package models
 
 import (
 	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	"mvdan.cc/xurls/v2"
+)
+
+const (
+	CountryIndia      = "IN"
+	CountryTurkey     = "TR"
+	CountrySouthKorea = "KR"
 )
 
+func setupSMSValidations() {
+	if validate == nil {
+		validate = validator.New()
+	}
+	validate.RegisterStructValidationCtx(smsMsgValidation, SMSMsg{})
+	validate.RegisterStructValidationCtx(binarySMSMsgValidation, BinarySMSMsg{})
+	validate.RegisterStructValidation(smsRegionalValidation, SMSRegional{})
+	validate.RegisterStructValidation(sendSMSRequestValidation, SendSMSRequest{})
+}
+
 type SMSDestination struct {
 	MessageID string `json:"messageId"`
 	To        string `json:"to" validate:"required"`
@@ -45,6 +68,12 @@ func (s *SMSMsg) Validate() error {
 	return validate.Struct(s)
 }
 
+// ValidateRegional validates the message like Validate, also requiring the regional parameters selected by rules
+// for its destinations.
+func (s *SMSMsg) ValidateRegional(rules SMSRegionalRules) error {
+	return validateRegional(rules, s)
+}
+
 func (s *SMSMsg) Marshal() (*bytes.Buffer, error) {
 	return marshalJSON(s)
 }
@@ -56,6 +85,20 @@ type SMSTracking struct {
 	ProcessKey string `json:"processKey"`
 }
 
+// SMSURLOptions sets up URL shortening and click tracking for the links found in the message text.
+type SMSURLOptions struct {
+	// ShortenURL replaces every link in the text with a short URL. Required to set up the other URL options.
+	ShortenURL *bool `json:"shortenUrl,omitempty"`
+	// TrackClicks counts clicks on the shortened URLs. Results are available in the conversion analytics.
+	TrackClicks *bool `json:"trackClicks,omitempty"`
+	// TrackingURL is called with the click details every time a shortened URL is clicked.
+	TrackingURL string `json:"trackingUrl,omitempty" validate:"omitempty,url"`
+	// RemoveProtocol strips the http:// or https:// prefix from the shortened URLs.
+	RemoveProtocol *bool `json:"removeProtocol,omitempty"`
+	// CustomDomain is a domain configured in your account, used instead of the default short URL domain.
+	CustomDomain string `json:"customDomain,omitempty" validate:"omitempty,fqdn"`
+}
+
 type SMSSendingSpeedLimit struct {
 	Amount   int    `json:"amount" validate:"required"`
 	TimeUnit string `json:"timeUnit" validate:"oneof=MINUTE HOUR DAY"`
@@ -66,16 +109,67 @@ type SendSMSRequest struct {
 	Messages          []SMSMsg              `json:"messages" validate:"required,min=1,dive"`
 	SendingSpeedLimit *SMSSendingSpeedLimit `json:"sendingSpeedLimit,omitempty"`
 	Tracking          *SMSTracking          `json:"tracking,omitempty"`
+	URLOptions        *SMSURLOptions        `json:"urlOptions,omitempty"`
 }
 
 func (s *SendSMSRequest) Validate() error {
 	return validate.Struct(s)
 }
 
+// ValidateRegional validates the request like Validate, also requiring the regional parameters selected by rules
+// for its destinations.
+func (s *SendSMSRequest) ValidateRegional(rules SMSRegionalRules) error {
+	return validateRegional(rules, s)
+}
+
 func (s *SendSMSRequest) Marshal() (*bytes.Buffer, error) {
 	return marshalJSON(s)
 }
 
+// TracksURLs reports whether the request asks for links in the message text to be shortened or tracked.
+func (s *SendSMSRequest) TracksURLs() bool {
+	if s.Tracking != nil && s.Tracking.Track == "URL" {
+		return true
+	}
+	options := s.URLOptions
+	if options == nil {
+		return false
+	}
+	return isTrue(options.ShortenURL) || isTrue(options.TrackClicks) || options.TrackingURL != ""
+}
+
+// MessagesWithoutURL returns the indexes of the messages without a link in their text, when the request asks for
+// links to be shortened or tracked. It does not block sending: such messages are sent, with nothing to track.
+func (s *SendSMSRequest) MessagesWithoutURL() []int {
+	if !s.TracksURLs() {
+		return nil
+	}
+	var indexes []int
+	for i, msg := range s.Messages {
+		if xurls.Relaxed().FindString(msg.Text) == "" {
+			indexes = append(indexes, i)
+		}
+	}
+	return indexes
+}
+
+func sendSMSRequestValidation(sl validator.StructLevel) {
+	req, _ := sl.Current().Interface().(SendSMSRequest)
+	if options := req.URLOptions; options != nil {
+		disabled := options.ShortenURL != nil && !*options.ShortenURL
+		if disabled && (isTrue(options.TrackClicks) || options.TrackingURL != "" || options.CustomDomain != "") {
+			sl.ReportError(options.ShortenURL, "urlOptions.shortenUrl", "ShortenURL", "requiredforurloptions", "")
+		}
+		if options.TrackingURL != "" && options.TrackClicks != nil && !*options.TrackClicks {
+			sl.ReportError(options.TrackClicks, "urlOptions.trackClicks", "TrackClicks", "requiredfortrackingurl", "")
+		}
+	}
+}
+
+func isTrue(value *bool) bool {
+	return value != nil && *value
+}
+
 type SMSStatus struct {
 	Action      string `json:"action"`
 	Description string `json:"description"`
@@ -90,6 +184,12 @@ type SMSPrice struct {
 	Currency        string  `json:"currency"`
 }
 
+// ConfirmSMSConversionResponse is returned when a conversion is reported for a tracked message.
+type ConfirmSMSConversionResponse struct {
+	MessageID  string `json:"messageId"`
+	ProcessKey string `json:"processKey"`
+}
+
 type SendSMSResponse struct {
 	BulkID   string `json:"bulkId"`
 	Messages []struct {
@@ -175,13 +275,129 @@ type SMSBinary struct {
 	EsmClass   int    `json:"esmClass"`
 }
 
+// IndiaDLT holds the Distributed Ledger Technology parameters required for sending SMS to India. The zero value
+// means that no DLT parameters are set, and is not validated.
 type IndiaDLT struct {
 	ContentTemplateID string `json:"contentTemplateId" validate:"max=30"`
 	PrincipalEntityID string `json:"principalEntityId" validate:"required,min=1,max=30"`
 }
 
+// TurkeyIYS holds the IYS parameters required for sending commercial SMS to numbers registered in Turkey.
+// When BrandCode is not set, the default brand code of the account is used.
+type TurkeyIYS struct {
+	BrandCode     int    `json:"brandCode,omitempty"`
+	RecipientType string `json:"recipientType" validate:"required,oneof=BIREYSEL TACIR"`
+}
+
+// SouthKorea holds the parameters specific to SMS sent to South Korea.
+type SouthKorea struct {
+	ResellerCode int    `json:"resellerCode,omitempty"`
+	Title        string `json:"title,omitempty" validate:"lte=66"`
+}
+
 type SMSRegional struct {
-	IndiaDLT `json:"indiaDlt" validate:"omitempty,dive"`
+	IndiaDLT   `json:"indiaDlt" validate:"-"`
+	TurkeyIYS  *TurkeyIYS  `json:"turkeyIys,omitempty"`
+	SouthKorea *SouthKorea `json:"southKorea,omitempty"`
+}
+
+// MarshalJSON leaves out IndiaDLT when it is not set.
+func (r SMSRegional) MarshalJSON() ([]byte, error) {
+	regional := struct {
+		IndiaDLT   *IndiaDLT   `json:"indiaDlt,omitempty"`
+		TurkeyIYS  *TurkeyIYS  `json:"turkeyIys,omitempty"`
+		SouthKorea *SouthKorea `json:"southKorea,omitempty"`
+	}{TurkeyIYS: r.TurkeyIYS, SouthKorea: r.SouthKorea}
+	if r.IndiaDLT != (IndiaDLT{}) {
+		regional.IndiaDLT = &r.IndiaDLT
+	}
+	return json.Marshal(regional)
+}
+
+// DestinationCountry returns the ISO 3166-1 alpha-2 code of the country with regional SMS regulations the
+// number belongs to, or an empty string for any other country. Numbers are expected in international format,
+// optionally prefixed with + or 00.
+func DestinationCountry(to string) string {
+	number := strings.TrimPrefix(strings.TrimPrefix(strings.TrimSpace(to), "+"), "00")
+	switch {
+	case strings.HasPrefix(number, "91"):
+		return CountryIndia
+	case strings.HasPrefix(number, "90"):
+		return CountryTurkey
+	case strings.HasPrefix(number, "82"):
+		return CountrySouthKorea
+	}
+	return ""
+}
+
+// SMSRegionalRules selects the regional parameters required by destination country. The rules are only checked by
+// ValidateRegional, since they depend on the traffic being sent.
+type SMSRegionalRules struct {
+	// IndiaDLT requires DLT parameters with a content template ID for destinations in India.
+	IndiaDLT bool
+	// TurkeyIYS requires IYS parameters for destinations in Turkey. IYS only applies to commercial messages, so
+	// transactional traffic is sent without it.
+	TurkeyIYS bool
+}
+
+type smsRegionalRulesKey struct{}
+
+func regionalRules(ctx context.Context) SMSRegionalRules {
+	rules, _ := ctx.Value(smsRegionalRulesKey{}).(SMSRegionalRules)
+	return rules
+}
+
+func validateRegional(rules SMSRegionalRules, s interface{}) error {
+	return validate.StructCtx(context.WithValue(context.Background(), smsRegionalRulesKey{}, rules), s)
+}
+
+func smsMsgValidation(ctx context.Context, sl validator.StructLevel) {
+	msg, _ := sl.Current().Interface().(SMSMsg)
+	validateRegionalByDestination(sl, regionalRules(ctx), msg.Destinations, msg.Regional)
+}
+
+func binarySMSMsgValidation(ctx context.Context, sl validator.StructLevel) {
+	msg, _ := sl.Current().Interface().(BinarySMSMsg)
+	validateRegionalByDestination(sl, regionalRules(ctx), msg.Destinations, msg.Regional)
+}
+
+// smsRegionalValidation validates IndiaDLT only when it is set, since embedded structs are always validated.
+func smsRegionalValidation(sl validator.StructLevel) {
+	regional, _ := sl.Current().Interface().(SMSRegional)
+	if regional.IndiaDLT == (IndiaDLT{}) {
+		return
+	}
+	var validationErrors validator.ValidationErrors
+	if errors.As(sl.Validator().Struct(regional.IndiaDLT), &validationErrors) {
+		for _, fieldErr := range validationErrors {
+			sl.ReportError(fieldErr.Value(), fieldErr.Field(), fieldErr.StructField(), fieldErr.Tag(), fieldErr.Param())
+		}
+	}
+}
+
+func validateRegionalByDestination(
+	sl validator.StructLevel,
+	rules SMSRegionalRules,
+	destinations []SMSDestination,
+	regional *SMSRegional,
+) {
+	countries := map[string]bool{}
+	for _, destination := range destinations {
+		countries[DestinationCountry(destination.To)] = true
+	}
+
+	if rules.IndiaDLT && countries[CountryIndia] {
+		switch {
+		case regional == nil || regional.IndiaDLT == (IndiaDLT{}):
+			sl.ReportError(regional, "regional", "Regional", "missingindiadlt", "")
+		case regional.IndiaDLT.ContentTemplateID == "":
+			sl.ReportError(
+				regional.IndiaDLT.ContentTemplateID, "contentTemplateId", "ContentTemplateID", "missingdlttemplateid", "")
+		}
+	}
+	if rules.TurkeyIYS && countries[CountryTurkey] && (regional == nil || regional.TurkeyIYS == nil) {
+		sl.ReportError(regional, "regional", "Regional", "missingturkeyiys", "")
+	}
 }
 
 type BinarySMSMsg struct {
@@ -202,6 +418,12 @@ func (b *BinarySMSMsg) Validate() error {
 	return validate.Struct(b)
 }
 
+// ValidateRegional validates the message like Validate, also requiring the regional parameters selected by rules
+// for its destinations.
+func (b *BinarySMSMsg) ValidateRegional(rules SMSRegionalRules) error {
+	return validateRegional(rules, b)
+}
+
 func (b *BinarySMSMsg) Marshal() (*bytes.Buffer, error) {
 	return marshalJSON(b)
 }
@@ -216,6 +438,12 @@ func (s *SendBinarySMSRequest) Validate() error {
 	return validate.Struct(s)
 }
 
+// ValidateRegional validates the request like Validate, also requiring the regional parameters selected by rules
+// for its destinations.
+func (s *SendBinarySMSRequest) ValidateRegional(rules SMSRegionalRules) error {
+	return validateRegional(rules, s)
+}
+
 func (s *SendBinarySMSRequest) Marshal() (*bytes.Buffer, error) {
 	return marshalJSON(s)
 }
@@ -586,6 +814,72 @@ func (r *ResendPINOverVoiceRequest) Marshal() (*bytes.Buffer, error) {
 
 type ResendPINOverVoiceResponse SendPINResponse
 
+type TFAEmailMessageTemplate struct {
+	ApplicationID   string  `json:"applicationId,omitempty"`
+	MessageID       string  `json:"messageId,omitempty"`
+	From            string  `json:"from" validate:"required"`
+	EmailTemplateID int64   `json:"emailTemplateId" validate:"required"`
+	PINLength       int     `json:"pinLength" validate:"required"`
+	PINType         PINType `json:"pinType" validate:"required"`
+}
+
+type CreateTFAEmailMessageTemplateRequest TFAEmailMessageTemplate
+
+func (c *CreateTFAEmailMessageTemplateRequest) Validate() error {
+	return validate.Struct(c)
+}
+
+func (c *CreateTFAEmailMessageTemplateRequest) Marshal() (*bytes.Buffer, error) {
+	return marshalJSON(c)
+}
+
+type CreateTFAEmailMessageTemplateResponse TFAEmailMessageTemplate
+
+type UpdateTFAEmailMessageTemplateRequest TFAEmailMessageTemplate
+
+func (u *UpdateTFAEmailMessageTemplateRequest) Validate() error {
+	return validate.Struct(u)
+}
+
+func (u *UpdateTFAEmailMessageTemplateRequest) Marshal() (*bytes.Buffer, error) {
+	return marshalJSON(u)
+}
+
+type UpdateTFAEmailMessageTemplateResponse TFAEmailMessageTemplate
+
+type SendPINOverEmailRequest SendPINRequest
+
+func (s *SendPINOverEmailRequest) Validate() error {
+	return validate.Struct(s)
+}
+
+func (s *SendPINOverEmailRequest) Marshal() (*bytes.Buffer, error) {
+	return marshalJSON(s)
+}
+
+type TFAEmailStatus struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+type SendPINOverEmailResponse struct {
+	PINID       string          `json:"pinId"`
+	To          string          `json:"to"`
+	EmailStatus *TFAEmailStatus `json:"emailStatus,omitempty"`
+}
+
+type ResendPINOverEmailRequest ResendPINRequest
+
+func (r *ResendPINOverEmailRequest) Validate() error {
+	return validate.Struct(r)
+}
+
+func (r *ResendPINOverEmailRequest) Marshal() (*bytes.Buffer, error) {
+	return marshalJSON(r)
+}
+
+type ResendPINOverEmailResponse SendPINOverEmailResponse
+
 type VerifyPhoneNumberRequest struct {
 	PIN string `json:"pin" validate:"required"`
 }
@@ -603,6 +897,7 @@ type VerifyPhoneNumberResponse struct {
 	MSISDN            string `json:"msisdn"`
 	Verified          bool   `json:"verified"`
 	AttemptsRemaining int    `json:"attemptsRemaining"`
+	PINError          string `json:"pinError,omitempty"`
 }
 
 type GetTFAVerificationStatusParams struct {
@@ -0,0 +1,195 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDestinationCountry(t *testing.T) {
+	tests := []struct {
+		to       string
+		expected string
+	}{
+		{to: "919876543210", expected: CountryIndia},
+		{to: "+919876543210", expected: CountryIndia},
+		{to: "00905321234567", expected: CountryTurkey},
+		{to: "821012345678", expected: CountrySouthKorea},
+		{to: "16175551212", expected: ""},
+		{to: "41793026727", expected: ""},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.to, func(t *testing.T) {
+			assert.Equal(t, tc.expected, DestinationCountry(tc.to))
+		})
+	}
+}
+
+func TestValidRegionalSMSMsg(t *testing.T) {
+	tests := []struct {
+		name     string
+		instance SMSMsg
+	}{
+		{
+			name: "india with dlt",
+			instance: SMSMsg{
+				Destinations: []SMSDestination{{To: "919876543210"}},
+				Regional: &SMSRegional{
+					IndiaDLT: IndiaDLT{ContentTemplateID: "some-template", PrincipalEntityID: "some-entity"},
+				},
+			},
+		},
+		{
+			name: "turkey with iys",
+			instance: SMSMsg{
+				Destinations: []SMSDestination{{To: "905321234567"}},
+				Regional:     &SMSRegional{TurkeyIYS: &TurkeyIYS{BrandCode: 123456, RecipientType: "TACIR"}},
+			},
+		},
+		{
+			name: "south korea",
+			instance: SMSMsg{
+				Destinations: []SMSDestination{{To: "821012345678"}},
+				Regional:     &SMSRegional{SouthKorea: &SouthKorea{ResellerCode: 1234, Title: "Notice"}},
+			},
+		},
+		{
+			name: "india and turkey",
+			instance: SMSMsg{
+				Destinations: []SMSDestination{{To: "919876543210"}, {To: "905321234567"}},
+				Regional: &SMSRegional{
+					IndiaDLT:  IndiaDLT{ContentTemplateID: "some-template", PrincipalEntityID: "some-entity"},
+					TurkeyIYS: &TurkeyIYS{RecipientType: "BIREYSEL"},
+				},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.instance.ValidateRegional(SMSRegionalRules{IndiaDLT: true, TurkeyIYS: true})
+			require.NoError(t, err)
+
+			marshalled, err := tc.instance.Marshal()
+			require.NoError(t, err)
+
+			var unmarshalled SMSMsg
+			err = json.Unmarshal(marshalled.Bytes(), &unmarshalled)
+			require.NoError(t, err)
+			assert.Equal(t, tc.instance, unmarshalled)
+		})
+	}
+}
+
+func TestInvalidRegionalSMSMsg(t *testing.T) {
+	tests := []struct {
+		name     string
+		instance SMSMsg
+	}{
+		{
+			name:     "india without regional",
+			instance: SMSMsg{Destinations: []SMSDestination{{To: "919876543210"}}},
+		},
+		{
+			name: "india without dlt template id",
+			instance: SMSMsg{
+				Destinations: []SMSDestination{{To: "919876543210"}},
+				Regional:     &SMSRegional{IndiaDLT: IndiaDLT{PrincipalEntityID: "some-entity"}},
+			},
+		},
+		{
+			name: "india without principal entity id",
+			instance: SMSMsg{
+				Destinations: []SMSDestination{{To: "919876543210"}},
+				Regional:     &SMSRegional{IndiaDLT: IndiaDLT{ContentTemplateID: "some-template"}},
+			},
+		},
+		{
+			name: "turkey without iys",
+			instance: SMSMsg{
+				Destinations: []SMSDestination{{To: "905321234567"}},
+				Regional:     &SMSRegional{SouthKorea: &SouthKorea{Title: "Notice"}},
+			},
+		},
+		{
+			name: "turkey with invalid recipient type",
+			instance: SMSMsg{
+				Destinations: []SMSDestination{{To: "905321234567"}},
+				Regional:     &SMSRegional{TurkeyIYS: &TurkeyIYS{RecipientType: "SOMEONE"}},
+			},
+		},
+		{
+			name: "mixed destinations without india dlt",
+			instance: SMSMsg{
+				Destinations: []SMSDestination{{To: "16175551212"}, {To: "+919876543210"}},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.instance.ValidateRegional(SMSRegionalRules{IndiaDLT: true, TurkeyIYS: true})
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestRegionalRulesAreOptIn(t *testing.T) {
+	msg := SMSMsg{Destinations: []SMSDestination{{To: "919876543210"}, {To: "905321234567"}}}
+	require.NoError(t, msg.Validate())
+	require.NoError(t, msg.ValidateRegional(SMSRegionalRules{}))
+
+	err := msg.ValidateRegional(SMSRegionalRules{TurkeyIYS: true})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "missingturkeyiys")
+	assert.NotContains(t, err.Error(), "missingindiadlt")
+
+	req := SendSMSRequest{Messages: []SMSMsg{msg}}
+	require.NoError(t, req.Validate())
+	err = req.ValidateRegional(SMSRegionalRules{IndiaDLT: true})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "missingindiadlt")
+}
+
+func TestIndiaDLTRequiresPrincipalEntityIDWhenSet(t *testing.T) {
+	msg := SMSMsg{
+		Destinations: []SMSDestination{{To: "16175551212"}},
+		Regional:     &SMSRegional{IndiaDLT: IndiaDLT{ContentTemplateID: "some-template"}},
+	}
+	err := msg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "PrincipalEntityID")
+
+	msg.Regional = &SMSRegional{SouthKorea: &SouthKorea{Title: "Notice"}}
+	require.NoError(t, msg.Validate())
+}
+
+func TestInvalidRegionalBinarySMSMsg(t *testing.T) {
+	msg := GenerateBinarySMSMsg()
+	msg.Destinations = []SMSDestination{{To: "905321234567"}}
+	require.NoError(t, msg.Validate())
+	require.Error(t, msg.ValidateRegional(SMSRegionalRules{TurkeyIYS: true}))
+
+	msg.Regional.TurkeyIYS = &TurkeyIYS{RecipientType: "TACIR"}
+	require.NoError(t, msg.ValidateRegional(SMSRegionalRules{TurkeyIYS: true}))
+
+	req := SendBinarySMSRequest{Messages: []BinarySMSMsg{msg}}
+	require.NoError(t, req.ValidateRegional(SMSRegionalRules{TurkeyIYS: true}))
+}
+
+func TestSMSRegionalMarshalsIndiaDLTOnlyWhenSet(t *testing.T) {
+	marshalled, err := json.Marshal(SMSRegional{TurkeyIYS: &TurkeyIYS{RecipientType: "TACIR"}})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"turkeyIys": {"recipientType": "TACIR"}}`, string(marshalled))
+
+	marshalled, err = json.Marshal(SMSRegional{IndiaDLT: IndiaDLT{PrincipalEntityID: "some-entity"}})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"indiaDlt": {"contentTemplateId": "", "principalEntityId": "some-entity"}}`, string(marshalled))
+
+	template := GenerateCreateTFAMessageTemplateRequest()
+	template.Regional = &SMSRegional{SouthKorea: &SouthKorea{Title: "Notice"}}
+	require.NoError(t, template.Validate())
+}
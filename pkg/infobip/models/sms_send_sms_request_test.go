@@ -9,6 +9,7 @@ import (
 )
 
 func TestValidSendSMSRequest(t *testing.T) {
+	trueValue, falseValue := true, false
 	tests := []struct {
 		name     string
 		instance SendSMSRequest
@@ -29,6 +30,45 @@ func TestValidSendSMSRequest(t *testing.T) {
 			name:     "full input",
 			instance: GenerateSendSMSRequest(),
 		},
+		{
+			name: "url options",
+			instance: SendSMSRequest{
+				Messages: []SMSMsg{
+					{
+						Destinations: []SMSDestination{{To: "1212345678"}},
+						Text:         "Track your order at https://www.example.com/orders/1",
+					},
+				},
+				URLOptions: &SMSURLOptions{
+					ShortenURL:     &trueValue,
+					TrackClicks:    &trueValue,
+					TrackingURL:    "https://tracking.example.com/clicks",
+					RemoveProtocol: &trueValue,
+					CustomDomain:   "go.example.com",
+				},
+			},
+		},
+		{
+			name: "url tracking disabled without url in text",
+			instance: SendSMSRequest{
+				Messages:   []SMSMsg{{Destinations: []SMSDestination{{To: "1212345678"}}, Text: "No links"}},
+				URLOptions: &SMSURLOptions{ShortenURL: &falseValue},
+			},
+		},
+		{
+			name: "click tracking without url in text",
+			instance: SendSMSRequest{
+				Messages:   []SMSMsg{{Destinations: []SMSDestination{{To: "1212345678"}}, Text: "No links"}},
+				URLOptions: &SMSURLOptions{ShortenURL: &trueValue, TrackClicks: &trueValue},
+			},
+		},
+		{
+			name: "legacy url tracking without url in text",
+			instance: SendSMSRequest{
+				Messages: []SMSMsg{{Destinations: []SMSDestination{{To: "1212345678"}}, Text: "No links"}},
+				Tracking: &SMSTracking{Track: "URL", Type: "SOCIAL_INVITES"},
+			},
+		},
 	}
 
 	for _, tc := range tests {
@@ -49,6 +89,7 @@ func TestValidSendSMSRequest(t *testing.T) {
 }
 
 func TestInvalidSendSMSRequest(t *testing.T) {
+	trueValue, falseValue := true, false
 	tests := []struct {
 		name     string
 		instance SendSMSRequest
@@ -59,6 +100,30 @@ func TestInvalidSendSMSRequest(t *testing.T) {
 				Messages: []SMSMsg{},
 			},
 		},
+		{
+			name: "click tracking with shortening disabled",
+			instance: SendSMSRequest{
+				Messages:   []SMSMsg{{Destinations: []SMSDestination{{To: "1212345678"}}, Text: "See example.com"}},
+				URLOptions: &SMSURLOptions{ShortenURL: &falseValue, TrackClicks: &trueValue},
+			},
+		},
+		{
+			name: "tracking url with click tracking disabled",
+			instance: SendSMSRequest{
+				Messages: []SMSMsg{{Destinations: []SMSDestination{{To: "1212345678"}}, Text: "See example.com"}},
+				URLOptions: &SMSURLOptions{
+					TrackClicks: &falseValue,
+					TrackingURL: "https://tracking.example.com/clicks",
+				},
+			},
+		},
+		{
+			name: "invalid tracking url",
+			instance: SendSMSRequest{
+				Messages:   []SMSMsg{{Destinations: []SMSDestination{{To: "1212345678"}}, Text: "See example.com"}},
+				URLOptions: &SMSURLOptions{TrackingURL: "not a url"},
+			},
+		},
 	}
 
 	for _, tc := range tests {
@@ -68,3 +133,23 @@ func TestInvalidSendSMSRequest(t *testing.T) {
 		})
 	}
 }
+
+func TestSendSMSRequestMessagesWithoutURL(t *testing.T) {
+	trueValue := true
+	req := SendSMSRequest{
+		Messages: []SMSMsg{
+			{Destinations: []SMSDestination{{To: "1212345678"}}, Text: "No links"},
+			{Destinations: []SMSDestination{{To: "1212345678"}}, Text: "See example.com/offers"},
+			{Destinations: []SMSDestination{{To: "1212345678"}}},
+		},
+	}
+	assert.Empty(t, req.MessagesWithoutURL())
+
+	req.URLOptions = &SMSURLOptions{ShortenURL: &trueValue, TrackClicks: &trueValue}
+	assert.Equal(t, []int{0, 2}, req.MessagesWithoutURL())
+	require.NoError(t, req.Validate())
+
+	req.URLOptions = nil
+	req.Tracking = &SMSTracking{Track: "URL", Type: "SOCIAL_INVITES"}
+	assert.Equal(t, []int{0, 2}, req.MessagesWithoutURL())
+}
@@ -89,7 +89,7 @@ func GenerateBinarySMSMsg() BinarySMSMsg {
 			},
 		},
 		Regional: &SMSRegional{
-			IndiaDLT{
+			IndiaDLT: IndiaDLT{
 				ContentTemplateID: "some-id",
 				PrincipalEntityID: "some-principal-id",
 			},
@@ -135,7 +135,7 @@ func GenerateSMSMsg() SMSMsg {
 		},
 		SendAt: "10-10-2020T10:10:10Z",
 		Regional: &SMSRegional{
-			IndiaDLT{
+			IndiaDLT: IndiaDLT{
 				ContentTemplateID: "some-id",
 				PrincipalEntityID: "some-principal-id",
 			},
@@ -298,7 +298,7 @@ func GenerateCreateTFAMessageTemplateRequest() CreateTFAMessageTemplateRequest {
 		PINPlaceholder: "{{pin}}",
 		PINType:        NUMERIC,
 		Regional: &SMSRegional{
-			IndiaDLT{
+			IndiaDLT: IndiaDLT{
 				ContentTemplateID: "some-id",
 				PrincipalEntityID: "some-id",
 			},
@@ -319,7 +319,7 @@ func GenerateUpdateTFAMessageTemplateRequest() UpdateTFAMessageTemplateRequest {
 		PINPlaceholder: "{{pin}}",
 		PINType:        NUMERIC,
 		Regional: &SMSRegional{
-			IndiaDLT{
+			IndiaDLT: IndiaDLT{
 				ContentTemplateID: "some-id",
 				PrincipalEntityID: "some-id",
 			},
@@ -353,6 +353,44 @@ func GenerateSendPINOverVoiceRequest() SendPINOverVoiceRequest {
 	}
 }
 
+func GenerateCreateTFAEmailMessageTemplateRequest() CreateTFAEmailMessageTemplateRequest {
+	return CreateTFAEmailMessageTemplateRequest{
+		From:            "Company <company@example.com>",
+		EmailTemplateID: 1234,
+		PINLength:       4,
+		PINType:         NUMERIC,
+	}
+}
+
+func GenerateUpdateTFAEmailMessageTemplateRequest() UpdateTFAEmailMessageTemplateRequest {
+	return UpdateTFAEmailMessageTemplateRequest{
+		From:            "company@example.com",
+		EmailTemplateID: 1234,
+		PINLength:       6,
+		PINType:         ALPHANUMERIC,
+	}
+}
+
+func GenerateSendPINOverEmailRequest() SendPINOverEmailRequest {
+	return SendPINOverEmailRequest{
+		ApplicationID: "ABC1234",
+		MessageID:     "ABC1234",
+		From:          "company@example.com",
+		To:            "john.smith@example.com",
+		Placeholders: map[string]string{
+			"name": "some-name",
+		},
+	}
+}
+
+func GenerateResendPINOverEmailRequest() ResendPINOverEmailRequest {
+	return ResendPINOverEmailRequest{
+		Placeholders: map[string]string{
+			"name": "some-name",
+		},
+	}
+}
+
 func GenerateResendPINOverSMSRequest() ResendPINOverSMSRequest {
 	return ResendPINOverSMSRequest{
 		Placeholders: map[string]string{
@@ -0,0 +1,97 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidCreateTFAEmailMessageTemplateRequest(t *testing.T) {
+	tests := []struct {
+		name     string
+		instance CreateTFAEmailMessageTemplateRequest
+	}{
+		{
+			name: "minimum input",
+			instance: CreateTFAEmailMessageTemplateRequest{
+				From:            "company@example.com",
+				EmailTemplateID: 1,
+				PINLength:       4,
+				PINType:         NUMERIC,
+			},
+		},
+		{
+			name:     "full input",
+			instance: GenerateCreateTFAEmailMessageTemplateRequest(),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.instance.Validate()
+			require.NoError(t, err)
+
+			marshalled, err := tc.instance.Marshal()
+			require.NoError(t, err)
+			assert.NotEmpty(t, marshalled)
+
+			var unmarshalled CreateTFAEmailMessageTemplateRequest
+			err = json.Unmarshal(marshalled.Bytes(), &unmarshalled)
+			require.NoError(t, err)
+			assert.Equal(t, tc.instance, unmarshalled)
+		})
+	}
+}
+
+func TestInvalidCreateTFAEmailMessageTemplateRequest(t *testing.T) {
+	tests := []struct {
+		name     string
+		instance CreateTFAEmailMessageTemplateRequest
+	}{
+		{
+			name:     "empty",
+			instance: CreateTFAEmailMessageTemplateRequest{},
+		},
+		{
+			name: "no from",
+			instance: CreateTFAEmailMessageTemplateRequest{
+				EmailTemplateID: 1,
+				PINLength:       4,
+				PINType:         NUMERIC,
+			},
+		},
+		{
+			name: "no email template id",
+			instance: CreateTFAEmailMessageTemplateRequest{
+				From:      "company@example.com",
+				PINLength: 4,
+				PINType:   NUMERIC,
+			},
+		},
+		{
+			name: "no pin length",
+			instance: CreateTFAEmailMessageTemplateRequest{
+				From:            "company@example.com",
+				EmailTemplateID: 1,
+				PINType:         NUMERIC,
+			},
+		},
+		{
+			name: "no pin type",
+			instance: CreateTFAEmailMessageTemplateRequest{
+				From:            "company@example.com",
+				EmailTemplateID: 1,
+				PINLength:       4,
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.instance.Validate()
+			require.Error(t, err)
+		})
+	}
+}
@@ -88,7 +88,7 @@ func TestInvalidCreateTFAMessageTemplateRequest(t *testing.T) {
 				PINPlaceholder: "{{pin}}",
 				PINType:        NUMERIC,
 				Regional: &SMSRegional{
-					IndiaDLT{
+					IndiaDLT: IndiaDLT{
 						ContentTemplateID: "some-id",
 						PrincipalEntityID: "",
 					},
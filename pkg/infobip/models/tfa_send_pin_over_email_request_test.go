@@ -0,0 +1,89 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidSendPINOverEmailRequest(t *testing.T) {
+	tests := []struct {
+		name     string
+		instance SendPINOverEmailRequest
+	}{
+		{
+			name: "minimum input",
+			instance: SendPINOverEmailRequest{
+				ApplicationID: "ABC123",
+				MessageID:     "ABC123",
+				To:            "john.smith@example.com",
+				Placeholders:  map[string]string{"name": "John"},
+			},
+		},
+		{
+			name:     "full input",
+			instance: GenerateSendPINOverEmailRequest(),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.instance.Validate()
+			require.NoError(t, err)
+
+			marshalled, err := tc.instance.Marshal()
+			require.NoError(t, err)
+			assert.NotEmpty(t, marshalled)
+
+			var unmarshalled SendPINOverEmailRequest
+			err = json.Unmarshal(marshalled.Bytes(), &unmarshalled)
+			require.NoError(t, err)
+			assert.Equal(t, tc.instance, unmarshalled)
+		})
+	}
+}
+
+func TestInvalidSendPINOverEmailRequest(t *testing.T) {
+	tests := []struct {
+		name     string
+		instance SendPINOverEmailRequest
+	}{
+		{
+			name:     "empty",
+			instance: SendPINOverEmailRequest{},
+		},
+		{
+			name: "no application id",
+			instance: SendPINOverEmailRequest{
+				MessageID:    "ABC123",
+				To:           "john.smith@example.com",
+				Placeholders: map[string]string{"name": "John"},
+			},
+		},
+		{
+			name: "no message id",
+			instance: SendPINOverEmailRequest{
+				ApplicationID: "ABC123",
+				To:            "john.smith@example.com",
+				Placeholders:  map[string]string{"name": "John"},
+			},
+		},
+		{
+			name: "no to",
+			instance: SendPINOverEmailRequest{
+				ApplicationID: "ABC123",
+				MessageID:     "ABC123",
+				Placeholders:  map[string]string{"name": "John"},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.instance.Validate()
+			require.Error(t, err)
+		})
+	}
+}
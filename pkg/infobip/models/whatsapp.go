@@ -2,7 +2,15 @@ package models
 
 import (
 	"bytes"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/textproto"
+	"net/url"
+	"strings"
 	"time"
 	"unicode"
 
@@ -18,6 +26,10 @@ func setupWhatsAppValidations() {
 	}
 	validate.RegisterStructValidation(templateCreateValidation, TemplateCreate{})
 	validate.RegisterStructValidation(templateCreateButtonValidation, TemplateButton{})
+	validate.RegisterStructValidation(templateEditValidation, TemplateEdit{})
+	validate.RegisterStructValidation(waUploadMediaValidation, WAUploadMediaRequest{})
+	validate.RegisterStructValidation(waMsgContextValidation, WAMsgContext{})
+	validate.RegisterStructValidation(reactionContentValidation, ReactionContent{})
 	validate.RegisterStructValidation(templateMsgValidation, TemplateMsg{})
 	validate.RegisterStructValidation(templateMsgButtonValidation, TemplateMsgButton{})
 	validate.RegisterStructValidation(textMsgValidation, WATextMsg{})
@@ -25,6 +37,8 @@ func setupWhatsAppValidations() {
 	validate.RegisterStructValidation(interactiveButtonsMsgValidation, WAInteractiveButtonsMsg{})
 	validate.RegisterStructValidation(interactiveListMsgValidation, WAInteractiveListMsg{})
 	validate.RegisterStructValidation(multiproductMsgValidation, WAInteractiveMultiproductMsg{})
+	validate.RegisterStructValidation(urlButtonMsgValidation, WAInteractiveURLButtonMsg{})
+	validate.RegisterStructValidation(flowMsgValidation, WAInteractiveFlowMsg{})
 }
 
 type BulkWAMsgResponse struct {
@@ -60,6 +74,7 @@ type CreateWATemplateResponse struct {
 	Status            string            `json:"status"`
 	Category          string            `json:"category"`
 	Structure         TemplateStructure `json:"structure"`
+	RejectedReason    string            `json:"rejectedReason,omitempty"`
 }
 
 type TemplateStructureBody struct {
@@ -175,12 +190,66 @@ func templateCreateButtonValidation(sl validator.StructLevel) {
 	}
 }
 
+// TemplateEdit changes the category or structure of an existing template. The name and language of a template
+// cannot be changed.
+type TemplateEdit struct {
+	Category  string            `json:"category,omitempty"`
+	Structure TemplateStructure `json:"structure" validate:"required"`
+}
+
+func (t *TemplateEdit) Validate() error {
+	return validate.Struct(t)
+}
+
+func (t *TemplateEdit) Marshal() (*bytes.Buffer, error) {
+	return marshalJSON(t)
+}
+
+func templateEditValidation(sl validator.StructLevel) {
+	edit, _ := sl.Current().Interface().(TemplateEdit)
+	template := TemplateCreate{Category: edit.Category, Structure: edit.Structure}
+	if edit.Category != "" {
+		validateTemplateCategory(sl, template)
+	}
+	validateTemplateHeader(sl, template)
+	validateTemplateButtons(sl, template)
+}
+
 type MsgCommon struct {
 	From         string `json:"from" validate:"required,lte=24"`
 	To           string `json:"to" validate:"required,lte=24"`
 	MessageID    string `json:"messageId,omitempty" validate:"lte=50"`
 	CallbackData string `json:"callbackData,omitempty" validate:"lte=4000"`
 	NotifyURL    string `json:"notifyUrl,omitempty" validate:"omitempty,url,lte=2048"`
+	// Context makes the message a reply quoting an earlier message of the conversation.
+	Context *WAMsgContext `json:"context,omitempty"`
+}
+
+// WAMsgContext references the message a reply is quoting, by the ID of the inbound or outbound message.
+type WAMsgContext struct {
+	MessageID string `json:"messageId" validate:"required,lte=200"`
+}
+
+func waMsgContextValidation(sl validator.StructLevel) {
+	msgContext, _ := sl.Current().Interface().(WAMsgContext)
+	if msgContext.MessageID != "" && !IsValidWAMessageID(msgContext.MessageID) {
+		sl.ReportError(msgContext.MessageID, "messageId", "MessageID", "invalidmessageid", "")
+	}
+}
+
+// IsValidWAMessageID reports whether the ID has the format of a WhatsApp message ID: Infobip IDs are UUIDs or
+// alphanumeric, and Meta IDs look like wamid.HBgLMzg1OTE2MjQyNDkzFQIAEhgg=.
+func IsValidWAMessageID(messageID string) bool {
+	for _, r := range messageID {
+		if !isMessageIDRune(r) {
+			return false
+		}
+	}
+	return messageID != ""
+}
+
+func isMessageIDRune(r rune) bool {
+	return r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r) || strings.ContainsRune("._:=+/-", r))
 }
 
 type WATemplateMsgs struct {
@@ -435,6 +504,133 @@ type StickerContent struct {
 	MediaURL string `json:"mediaUrl" validate:"required,url,lte=2048"`
 }
 
+type WAReactionMsg struct {
+	MsgCommon
+	Content ReactionContent `json:"content" validate:"required"`
+}
+
+func (t *WAReactionMsg) Validate() error {
+	return validate.Struct(t)
+}
+
+func (t *WAReactionMsg) Marshal() (*bytes.Buffer, error) {
+	return marshalJSON(t)
+}
+
+// ReactionContent reacts to the message with the ID with a single emoji. An empty Reaction removes an earlier
+// reaction to the message.
+type ReactionContent struct {
+	MessageID string `json:"messageId" validate:"required,lte=200"`
+	Reaction  string `json:"reaction"`
+}
+
+func reactionContentValidation(sl validator.StructLevel) {
+	content, _ := sl.Current().Interface().(ReactionContent)
+	if content.MessageID != "" && !IsValidWAMessageID(content.MessageID) {
+		sl.ReportError(content.MessageID, "messageId", "MessageID", "invalidmessageid", "")
+	}
+	if content.Reaction != "" && !IsSingleEmoji(content.Reaction) {
+		sl.ReportError(content.Reaction, "reaction", "Reaction", "singleemoji", "")
+	}
+}
+
+const (
+	variationSelector = '\uFE0F'
+	zeroWidthJoiner   = '\u200D'
+	combiningKeycap   = '\u20E3'
+)
+
+// IsSingleEmoji reports whether the text is exactly one emoji, including emoji with skin tone modifiers, keycaps,
+// flags and sequences joined with zero width joiners, like the family emoji.
+func IsSingleEmoji(text string) bool {
+	runes := []rune(text)
+	if len(runes) == 0 {
+		return false
+	}
+	if isRegionalIndicator(runes[0]) {
+		return len(runes) == 2 && isRegionalIndicator(runes[1])
+	}
+	if isKeycapBase(runes[0]) {
+		rest := runes[1:]
+		if len(rest) > 0 && rest[0] == variationSelector {
+			rest = rest[1:]
+		}
+		return len(rest) == 1 && rest[0] == combiningKeycap
+	}
+
+	expectEmoji := true
+	for _, r := range runes {
+		switch {
+		case expectEmoji:
+			if !isEmojiRune(r) {
+				return false
+			}
+			expectEmoji = false
+		case r == zeroWidthJoiner:
+			expectEmoji = true
+		case r == variationSelector, isSkinToneModifier(r), isTagRune(r):
+		default:
+			return false
+		}
+	}
+	return !expectEmoji
+}
+
+// Emoji code points, from the Unicode emoji data. Regional indicators and skin tone modifiers only appear as part
+// of an emoji, so they are kept in their own tables.
+var (
+	emojiTable = &unicode.RangeTable{ //nolint: gochecknoglobals // read-only lookup table
+		R16: []unicode.Range16{
+			{Lo: 0x00A9, Hi: 0x00AE, Stride: 5},
+			{Lo: 0x203C, Hi: 0x2049, Stride: 13},
+			{Lo: 0x2122, Hi: 0x2139, Stride: 23},
+			{Lo: 0x2190, Hi: 0x21FF, Stride: 1},
+			{Lo: 0x2300, Hi: 0x23FF, Stride: 1},
+			{Lo: 0x24C2, Hi: 0x24C2, Stride: 1},
+			{Lo: 0x25A0, Hi: 0x27BF, Stride: 1},
+			{Lo: 0x2934, Hi: 0x2935, Stride: 1},
+			{Lo: 0x2B00, Hi: 0x2BFF, Stride: 1},
+			{Lo: 0x3030, Hi: 0x303D, Stride: 13},
+			{Lo: 0x3297, Hi: 0x3299, Stride: 2},
+		},
+		R32: []unicode.Range32{
+			{Lo: 0x1F000, Hi: 0x1F1E5, Stride: 1},
+			{Lo: 0x1F200, Hi: 0x1F3FA, Stride: 1},
+			{Lo: 0x1F400, Hi: 0x1FAFF, Stride: 1},
+		},
+		LatinOffset: 1,
+	}
+	regionalIndicatorTable = &unicode.RangeTable{ //nolint: gochecknoglobals // read-only lookup table
+		R32: []unicode.Range32{{Lo: 0x1F1E6, Hi: 0x1F1FF, Stride: 1}},
+	}
+	skinToneModifierTable = &unicode.RangeTable{ //nolint: gochecknoglobals // read-only lookup table
+		R32: []unicode.Range32{{Lo: 0x1F3FB, Hi: 0x1F3FF, Stride: 1}},
+	}
+	tagTable = &unicode.RangeTable{ //nolint: gochecknoglobals // read-only lookup table
+		R32: []unicode.Range32{{Lo: 0xE0020, Hi: 0xE007F, Stride: 1}},
+	}
+)
+
+func isEmojiRune(r rune) bool {
+	return unicode.Is(emojiTable, r)
+}
+
+func isRegionalIndicator(r rune) bool {
+	return unicode.Is(regionalIndicatorTable, r)
+}
+
+func isSkinToneModifier(r rune) bool {
+	return unicode.Is(skinToneModifierTable, r)
+}
+
+func isTagRune(r rune) bool {
+	return unicode.Is(tagTable, r)
+}
+
+func isKeycapBase(r rune) bool {
+	return r == '#' || r == '*' || (r >= '0' && r <= '9')
+}
+
 type WALocationMsg struct {
 	MsgCommon
 	Content LocationContent `json:"content" validate:"required"`
@@ -557,15 +753,18 @@ func validateInteractiveButtonsHeader(sl validator.StructLevel, msg WAInteractiv
 	if header == nil {
 		return
 	}
+	validateInteractiveHeader(sl, header.Type, header.Text, header.MediaURL)
+}
 
-	switch header.Type {
+func validateInteractiveHeader(sl validator.StructLevel, headerType string, text string, mediaURL string) {
+	switch headerType {
 	case "TEXT":
-		if header.Text == "" {
-			sl.ReportError(msg.Content.Header.Text, "text", "Text", "missingtext", "")
+		if text == "" {
+			sl.ReportError(text, "text", "Text", "missingtext", "")
 		}
 	case "VIDEO", "IMAGE", "DOCUMENT":
-		if header.MediaURL == "" {
-			sl.ReportError(msg.Content.Header.MediaURL, "mediaUrl", "MediaURL", "missingmediaurl", "")
+		if mediaURL == "" {
+			sl.ReportError(mediaURL, "mediaUrl", "MediaURL", "missingmediaurl", "")
 		}
 	}
 }
@@ -810,3 +1009,530 @@ type InteractiveMultiproductSection struct {
 type InteractiveMultiproductFooter struct {
 	Text string `json:"text" validate:"required,lte=60"`
 }
+
+const (
+	WAMediaImage    = "IMAGE"
+	WAMediaDocument = "DOCUMENT"
+	WAMediaAudio    = "AUDIO"
+	WAMediaVideo    = "VIDEO"
+	WAMediaSticker  = "STICKER"
+
+	megabyte = 1024 * 1024
+	kilobyte = 1024
+
+	waImageMaxSize    = 5 * megabyte
+	waDocumentMaxSize = 100 * megabyte
+	waAudioMaxSize    = 16 * megabyte
+	waVideoMaxSize    = 16 * megabyte
+	waStickerMaxSize  = 500 * kilobyte
+)
+
+var ErrWAMediaTooLarge = errors.New("media exceeds the whatsapp size limit")
+
+// ErrMediaNotRewindable is returned when a request body is written again, e.g. on a retry, but its media or an
+// attachment was already read from a reader that can't seek.
+var ErrMediaNotRewindable = errors.New("media was already read and can't be rewound")
+
+// WAMediaLimit lists the content types WhatsApp accepts for a media type, and the maximum size in bytes.
+type WAMediaLimit struct {
+	ContentTypes []string
+	MaxSize      int64
+}
+
+// WAMediaLimitFor returns the limits WhatsApp applies to the media type, e.g. WAMediaImage.
+// Animated stickers may be up to 500 KB, static ones only up to 100 KB.
+func WAMediaLimitFor(mediaType string) (WAMediaLimit, bool) {
+	switch mediaType {
+	case WAMediaImage:
+		return WAMediaLimit{ContentTypes: []string{"image/jpeg", "image/png"}, MaxSize: waImageMaxSize}, true
+	case WAMediaDocument:
+		return WAMediaLimit{ContentTypes: []string{
+			"text/plain", "application/pdf", "application/vnd.ms-powerpoint", "application/msword",
+			"application/vnd.ms-excel", "application/vnd.openxmlformats-officedocument.wordprocessingml.document",
+			"application/vnd.openxmlformats-officedocument.presentationml.presentation",
+			"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+		}, MaxSize: waDocumentMaxSize}, true
+	case WAMediaAudio:
+		return WAMediaLimit{ContentTypes: []string{
+			"audio/aac", "audio/mp4", "audio/mpeg", "audio/amr", "audio/ogg",
+		}, MaxSize: waAudioMaxSize}, true
+	case WAMediaVideo:
+		return WAMediaLimit{ContentTypes: []string{"video/mp4", "video/3gpp"}, MaxSize: waVideoMaxSize}, true
+	case WAMediaSticker:
+		return WAMediaLimit{ContentTypes: []string{"image/webp"}, MaxSize: waStickerMaxSize}, true
+	}
+	return WAMediaLimit{}, false
+}
+
+// Allows reports whether the content type, ignoring parameters such as codecs, is accepted.
+func (l WAMediaLimit) Allows(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	for _, allowed := range l.ContentTypes {
+		if mediaType == allowed {
+			return true
+		}
+	}
+	return false
+}
+
+// WAUploadMediaRequest uploads media to be sent in WhatsApp messages. Media is read when the request is sent and is
+// never closed; media implementing io.Seeker is rewound when the request is retried. Size is optional; when set,
+// or when media implements io.Seeker, oversized media is rejected before the request is sent.
+type WAUploadMediaRequest struct {
+	MediaType   string    `validate:"required,oneof=IMAGE DOCUMENT AUDIO VIDEO STICKER"`
+	ContentType string    `validate:"required"`
+	Filename    string    `validate:"required,lte=240"`
+	Size        int64     `validate:"gte=0"`
+	Media       io.Reader `validate:"required"`
+	mediaRead   bool
+	mediaOffset int64
+}
+
+func (u *WAUploadMediaRequest) Validate() error {
+	if err := validate.Struct(u); err != nil {
+		return err
+	}
+	return u.checkMediaSize()
+}
+
+// checkMediaSize measures seekable media when Size isn't set, since the streamed body only fails once the limit is
+// exceeded, after the request was sent.
+func (u *WAUploadMediaRequest) checkMediaSize() error {
+	seeker, ok := u.Media.(io.Seeker)
+	if u.Size > 0 || !ok {
+		return nil
+	}
+	offset, err := seeker.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return err
+	}
+	end, err := seeker.Seek(0, io.SeekEnd)
+	if err != nil {
+		return err
+	}
+	if _, err = seeker.Seek(offset, io.SeekStart); err != nil {
+		return err
+	}
+	limit, _ := WAMediaLimitFor(u.MediaType)
+	if end-offset > limit.MaxSize {
+		return fmt.Errorf("%w: %s is larger than %d bytes", ErrWAMediaTooLarge, u.MediaType, limit.MaxSize)
+	}
+	return nil
+}
+
+func (u *WAUploadMediaRequest) WriteMultipart(multipartWriter *multipart.Writer) error {
+	if err := u.rewindMedia(); err != nil {
+		return err
+	}
+
+	limit, _ := WAMediaLimitFor(u.MediaType)
+	header := textproto.MIMEHeader{}
+	header.Set("Content-Type", u.ContentType)
+	header.Set("Content-Disposition",
+		fmt.Sprintf(`form-data; name="media"; filename="%s"`, escapeQuotes(u.Filename)))
+	part, err := multipartWriter.CreatePart(header)
+	if err != nil {
+		return err
+	}
+	written, err := io.Copy(part, io.LimitReader(u.Media, limit.MaxSize+1))
+	if err != nil {
+		return err
+	}
+	if written > limit.MaxSize {
+		return fmt.Errorf("%w: %s is larger than %d bytes", ErrWAMediaTooLarge, u.MediaType, limit.MaxSize)
+	}
+
+	return writeMultipartText(multipartWriter, "mediaType", u.MediaType)
+}
+
+// rewindMedia records the offset media is first read from, and seeks back to it on later writes.
+func (u *WAUploadMediaRequest) rewindMedia() error {
+	seeker, ok := u.Media.(io.Seeker)
+	if !u.mediaRead {
+		u.mediaRead = true
+		if ok {
+			offset, err := seeker.Seek(0, io.SeekCurrent)
+			if err != nil {
+				return err
+			}
+			u.mediaOffset = offset
+		}
+		return nil
+	}
+	if !ok {
+		return ErrMediaNotRewindable
+	}
+	_, err := seeker.Seek(u.mediaOffset, io.SeekStart)
+	return err
+}
+
+// Marshal buffers the whole multipart body. Requests stream the body instead.
+func (u *WAUploadMediaRequest) Marshal() (*bytes.Buffer, error) {
+	buf, _, err := marshalMultipart(u)
+	return buf, err
+}
+
+func waUploadMediaValidation(sl validator.StructLevel) {
+	req, _ := sl.Current().Interface().(WAUploadMediaRequest)
+	limit, ok := WAMediaLimitFor(req.MediaType)
+	if !ok {
+		return
+	}
+	if req.ContentType != "" && !limit.Allows(req.ContentType) {
+		sl.ReportError(req.ContentType, "contentType", "ContentType", "unsupportedcontenttype", "")
+	}
+	if req.Size > limit.MaxSize {
+		sl.ReportError(req.Size, "size", "Size", "mediatoolarge", "")
+	}
+}
+
+// WAUploadMediaResponse is the response of the API. MediaTransformation records how the media pipeline of the
+// channel changed the media to fit the WhatsApp size limit, if it did.
+type WAUploadMediaResponse struct {
+	MediaID             string               `json:"mediaId"`
+	MediaURL            string               `json:"mediaUrl,omitempty"`
+	MediaTransformation *MediaTransformation `json:"-"`
+}
+
+// WAMediaMetadata describes inbound media, as returned in the headers of the media.
+type WAMediaMetadata struct {
+	ContentType   string
+	ContentLength int64
+}
+
+type WAInteractiveLocationRequestMsg struct {
+	MsgCommon
+	Content InteractiveLocationRequestContent `json:"content" validate:"required"`
+}
+
+func (t *WAInteractiveLocationRequestMsg) Validate() error {
+	return validate.Struct(t)
+}
+
+func (t *WAInteractiveLocationRequestMsg) Marshal() (*bytes.Buffer, error) {
+	return marshalJSON(t)
+}
+
+// InteractiveLocationRequestContent asks the user to share their location. The location is received as an inbound
+// LOCATION message.
+type InteractiveLocationRequestContent struct {
+	Body InteractiveLocationRequestBody `json:"body" validate:"required"`
+}
+
+type InteractiveLocationRequestBody struct {
+	Text string `json:"text" validate:"required,lte=1024"`
+}
+
+type WAInteractiveURLButtonMsg struct {
+	MsgCommon
+	Content InteractiveURLButtonContent `json:"content" validate:"required"`
+}
+
+func (t *WAInteractiveURLButtonMsg) Validate() error {
+	return validate.Struct(t)
+}
+
+func (t *WAInteractiveURLButtonMsg) Marshal() (*bytes.Buffer, error) {
+	return marshalJSON(t)
+}
+
+func urlButtonMsgValidation(sl validator.StructLevel) {
+	msg, _ := sl.Current().Interface().(WAInteractiveURLButtonMsg)
+	if header := msg.Content.Header; header != nil {
+		validateInteractiveHeader(sl, header.Type, header.Text, header.MediaURL)
+	}
+	if link := msg.Content.Action.URL; link != "" && !isWebURL(link) {
+		sl.ReportError(link, "url", "URL", "httpurl", "")
+	}
+}
+
+// isWebURL reports whether the link opens in a browser, as the call-to-action button only supports web pages.
+func isWebURL(link string) bool {
+	parsed, err := url.Parse(link)
+	return err == nil && (parsed.Scheme == "http" || parsed.Scheme == "https") && parsed.Host != ""
+}
+
+type InteractiveURLButtonContent struct {
+	Body   InteractiveURLButtonBody    `json:"body" validate:"required"`
+	Action InteractiveURLButtonAction  `json:"action" validate:"required"`
+	Header *InteractiveURLButtonHeader `json:"header,omitempty" validate:"omitempty"`
+	Footer *InteractiveURLButtonFooter `json:"footer,omitempty"`
+}
+
+type InteractiveURLButtonBody struct {
+	Text string `json:"text" validate:"required,lte=1024"`
+}
+
+type InteractiveURLButtonAction struct {
+	DisplayText string `json:"displayText" validate:"required,lte=20"`
+	URL         string `json:"url" validate:"required,url,lte=2000"`
+}
+
+type InteractiveURLButtonHeader struct {
+	Type     string `json:"type" validate:"required,oneof=TEXT VIDEO IMAGE DOCUMENT"`
+	Text     string `json:"text,omitempty" validate:"lte=60"`
+	MediaURL string `json:"mediaUrl,omitempty" validate:"omitempty,url,lte=2048"`
+}
+
+type InteractiveURLButtonFooter struct {
+	Text string `json:"text" validate:"required,lte=60"`
+}
+
+const (
+	FlowModeDraft     = "DRAFT"
+	FlowModePublished = "PUBLISHED"
+
+	FlowActionNavigate     = "NAVIGATE"
+	FlowActionDataExchange = "DATA_EXCHANGE"
+)
+
+type WAInteractiveFlowMsg struct {
+	MsgCommon
+	Content InteractiveFlowContent `json:"content" validate:"required"`
+}
+
+func (t *WAInteractiveFlowMsg) Validate() error {
+	return validate.Struct(t)
+}
+
+func (t *WAInteractiveFlowMsg) Marshal() (*bytes.Buffer, error) {
+	return marshalJSON(t)
+}
+
+func flowMsgValidation(sl validator.StructLevel) {
+	msg, _ := sl.Current().Interface().(WAInteractiveFlowMsg)
+	if header := msg.Content.Header; header != nil {
+		validateInteractiveHeader(sl, header.Type, header.Text, header.MediaURL)
+	}
+	validateFlowAction(sl, msg.Content.Action)
+}
+
+// validateFlowAction checks the initial screen: NAVIGATE flows open the screen of the payload, while DATA_EXCHANGE
+// flows ask the flow endpoint for it, so they have no payload.
+func validateFlowAction(sl validator.StructLevel, action InteractiveFlowAction) {
+	switch action.FlowAction {
+	case FlowActionNavigate, "":
+		if action.FlowActionPayload == nil || action.FlowActionPayload.Screen == "" {
+			sl.ReportError(action.FlowActionPayload, "flowActionPayload", "FlowActionPayload", "missingscreen", "")
+		}
+	case FlowActionDataExchange:
+		if action.FlowActionPayload != nil {
+			sl.ReportError(
+				action.FlowActionPayload,
+				"flowActionPayload",
+				"FlowActionPayload",
+				"payloadwithdataexchange",
+				"",
+			)
+		}
+	}
+}
+
+type InteractiveFlowContent struct {
+	Body   InteractiveFlowBody    `json:"body" validate:"required"`
+	Action InteractiveFlowAction  `json:"action" validate:"required"`
+	Header *InteractiveFlowHeader `json:"header,omitempty" validate:"omitempty"`
+	Footer *InteractiveFlowFooter `json:"footer,omitempty"`
+}
+
+type InteractiveFlowBody struct {
+	Text string `json:"text" validate:"required,lte=1024"`
+}
+
+// InteractiveFlowAction opens the flow with the ID. FlowToken identifies the conversation and is sent back in the
+// flow reply. FlowAction defaults to NAVIGATE, and Mode to PUBLISHED.
+type InteractiveFlowAction struct {
+	Mode               string                  `json:"mode,omitempty" validate:"omitempty,oneof=DRAFT PUBLISHED"`
+	FlowMessageVersion int                     `json:"flowMessageVersion,omitempty" validate:"omitempty,eq=3"`
+	FlowToken          string                  `json:"flowToken,omitempty" validate:"lte=200"`
+	FlowID             string                  `json:"flowId" validate:"required,numeric"`
+	CallToActionButton string                  `json:"callToActionButton" validate:"required,lte=20"`
+	FlowAction         string                  `json:"flowAction,omitempty" validate:"omitempty,oneof=NAVIGATE DATA_EXCHANGE"` //nolint:lll
+	FlowActionPayload  *InteractiveFlowPayload `json:"flowActionPayload,omitempty"`
+}
+
+// InteractiveFlowPayload is the initial screen of a NAVIGATE flow and the data passed to it.
+type InteractiveFlowPayload struct {
+	Screen string                 `json:"screen" validate:"required"`
+	Data   map[string]interface{} `json:"data,omitempty"`
+}
+
+type InteractiveFlowHeader struct {
+	Type     string `json:"type" validate:"required,oneof=TEXT VIDEO IMAGE DOCUMENT"`
+	Text     string `json:"text,omitempty" validate:"lte=60"`
+	MediaURL string `json:"mediaUrl,omitempty" validate:"omitempty,url,lte=2048"`
+}
+
+type InteractiveFlowFooter struct {
+	Text string `json:"text" validate:"required,lte=60"`
+}
+
+type GetWASendersParams struct {
+	Page int `validate:"omitempty,min=0"`
+	Size int `validate:"omitempty,min=1,max=100"`
+}
+
+func (p *GetWASendersParams) Validate() error {
+	return validate.Struct(p)
+}
+
+type GetWASendersResponse struct {
+	Senders []WASender `json:"senders"`
+	Paging  struct {
+		Page         int `json:"page"`
+		Size         int `json:"size"`
+		TotalPages   int `json:"totalPages"`
+		TotalResults int `json:"totalResults"`
+	} `json:"paging"`
+}
+
+type WASender struct {
+	Sender        string `json:"sender"`
+	DisplayName   string `json:"displayName"`
+	Status        string `json:"status"`
+	QualityRating string `json:"qualityRating"`
+	CurrentLimit  string `json:"currentLimit"`
+}
+
+// Quality ratings of a sender, given by Meta from the feedback of users in the last days.
+const (
+	WAQualityGreen   = "GREEN"
+	WAQualityYellow  = "YELLOW"
+	WAQualityRed     = "RED"
+	WAQualityUnknown = "UNKNOWN"
+)
+
+// Messaging limit tiers of a sender: the number of users it can start conversations with in 24 hours.
+const (
+	WALimitTier50        = "TIER_50"
+	WALimitTier250       = "TIER_250"
+	WALimitTier1K        = "TIER_1K"
+	WALimitTier10K       = "TIER_10K"
+	WALimitTier100K      = "TIER_100K"
+	WALimitTierUnlimited = "TIER_UNLIMITED"
+)
+
+type GetWASenderQualityResponse struct {
+	Results []WASenderQuality `json:"results"`
+}
+
+// WASenderQuality is the quality rating and messaging limit tier of a sender. Status is CONNECTED, FLAGGED when the
+// quality rating dropped to RED, or RESTRICTED when the sender reached its limit.
+type WASenderQuality struct {
+	Sender        string `json:"sender"`
+	QualityRating string `json:"qualityRating"`
+	Status        string `json:"status"`
+	CurrentLimit  string `json:"currentLimit"`
+	LastUpdated   string `json:"lastUpdated,omitempty"`
+}
+
+// WABusinessProfile is the business profile shown to users in the details of a sender. When updating, only the set
+// fields are changed.
+type WABusinessProfile struct {
+	About       string   `json:"about,omitempty" validate:"lte=139"`
+	Address     string   `json:"address,omitempty" validate:"lte=256"`
+	Description string   `json:"description,omitempty" validate:"lte=512"`
+	Email       string   `json:"email,omitempty" validate:"omitempty,email,lte=128"`
+	Websites    []string `json:"websites,omitempty" validate:"omitempty,max=2,dive,url,lte=256"`
+	Vertical    string   `json:"vertical,omitempty" validate:"omitempty,oneof=OTHER AUTOMOTIVE BEAUTY_SPA_AND_SALON CLOTHING_AND_APPAREL EDUCATION ENTERTAINMENT EVENT_PLANNING_AND_SERVICE FINANCE_AND_BANKING FOOD_AND_GROCERY PUBLIC_SERVICE HOTEL_AND_LODGING MEDICAL_AND_HEALTH NON_PROFIT PROFESSIONAL_SERVICES SHOPPING_AND_RETAIL TRAVEL_AND_TRANSPORTATION RESTAURANT"` //nolint:lll
+	// LogoURL is the profile photo, a square image of at least 192x192 pixels.
+	LogoURL string `json:"logoUrl,omitempty" validate:"omitempty,url,lte=2048"`
+}
+
+func (p *WABusinessProfile) Validate() error {
+	return validate.Struct(p)
+}
+
+func (p *WABusinessProfile) Marshal() (*bytes.Buffer, error) {
+	return marshalJSON(p)
+}
+
+// WAIdentity is the identity of a user, which changes when they reinstall WhatsApp or move to another phone.
+type WAIdentity struct {
+	Hash      string `json:"hash"`
+	CreatedAt string `json:"createdAt,omitempty"`
+}
+
+// WAIdentityConfirmation confirms the identity of a user after a change, by the hash of the new identity.
+type WAIdentityConfirmation struct {
+	Hash string `json:"hash" validate:"required"`
+}
+
+func (c *WAIdentityConfirmation) Validate() error {
+	return validate.Struct(c)
+}
+
+func (c *WAIdentityConfirmation) Marshal() (*bytes.Buffer, error) {
+	return marshalJSON(c)
+}
+
+// WAInboundMessages is the payload posted to the webhook receiving inbound WhatsApp messages.
+type WAInboundMessages struct {
+	Results             []WAInboundMessage `json:"results"`
+	MessageCount        int32              `json:"messageCount"`
+	PendingMessageCount int32              `json:"pendingMessageCount"`
+}
+
+type WAInboundMessage struct {
+	From            string           `json:"from"`
+	To              string           `json:"to"`
+	IntegrationType string           `json:"integrationType"`
+	ReceivedAt      string           `json:"receivedAt"`
+	MessageID       string           `json:"messageId"`
+	PairedMessageID string           `json:"pairedMessageId,omitempty"`
+	CallbackData    string           `json:"callbackData,omitempty"`
+	Message         WAInboundContent `json:"message"`
+	Contact         WAInboundContact `json:"contact"`
+}
+
+// InboundFlowReplyType is the type of the inbound message sent when the user completes a flow.
+const InboundFlowReplyType = "INTERACTIVE_FLOW_REPLY"
+
+type WAInboundContent struct {
+	Type    string `json:"type"`
+	Text    string `json:"text,omitempty"`
+	Caption string `json:"caption,omitempty"`
+	URL     string `json:"url,omitempty"`
+	// Response is set for INTERACTIVE_FLOW_REPLY messages, see FlowReply.
+	Response string `json:"response,omitempty"`
+}
+
+// ErrNotFlowReply is returned by FlowReply for inbound messages which are not flow replies.
+var ErrNotFlowReply = errors.New("inbound message is not a flow reply")
+
+// FlowReply parses the response of a completed flow, a JSON object holding the flow token and the fields submitted
+// by the last screen.
+func (c WAInboundContent) FlowReply() (WAFlowReply, error) {
+	if c.Type != InboundFlowReplyType {
+		return WAFlowReply{}, ErrNotFlowReply
+	}
+	reply := WAFlowReply{Data: json.RawMessage(c.Response)}
+	var token struct {
+		FlowToken string `json:"flow_token"`
+	}
+	if err := json.Unmarshal(reply.Data, &token); err != nil {
+		return WAFlowReply{}, err
+	}
+	reply.FlowToken = token.FlowToken
+	return reply, nil
+}
+
+// WAFlowReply is the response of a completed flow.
+type WAFlowReply struct {
+	// FlowToken is the token of the flow message, identifying the conversation.
+	FlowToken string
+	// Data is the response as received, including the flow token.
+	Data json.RawMessage
+}
+
+// Decode unmarshals the response into v, usually a struct with a field per input of the last screen of the flow.
+func (r WAFlowReply) Decode(v interface{}) error {
+	return json.Unmarshal(r.Data, v)
+}
+
+type WAInboundContact struct {
+	Name string `json:"name"`
+}
@@ -0,0 +1,83 @@
+package models
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidWABusinessProfile(t *testing.T) {
+	tests := []struct {
+		name     string
+		instance WABusinessProfile
+	}{
+		{
+			name:     "single field",
+			instance: WABusinessProfile{About: "Open 9 to 5"},
+		},
+		{
+			name: "complete input",
+			instance: WABusinessProfile{
+				About:       "Open 9 to 5",
+				Address:     "Zagrebacka 80, Vodnjan",
+				Description: "Global cloud communications platform",
+				Email:       "support@example.com",
+				Websites:    []string{"https://www.example.com", "https://www.example.org"},
+				Vertical:    "PROFESSIONAL_SERVICES",
+				LogoURL:     "https://www.example.com/logo.png",
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.instance.Validate()
+			require.NoError(t, err)
+			marshalled, err := tc.instance.Marshal()
+			require.NoError(t, err)
+			require.NotEmpty(t, marshalled)
+		})
+	}
+}
+
+func TestInvalidWABusinessProfile(t *testing.T) {
+	tests := []struct {
+		name     string
+		instance WABusinessProfile
+	}{
+		{
+			name:     "About too long",
+			instance: WABusinessProfile{About: strings.Repeat("a", 140)},
+		},
+		{
+			name:     "invalid Email",
+			instance: WABusinessProfile{Email: "support"},
+		},
+		{
+			name: "too many Websites",
+			instance: WABusinessProfile{
+				Websites: []string{"https://a.example.com", "https://b.example.com", "https://c.example.com"},
+			},
+		},
+		{
+			name:     "invalid Website",
+			instance: WABusinessProfile{Websites: []string{"example"}},
+		},
+		{
+			name:     "unknown Vertical",
+			instance: WABusinessProfile{Vertical: "SPACE_TRAVEL"},
+		},
+		{
+			name:     "invalid LogoURL",
+			instance: WABusinessProfile{LogoURL: "logo.png"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.instance.Validate()
+			require.NotNil(t, err)
+		})
+	}
+}
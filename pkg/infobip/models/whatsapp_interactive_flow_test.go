@@ -0,0 +1,164 @@
+package models
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidInteractiveFlowMessage(t *testing.T) {
+	tests := []struct {
+		name     string
+		instance WAInteractiveFlowMsg
+	}{
+		{
+			name: "navigate",
+			instance: WAInteractiveFlowMsg{
+				MsgCommon: GenerateTestMsgCommon(),
+				Content: InteractiveFlowContent{
+					Body: InteractiveFlowBody{Text: "Book your appointment"},
+					Action: InteractiveFlowAction{
+						FlowID:             "1234567890",
+						CallToActionButton: "Book",
+						FlowActionPayload:  &InteractiveFlowPayload{Screen: "APPOINTMENT"},
+					},
+				},
+			},
+		},
+		{
+			name: "data exchange",
+			instance: WAInteractiveFlowMsg{
+				MsgCommon: GenerateTestMsgCommon(),
+				Content: InteractiveFlowContent{
+					Body: InteractiveFlowBody{Text: "Book your appointment"},
+					Action: InteractiveFlowAction{
+						Mode:               FlowModeDraft,
+						FlowMessageVersion: 3,
+						FlowToken:          "appointment-42",
+						FlowID:             "1234567890",
+						CallToActionButton: "Book",
+						FlowAction:         FlowActionDataExchange,
+					},
+					Header: &InteractiveFlowHeader{Type: "TEXT", Text: "Appointments"},
+					Footer: &InteractiveFlowFooter{Text: "Takes a minute"},
+				},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.instance.Validate()
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestInteractiveFlowMessageConstraints(t *testing.T) {
+	body := InteractiveFlowBody{Text: "Book your appointment"}
+	payload := &InteractiveFlowPayload{Screen: "APPOINTMENT"}
+	tests := []struct {
+		name    string
+		content InteractiveFlowContent
+	}{
+		{
+			name: "missing flow ID",
+			content: InteractiveFlowContent{
+				Body:   body,
+				Action: InteractiveFlowAction{CallToActionButton: "Book", FlowActionPayload: payload},
+			},
+		},
+		{
+			name: "non-numeric flow ID",
+			content: InteractiveFlowContent{
+				Body:   body,
+				Action: InteractiveFlowAction{FlowID: "flow", CallToActionButton: "Book", FlowActionPayload: payload},
+			},
+		},
+		{
+			name: "call to action too long",
+			content: InteractiveFlowContent{
+				Body: body,
+				Action: InteractiveFlowAction{
+					FlowID: "1234567890", CallToActionButton: strings.Repeat("a", 21), FlowActionPayload: payload,
+				},
+			},
+		},
+		{
+			name: "invalid mode",
+			content: InteractiveFlowContent{
+				Body: body,
+				Action: InteractiveFlowAction{
+					Mode: "TEST", FlowID: "1234567890", CallToActionButton: "Book", FlowActionPayload: payload,
+				},
+			},
+		},
+		{
+			name: "navigate without screen",
+			content: InteractiveFlowContent{
+				Body:   body,
+				Action: InteractiveFlowAction{FlowID: "1234567890", CallToActionButton: "Book"},
+			},
+		},
+		{
+			name: "data exchange with payload",
+			content: InteractiveFlowContent{
+				Body: body,
+				Action: InteractiveFlowAction{
+					FlowID:             "1234567890",
+					CallToActionButton: "Book",
+					FlowAction:         FlowActionDataExchange,
+					FlowActionPayload:  payload,
+				},
+			},
+		},
+		{
+			name: "media header without media URL",
+			content: InteractiveFlowContent{
+				Body: body,
+				Action: InteractiveFlowAction{
+					FlowID: "1234567890", CallToActionButton: "Book", FlowActionPayload: payload,
+				},
+				Header: &InteractiveFlowHeader{Type: "IMAGE"},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			msg := WAInteractiveFlowMsg{
+				MsgCommon: GenerateTestMsgCommon(),
+				Content:   tc.content,
+			}
+			err := msg.Validate()
+			require.NotNil(t, err)
+		})
+	}
+}
+
+func TestInboundFlowReply(t *testing.T) {
+	content := WAInboundContent{
+		Type:     InboundFlowReplyType,
+		Response: `{"flow_token": "appointment-42", "date": "2022-03-01", "guests": 2}`,
+	}
+
+	reply, err := content.FlowReply()
+	require.NoError(t, err)
+	assert.Equal(t, "appointment-42", reply.FlowToken)
+
+	var appointment struct {
+		Date   string `json:"date"`
+		Guests int    `json:"guests"`
+	}
+	require.NoError(t, reply.Decode(&appointment))
+	assert.Equal(t, "2022-03-01", appointment.Date)
+	assert.Equal(t, 2, appointment.Guests)
+
+	_, err = WAInboundContent{Type: "TEXT", Text: "Hi"}.FlowReply()
+	assert.ErrorIs(t, err, ErrNotFlowReply)
+
+	_, err = WAInboundContent{Type: InboundFlowReplyType, Response: "not json"}.FlowReply()
+	assert.Error(t, err)
+}
@@ -0,0 +1,49 @@
+package models
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidInteractiveLocationRequestMessage(t *testing.T) {
+	msg := WAInteractiveLocationRequestMsg{
+		MsgCommon: GenerateTestMsgCommon(),
+		Content: InteractiveLocationRequestContent{
+			Body: InteractiveLocationRequestBody{Text: "Where should we deliver your order?"},
+		},
+	}
+
+	err := msg.Validate()
+	require.NoError(t, err)
+}
+
+func TestInteractiveLocationRequestMessageConstraints(t *testing.T) {
+	tests := []struct {
+		name    string
+		content InteractiveLocationRequestContent
+	}{
+		{
+			name:    "missing body text",
+			content: InteractiveLocationRequestContent{},
+		},
+		{
+			name: "body text too long",
+			content: InteractiveLocationRequestContent{
+				Body: InteractiveLocationRequestBody{Text: strings.Repeat("a", 1025)},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			msg := WAInteractiveLocationRequestMsg{
+				MsgCommon: GenerateTestMsgCommon(),
+				Content:   tc.content,
+			}
+			err := msg.Validate()
+			require.NotNil(t, err)
+		})
+	}
+}
@@ -0,0 +1,104 @@
+package models
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidInteractiveURLButtonMessage(t *testing.T) {
+	tests := []struct {
+		name     string
+		instance WAInteractiveURLButtonMsg
+	}{
+		{
+			name: "minimum input",
+			instance: WAInteractiveURLButtonMsg{
+				MsgCommon: GenerateTestMsgCommon(),
+				Content: InteractiveURLButtonContent{
+					Body:   InteractiveURLButtonBody{Text: "Track your order"},
+					Action: InteractiveURLButtonAction{DisplayText: "Track", URL: "https://www.example.com/track"},
+				},
+			},
+		},
+		{
+			name: "complete input",
+			instance: WAInteractiveURLButtonMsg{
+				MsgCommon: GenerateTestMsgCommon(),
+				Content: InteractiveURLButtonContent{
+					Body:   InteractiveURLButtonBody{Text: "Track your order"},
+					Action: InteractiveURLButtonAction{DisplayText: "Track", URL: "https://www.example.com/track"},
+					Header: &InteractiveURLButtonHeader{Type: "IMAGE", MediaURL: "https://www.example.com/box.png"},
+					Footer: &InteractiveURLButtonFooter{Text: "Thanks for your order"},
+				},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.instance.Validate()
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestInteractiveURLButtonMessageConstraints(t *testing.T) {
+	body := InteractiveURLButtonBody{Text: "Track your order"}
+	action := InteractiveURLButtonAction{DisplayText: "Track", URL: "https://www.example.com/track"}
+	tests := []struct {
+		name    string
+		content InteractiveURLButtonContent
+	}{
+		{
+			name:    "missing body",
+			content: InteractiveURLButtonContent{Action: action},
+		},
+		{
+			name:    "missing action",
+			content: InteractiveURLButtonContent{Body: body},
+		},
+		{
+			name: "display text too long",
+			content: InteractiveURLButtonContent{
+				Body:   body,
+				Action: InteractiveURLButtonAction{DisplayText: strings.Repeat("a", 21), URL: action.URL},
+			},
+		},
+		{
+			name: "URL not a web page",
+			content: InteractiveURLButtonContent{
+				Body:   body,
+				Action: InteractiveURLButtonAction{DisplayText: "Call", URL: "tel:+441134960000"},
+			},
+		},
+		{
+			name: "text header without text",
+			content: InteractiveURLButtonContent{
+				Body:   body,
+				Action: action,
+				Header: &InteractiveURLButtonHeader{Type: "TEXT"},
+			},
+		},
+		{
+			name: "media header without media URL",
+			content: InteractiveURLButtonContent{
+				Body:   body,
+				Action: action,
+				Header: &InteractiveURLButtonHeader{Type: "VIDEO"},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			msg := WAInteractiveURLButtonMsg{
+				MsgCommon: GenerateTestMsgCommon(),
+				Content:   tc.content,
+			}
+			err := msg.Validate()
+			require.NotNil(t, err)
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package models
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidReactionMessage(t *testing.T) {
+	tests := []struct {
+		name     string
+		instance WAReactionMsg
+	}{
+		{
+			name: "simple emoji",
+			instance: WAReactionMsg{
+				MsgCommon: GenerateTestMsgCommon(),
+				Content:   ReactionContent{MessageID: "a28dd97c-1ffb-4fcf-99f1-0b557ed381da", Reaction: "👍"},
+			},
+		},
+		{
+			name: "emoji with skin tone",
+			instance: WAReactionMsg{
+				MsgCommon: GenerateTestMsgCommon(),
+				Content:   ReactionContent{MessageID: "wamid.HBgLMTYxNzU1NTEyMTI=", Reaction: "👍🏽"},
+			},
+		},
+		{
+			name: "remove reaction",
+			instance: WAReactionMsg{
+				MsgCommon: GenerateTestMsgCommon(),
+				Content:   ReactionContent{MessageID: "a28dd97c-1ffb-4fcf-99f1-0b557ed381da"},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.instance.Validate()
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestReactionMessageConstraints(t *testing.T) {
+	msgCommon := GenerateTestMsgCommon()
+	tests := []struct {
+		name    string
+		content ReactionContent
+	}{
+		{
+			name:    "missing MessageID",
+			content: ReactionContent{Reaction: "👍"},
+		},
+		{
+			name:    "invalid MessageID",
+			content: ReactionContent{MessageID: "message id", Reaction: "👍"},
+		},
+		{
+			name:    "MessageID too long",
+			content: ReactionContent{MessageID: strings.Repeat("a", 201), Reaction: "👍"},
+		},
+		{
+			name:    "text reaction",
+			content: ReactionContent{MessageID: "a28dd97c-1ffb-4fcf-99f1-0b557ed381da", Reaction: "ok"},
+		},
+		{
+			name:    "two emoji",
+			content: ReactionContent{MessageID: "a28dd97c-1ffb-4fcf-99f1-0b557ed381da", Reaction: "👍👍"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			msg := WAReactionMsg{
+				MsgCommon: msgCommon,
+				Content:   tc.content,
+			}
+			err := msg.Validate()
+			require.NotNil(t, err)
+		})
+	}
+}
+
+func TestIsSingleEmoji(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected bool
+	}{
+		{text: "😀", expected: true},
+		{text: "❤️", expected: true},
+		{text: "👩‍👩‍👧‍👦", expected: true},
+		{text: "🇭🇷", expected: true},
+		{text: "1️⃣", expected: true},
+		{text: "#⃣", expected: true},
+		{text: "🏴󠁧󠁢󠁳󠁣󠁴󠁿", expected: true},
+		{text: "", expected: false},
+		{text: "a", expected: false},
+		{text: "1", expected: false},
+		{text: "🇭", expected: false},
+		{text: "😀😀", expected: false},
+		{text: "😀‍", expected: false},
+		{text: "🏽", expected: false},
+		{text: "😀 ", expected: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.text, func(t *testing.T) {
+			assert.Equal(t, tc.expected, IsSingleEmoji(tc.text))
+		})
+	}
+}
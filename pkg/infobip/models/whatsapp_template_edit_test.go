@@ -0,0 +1,81 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidTemplateEdit(t *testing.T) {
+	tests := []struct {
+		name     string
+		instance TemplateEdit
+	}{
+		{
+			name: "structure only",
+			instance: TemplateEdit{
+				Structure: TemplateStructure{Body: &TemplateStructureBody{Text: "body {{1}} content"}, Type: "TEXT"},
+			},
+		},
+		{
+			name: "category and buttons",
+			instance: TemplateEdit{
+				Category: "UTILITY",
+				Structure: TemplateStructure{
+					Body: &TemplateStructureBody{Text: "body {{1}} content"},
+					Buttons: []TemplateButton{
+						{Type: "URL", Text: "Open", URL: "https://example.com"},
+						{Type: "PHONE_NUMBER", Text: "Call", PhoneNumber: "41793026727"},
+					},
+					Type: "TEXT",
+				},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.instance.Validate()
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestInvalidTemplateEdit(t *testing.T) {
+	tests := []struct {
+		name     string
+		instance TemplateEdit
+	}{
+		{
+			name:     "missing body",
+			instance: TemplateEdit{Structure: TemplateStructure{Type: "TEXT"}},
+		},
+		{
+			name: "invalid category",
+			instance: TemplateEdit{
+				Category:  "SHIPPING",
+				Structure: TemplateStructure{Body: &TemplateStructureBody{Text: "body"}, Type: "TEXT"},
+			},
+		},
+		{
+			name: "quick reply mixed with url",
+			instance: TemplateEdit{
+				Structure: TemplateStructure{
+					Body: &TemplateStructureBody{Text: "body"},
+					Buttons: []TemplateButton{
+						{Type: "QUICK_REPLY", Text: "Yes"},
+						{Type: "URL", Text: "Open", URL: "https://example.com"},
+					},
+					Type: "TEXT",
+				},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.instance.Validate()
+			require.Error(t, err)
+		})
+	}
+}
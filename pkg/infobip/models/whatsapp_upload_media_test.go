@@ -0,0 +1,138 @@
+package models
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidWAUploadMediaRequest(t *testing.T) {
+	tests := []struct {
+		name     string
+		instance WAUploadMediaRequest
+	}{
+		{
+			name: "image",
+			instance: WAUploadMediaRequest{
+				MediaType: WAMediaImage, ContentType: "image/jpeg", Filename: "photo.jpg", Media: strings.NewReader("x"),
+			},
+		},
+		{
+			name: "audio with codecs parameter",
+			instance: WAUploadMediaRequest{
+				MediaType: WAMediaAudio, ContentType: "audio/ogg; codecs=opus", Filename: "note.ogg",
+				Media: strings.NewReader("x"),
+			},
+		},
+		{
+			name: "document with known size",
+			instance: WAUploadMediaRequest{
+				MediaType: WAMediaDocument, ContentType: "application/pdf", Filename: "invoice.pdf",
+				Size: 50 * megabyte, Media: strings.NewReader("x"),
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.instance.Validate()
+			require.NoError(t, err)
+
+			marshalled, err := tc.instance.Marshal()
+			require.NoError(t, err)
+			assert.NotEmpty(t, marshalled)
+		})
+	}
+}
+
+func TestInvalidWAUploadMediaRequest(t *testing.T) {
+	tests := []struct {
+		name     string
+		instance WAUploadMediaRequest
+	}{
+		{
+			name:     "missing media",
+			instance: WAUploadMediaRequest{MediaType: WAMediaImage, ContentType: "image/png", Filename: "a.png"},
+		},
+		{
+			name: "unknown media type",
+			instance: WAUploadMediaRequest{
+				MediaType: "GIF", ContentType: "image/gif", Filename: "a.gif", Media: strings.NewReader("x"),
+			},
+		},
+		{
+			name: "unsupported content type",
+			instance: WAUploadMediaRequest{
+				MediaType: WAMediaVideo, ContentType: "video/quicktime", Filename: "a.mov", Media: strings.NewReader("x"),
+			},
+		},
+		{
+			name: "declared size too large",
+			instance: WAUploadMediaRequest{
+				MediaType: WAMediaImage, ContentType: "image/png", Filename: "a.png",
+				Size: 6 * megabyte, Media: strings.NewReader("x"),
+			},
+		},
+		{
+			name: "missing filename",
+			instance: WAUploadMediaRequest{
+				MediaType: WAMediaImage, ContentType: "image/png", Media: strings.NewReader("x"),
+			},
+		},
+		{
+			name: "seekable media too large",
+			instance: WAUploadMediaRequest{
+				MediaType: WAMediaSticker, ContentType: "image/webp", Filename: "a.webp",
+				Media: strings.NewReader(strings.Repeat("x", 600*1024)),
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.instance.Validate()
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestWAUploadMediaRequestMarshalTwice(t *testing.T) {
+	seekable := WAUploadMediaRequest{
+		MediaType: WAMediaImage, ContentType: "image/png", Filename: "a.png", Media: strings.NewReader("png bytes"),
+	}
+	first, err := seekable.Marshal()
+	require.NoError(t, err)
+	second, err := seekable.Marshal()
+	require.NoError(t, err)
+	assert.Equal(t, strings.ReplaceAll(first.String(), boundaryOf(first.String()), boundaryOf(second.String())),
+		second.String())
+	assert.Contains(t, second.String(), "png bytes")
+
+	streamed := WAUploadMediaRequest{
+		MediaType: WAMediaImage, ContentType: "image/png", Filename: "a.png",
+		Media: io.MultiReader(strings.NewReader("png bytes")),
+	}
+	_, err = streamed.Marshal()
+	require.NoError(t, err)
+	_, err = streamed.Marshal()
+	assert.ErrorIs(t, err, ErrMediaNotRewindable)
+}
+
+// boundaryOf returns the boundary of a buffered multipart body, from its first delimiter line.
+func boundaryOf(body string) string {
+	return strings.TrimPrefix(strings.SplitN(body, "\r\n", 2)[0], "--")
+}
+
+func TestWAMediaLimitFor(t *testing.T) {
+	limit, ok := WAMediaLimitFor(WAMediaImage)
+	require.True(t, ok)
+	assert.True(t, limit.Allows("image/png"))
+	assert.False(t, limit.Allows("image/webp"))
+	assert.False(t, limit.Allows("not a content type;;"))
+
+	_, ok = WAMediaLimitFor("GIF")
+	assert.False(t, ok)
+}
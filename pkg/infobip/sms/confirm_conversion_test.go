@@ -0,0 +1,53 @@
+package sms
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/infobip-community/infobip-api-go-sdk/v3/internal"
+	"github.com/infobip-community/infobip-api-go-sdk/v3/pkg/infobip/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfirmConversionValidReq(t *testing.T) {
+	apiKey := "some-key"
+	messageID := "MESSAGE-ID-123-xyz"
+	rawJSONResp := []byte(`
+		{
+		  "messageId": "MESSAGE-ID-123-xyz",
+		  "processKey": "somekey"
+		}
+	`)
+
+	var expectedResp models.ConfirmSMSConversionResponse
+	err := json.Unmarshal(rawJSONResp, &expectedResp)
+	require.NoError(t, err)
+
+	serv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, fmt.Sprint("/", confirmConversionPath, "/", messageID), r.URL.Path)
+		assert.Equal(t, fmt.Sprintf("App %s", apiKey), r.Header.Get("Authorization"))
+
+		_, servErr := w.Write(rawJSONResp)
+		assert.Nil(t, servErr)
+	}))
+	defer serv.Close()
+	var sms Conversions = &Channel{ReqHandler: internal.HTTPHandler{
+		HTTPClient: http.Client{},
+		BaseURL:    serv.URL,
+		APIKey:     apiKey,
+	}}
+
+	msgResp, respDetails, err := sms.ConfirmConversion(context.Background(), messageID)
+
+	require.NoError(t, err)
+	assert.Equal(t, expectedResp, msgResp)
+	assert.NotNil(t, respDetails)
+	assert.Equal(t, http.StatusOK, respDetails.HTTPResponse.StatusCode)
+	assert.Equal(t, models.ErrorDetails{}, respDetails.ErrorResponse)
+}
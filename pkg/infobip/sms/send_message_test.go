@@ -83,3 +83,29 @@ func TestSendMessageValidReq(t *testing.T) {
 	assert.Equal(t, http.StatusOK, respDetails.HTTPResponse.StatusCode)
 	assert.Equal(t, models.ErrorDetails{}, respDetails.ErrorResponse)
 }
+
+func TestSendMessageRegionalRules(t *testing.T) {
+	request := models.GenerateSendSMSRequest()
+	request.Messages[0].Destinations = []models.SMSDestination{{To: "905321234567"}}
+	requests := 0
+	serv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		_, servErr := w.Write([]byte(`{"bulkId": "some-bulk-id"}`))
+		assert.Nil(t, servErr)
+	}))
+	defer serv.Close()
+	sms := Channel{ReqHandler: internal.HTTPHandler{
+		HTTPClient: http.Client{},
+		BaseURL:    serv.URL,
+		APIKey:     "some-key",
+	}}
+
+	_, _, err := sms.Send(context.Background(), request)
+	require.NoError(t, err)
+
+	sms.RegionalRules = models.SMSRegionalRules{TurkeyIYS: true}
+	_, _, err = sms.Send(context.Background(), request)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "missingturkeyiys")
+	assert.Equal(t, 1, requests)
+}
@@ -0,0 +1,67 @@
+package sms
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/infobip-community/infobip-api-go-sdk/v3/internal"
+	"github.com/infobip-community/infobip-api-go-sdk/v3/pkg/infobip/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSendPINOverEmailValidReq(t *testing.T) {
+	apiKey := "some-key"
+	request := models.GenerateSendPINOverEmailRequest()
+	rawJSONResp := []byte(`
+		{
+		  "pinId": "9C817C6F8AF3D48F9FE553282AFA2B67",
+		  "to": "john.smith@example.com",
+		  "emailStatus": {
+		    "name": "PENDING_ACCEPTED",
+		    "description": "Message accepted, pending for delivery."
+		  }
+		}
+	`)
+
+	var expectedResp models.SendPINOverEmailResponse
+	err := json.Unmarshal(rawJSONResp, &expectedResp)
+	require.NoError(t, err)
+
+	serv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.True(t, strings.HasSuffix(r.URL.Path, sendPINOverEmailPath))
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, fmt.Sprintf("App %s", apiKey), r.Header.Get("Authorization"))
+		parsedBody, servErr := io.ReadAll(r.Body)
+		assert.Nil(t, servErr)
+
+		var receivedReq models.SendPINOverEmailRequest
+		servErr = json.Unmarshal(parsedBody, &receivedReq)
+		assert.Nil(t, servErr)
+		assert.Equal(t, receivedReq, request)
+
+		_, servErr = w.Write(rawJSONResp)
+		assert.Nil(t, servErr)
+	}))
+	defer serv.Close()
+	sms := Channel{ReqHandler: internal.HTTPHandler{
+		HTTPClient: http.Client{},
+		BaseURL:    serv.URL,
+		APIKey:     apiKey,
+	}}
+
+	msgResp, respDetails, err := sms.SendPINOverEmail(context.Background(), request)
+
+	require.NoError(t, err)
+	assert.NotEqual(t, models.SendPINOverEmailResponse{}, msgResp)
+	assert.Equal(t, expectedResp, msgResp)
+	assert.NotNil(t, respDetails)
+	assert.Equal(t, http.StatusOK, respDetails.HTTPResponse.StatusCode)
+	assert.Equal(t, models.ErrorDetails{}, respDetails.ErrorResponse)
+}
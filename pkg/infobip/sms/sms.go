@@ -20,6 +20,7 @@ const (
 	rescheduleSMSPath            = "sms/1/bulks"
 	getScheduledSMSStatusPath    = "sms/1/bulks/status"
 	updateScheduledSMSStatusPath = "sms/1/bulks/status"
+	confirmConversionPath        = "ct/1/log/end"
 	getTFAApplicationsPath       = "2fa/2/applications"
 	createTFAApplicationPath     = "2fa/2/applications"
 	getTFAApplicationPath        = "2fa/2/applications"
@@ -34,6 +35,10 @@ const (
 	resendPINOverVoicePath       = "2fa/2/pin"
 	verifyPhoneNumberPath        = "2fa/2/pin"
 	getTFAVerificationStatusPath = "2fa/2/applications"
+	createTFAEmailTemplatePath   = "2fa/2/applications"
+	updateTFAEmailTemplatePath   = "2fa/2/applications"
+	sendPINOverEmailPath         = "2fa/2/pin/email"
+	resendPINOverEmailPath       = "2fa/2/pin"
 )
 
 type SMS interface {
@@ -190,14 +195,64 @@ type SMS interface {
 	) (resp models.GetTFAVerificationStatusResponse, respDetails models.ResponseDetails, err error)
 }
 
+// Conversions confirms the conversions of messages sent with conversion tracking. Channel implements it; it is not
+// part of SMS, so that other implementations of SMS don't need it. Use e.g. client.SMS.(sms.Conversions).
+type Conversions interface {
+	// ConfirmConversion reports a completed conversion for a message sent with conversion tracking, e.g. when the
+	// recipient used the PIN or followed the link from the message.
+	ConfirmConversion(
+		ctx context.Context,
+		messageID string,
+	) (resp models.ConfirmSMSConversionResponse, respDetails models.ResponseDetails, err error)
+}
+
+// TFAEmail sends 2FA PIN codes over email. Channel implements it along with SMS; it is a separate interface so that
+// existing implementations of SMS keep satisfying it. Assert it from the client, e.g. client.SMS.(sms.TFAEmail).
+type TFAEmail interface {
+	// CreateTFAEmailMessageTemplate creates an email message template where your PIN will be dynamically
+	// included when you send the PIN over email.
+	CreateTFAEmailMessageTemplate(
+		ctx context.Context,
+		appID string,
+		req models.CreateTFAEmailMessageTemplateRequest,
+	) (resp models.CreateTFAEmailMessageTemplateResponse, respDetails models.ResponseDetails, err error)
+
+	// UpdateTFAEmailMessageTemplate changes configuration options for your existing 2FA email message template.
+	UpdateTFAEmailMessageTemplate(
+		ctx context.Context,
+		appID string,
+		messageID string,
+		req models.UpdateTFAEmailMessageTemplateRequest,
+	) (resp models.UpdateTFAEmailMessageTemplateResponse, respDetails models.ResponseDetails, err error)
+
+	// SendPINOverEmail sends a PIN code over email using a previously created email message template.
+	SendPINOverEmail(
+		ctx context.Context,
+		req models.SendPINOverEmailRequest,
+	) (resp models.SendPINOverEmailResponse, respDetails models.ResponseDetails, err error)
+
+	// ResendPINOverEmail resends the same (previously sent) PIN code over email.
+	ResendPINOverEmail(
+		ctx context.Context,
+		pinID string,
+		req models.ResendPINOverEmailRequest,
+	) (resp models.ResendPINOverEmailResponse, respDetails models.ResponseDetails, err error)
+}
+
+// Channel sends SMS over the API. RegionalRules selects the regional parameters required by destination country
+// before messages are sent; none are required by default.
 type Channel struct {
-	ReqHandler internal.HTTPHandler
+	ReqHandler    internal.HTTPHandler
+	RegionalRules models.SMSRegionalRules
 }
 
 func (sms *Channel) Send(
 	ctx context.Context,
 	req models.SendSMSRequest,
 ) (resp models.SendSMSResponse, respDetails models.ResponseDetails, err error) {
+	if err = req.ValidateRegional(sms.RegionalRules); err != nil {
+		return resp, respDetails, err
+	}
 	respDetails, err = sms.ReqHandler.PostJSONReq(ctx, &req, &resp, sendSMSPath)
 	return resp, respDetails, err
 }
@@ -206,6 +261,9 @@ func (sms *Channel) SendBinary(
 	ctx context.Context,
 	req models.SendBinarySMSRequest,
 ) (resp models.SendBinarySMSResponse, respDetails models.ResponseDetails, err error) {
+	if err = req.ValidateRegional(sms.RegionalRules); err != nil {
+		return resp, respDetails, err
+	}
 	respDetails, err = sms.ReqHandler.PostJSONReq(ctx, &req, &resp, sendBinarySMSPath)
 	return resp, respDetails, err
 }
@@ -365,6 +423,14 @@ func (sms *Channel) UpdateScheduledMessagesStatus(
 	return resp, respDetails, err
 }
 
+func (sms *Channel) ConfirmConversion(
+	ctx context.Context,
+	messageID string,
+) (resp models.ConfirmSMSConversionResponse, respDetails models.ResponseDetails, err error) {
+	respDetails, err = sms.ReqHandler.PostNoBodyReq(ctx, &resp, fmt.Sprint(confirmConversionPath, "/", messageID))
+	return resp, respDetails, err
+}
+
 func (sms *Channel) GetTFAApplications(
 	ctx context.Context,
 ) (resp models.GetTFAApplicationsResponse, respDetails models.ResponseDetails, err error) {
@@ -524,3 +590,50 @@ func (sms *Channel) GetTFAVerificationStatus(
 
 	return resp, respDetails, err
 }
+
+func (sms *Channel) CreateTFAEmailMessageTemplate(
+	ctx context.Context,
+	appID string,
+	req models.CreateTFAEmailMessageTemplateRequest,
+) (resp models.CreateTFAEmailMessageTemplateResponse, respDetails models.ResponseDetails, err error) {
+	respDetails, err = sms.ReqHandler.PostJSONReq(
+		ctx, &req, &resp, createTFAEmailTemplatePath+"/"+appID+"/email/messages")
+
+	return resp, respDetails, err
+}
+
+func (sms *Channel) UpdateTFAEmailMessageTemplate(
+	ctx context.Context,
+	appID string,
+	messageID string,
+	req models.UpdateTFAEmailMessageTemplateRequest,
+) (resp models.UpdateTFAEmailMessageTemplateResponse, respDetails models.ResponseDetails, err error) {
+	respDetails, err = sms.ReqHandler.PutJSONReq(
+		ctx,
+		&req,
+		&resp,
+		updateTFAEmailTemplatePath+
+			"/"+appID+"/email/messages/"+messageID,
+		nil)
+
+	return resp, respDetails, err
+}
+
+func (sms *Channel) SendPINOverEmail(
+	ctx context.Context,
+	req models.SendPINOverEmailRequest,
+) (resp models.SendPINOverEmailResponse, respDetails models.ResponseDetails, err error) {
+	respDetails, err = sms.ReqHandler.PostJSONReq(ctx, &req, &resp, sendPINOverEmailPath)
+
+	return resp, respDetails, err
+}
+
+func (sms *Channel) ResendPINOverEmail(
+	ctx context.Context,
+	pinID string,
+	req models.ResendPINOverEmailRequest,
+) (resp models.ResendPINOverEmailResponse, respDetails models.ResponseDetails, err error) {
+	respDetails, err = sms.ReqHandler.PostJSONReq(ctx, &req, &resp, resendPINOverEmailPath+"/"+pinID+"/resend/email")
+
+	return resp, respDetails, err
+}
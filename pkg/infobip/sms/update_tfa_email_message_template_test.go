@@ -0,0 +1,68 @@
+package sms
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/infobip-community/infobip-api-go-sdk/v3/internal"
+	"github.com/infobip-community/infobip-api-go-sdk/v3/pkg/infobip/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateTFAEmailMessageTemplateValidReq(t *testing.T) {
+	apiKey := "some-key"
+	request := models.GenerateUpdateTFAEmailMessageTemplateRequest()
+	rawJSONResp := []byte(`
+		{
+		  "applicationId": "9C817C6F8AF3D48F9FE553282AFA2B67",
+		  "messageId": "5E3C2F8D9AB14CB0A1D5C6E7F8091A2B",
+		  "from": "company@example.com",
+		  "emailTemplateId": 1234,
+		  "pinLength": 6,
+		  "pinType": "ALPHANUMERIC"
+		}
+	`)
+
+	var expectedResp models.UpdateTFAEmailMessageTemplateResponse
+	err := json.Unmarshal(rawJSONResp, &expectedResp)
+	require.NoError(t, err)
+
+	serv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.True(t, strings.HasSuffix(r.URL.Path, "/email/messages/5E3C2F8D9AB14CB0A1D5C6E7F8091A2B"))
+		assert.Equal(t, http.MethodPut, r.Method)
+		assert.Equal(t, fmt.Sprintf("App %s", apiKey), r.Header.Get("Authorization"))
+		parsedBody, servErr := io.ReadAll(r.Body)
+		assert.Nil(t, servErr)
+
+		var receivedReq models.UpdateTFAEmailMessageTemplateRequest
+		servErr = json.Unmarshal(parsedBody, &receivedReq)
+		assert.Nil(t, servErr)
+		assert.Equal(t, receivedReq, request)
+
+		_, servErr = w.Write(rawJSONResp)
+		assert.Nil(t, servErr)
+	}))
+	defer serv.Close()
+	sms := Channel{ReqHandler: internal.HTTPHandler{
+		HTTPClient: http.Client{},
+		BaseURL:    serv.URL,
+		APIKey:     apiKey,
+	}}
+
+	msgResp, respDetails, err := sms.UpdateTFAEmailMessageTemplate(context.Background(),
+		"9C817C6F8AF3D48F9FE553282AFA2B67", "5E3C2F8D9AB14CB0A1D5C6E7F8091A2B", request)
+
+	require.NoError(t, err)
+	assert.NotEqual(t, models.UpdateTFAEmailMessageTemplateResponse{}, msgResp)
+	assert.Equal(t, expectedResp, msgResp)
+	assert.NotNil(t, respDetails)
+	assert.Equal(t, http.StatusOK, respDetails.HTTPResponse.StatusCode)
+	assert.Equal(t, models.ErrorDetails{}, respDetails.ErrorResponse)
+}
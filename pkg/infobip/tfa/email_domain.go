@@ -0,0 +1,54 @@
+package tfa
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/infobip-community/infobip-api-go-sdk/v3/pkg/infobip/email"
+	"github.com/infobip-community/infobip-api-go-sdk/v3/pkg/infobip/models"
+)
+
+const maxEmailDomainsPageSize = 20
+
+// EmailDomainError is returned when the sender of a 2FA email template uses a domain that cannot send emails.
+type EmailDomainError struct {
+	Domain  string
+	Blocked bool
+}
+
+func (e *EmailDomainError) Error() string {
+	if e.Blocked {
+		return fmt.Sprintf("email domain %s is blocked", e.Domain)
+	}
+	return fmt.Sprintf("email domain %s is not registered", e.Domain)
+}
+
+// CheckEmailTemplateDomain makes sure the From address of a 2FA email template belongs to a domain
+// registered in the account, as returned by email.Channel.GetDomains, and that the domain is not blocked.
+func CheckEmailTemplateDomain(ctx context.Context, client email.Email, template models.TFAEmailMessageTemplate) error {
+	domain, err := email.SenderDomain(template.From)
+	if err != nil {
+		return err
+	}
+
+	params := models.GetEmailDomainsParams{Size: maxEmailDomainsPageSize}
+	for {
+		resp, respDetails, reqErr := client.GetDomains(ctx, params)
+		if err = models.CheckResponse(respDetails, reqErr); err != nil {
+			return err
+		}
+		for _, d := range resp.Results {
+			if strings.EqualFold(d.DomainName, domain) {
+				if d.Blocked {
+					return &EmailDomainError{Domain: domain, Blocked: true}
+				}
+				return nil
+			}
+		}
+		params.Page++
+		if params.Page >= resp.Paging.TotalPages {
+			return &EmailDomainError{Domain: domain}
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package tfa
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/infobip-community/infobip-api-go-sdk/v3/internal"
+	"github.com/infobip-community/infobip-api-go-sdk/v3/pkg/infobip/email"
+	"github.com/infobip-community/infobip-api-go-sdk/v3/pkg/infobip/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckEmailTemplateDomain(t *testing.T) {
+	pages := []string{
+		`{"paging": {"page": 0, "size": 20, "totalPages": 2, "totalResults": 3},
+		  "results": [{"domainName": "example.com"}, {"domainName": "blocked.com", "blocked": true}]}`,
+		`{"paging": {"page": 1, "size": 20, "totalPages": 2, "totalResults": 3},
+		  "results": [{"domainName": "second-page.com"}]}`,
+	}
+	serv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/email/1/domains", r.URL.Path)
+		assert.Equal(t, "20", r.URL.Query().Get("size"))
+		var page int
+		_, err := fmt.Sscan(r.URL.Query().Get("page"), &page)
+		assert.NoError(t, err)
+		_, err = w.Write([]byte(pages[page]))
+		assert.NoError(t, err)
+	}))
+	defer serv.Close()
+	client := &email.Channel{ReqHandler: internal.HTTPHandler{
+		HTTPClient: http.Client{},
+		BaseURL:    serv.URL,
+		APIKey:     "some-key",
+	}}
+
+	tests := []struct {
+		name  string
+		from  string
+		check func(t *testing.T, err error)
+	}{
+		{
+			name:  "registered domain",
+			from:  "Company <company@Example.com>",
+			check: func(t *testing.T, err error) { require.NoError(t, err) },
+		},
+		{
+			name:  "registered domain on second page",
+			from:  "company@second-page.com",
+			check: func(t *testing.T, err error) { require.NoError(t, err) },
+		},
+		{
+			name: "blocked domain",
+			from: "company@blocked.com",
+			check: func(t *testing.T, err error) {
+				assert.Equal(t, &EmailDomainError{Domain: "blocked.com", Blocked: true}, err)
+			},
+		},
+		{
+			name: "unregistered domain",
+			from: "company@unknown.com",
+			check: func(t *testing.T, err error) {
+				assert.Equal(t, &EmailDomainError{Domain: "unknown.com"}, err)
+			},
+		},
+		{
+			name:  "invalid sender",
+			from:  "not an address",
+			check: func(t *testing.T, err error) { require.Error(t, err) },
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			template := models.TFAEmailMessageTemplate{From: tc.from, EmailTemplateID: 1, PINLength: 4}
+			tc.check(t, CheckEmailTemplateDomain(context.Background(), client, template))
+		})
+	}
+}
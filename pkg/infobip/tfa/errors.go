@@ -0,0 +1,46 @@
+package tfa
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/infobip-community/infobip-api-go-sdk/v3/pkg/infobip/models"
+)
+
+var (
+	ErrNoPendingVerification = errors.New("no pending verification for this phone number")
+	ErrPINExpired            = errors.New("pin has expired")
+	ErrNoMorePINAttempts     = errors.New("no more pin attempts left")
+)
+
+// WrongPINError is returned when the PIN does not match but the number can still be verified.
+type WrongPINError struct {
+	AttemptsRemaining int
+}
+
+func (e *WrongPINError) Error() string {
+	return fmt.Sprintf("wrong pin, %d attempts remaining", e.AttemptsRemaining)
+}
+
+// CooldownError is returned when a resend is requested before the configured cooldown has elapsed.
+type CooldownError struct {
+	RetryAfter time.Duration
+}
+
+func (e *CooldownError) Error() string {
+	return fmt.Sprintf("resend is on cooldown, retry after %s", e.RetryAfter)
+}
+
+func pinError(resp models.VerifyPhoneNumberResponse) error {
+	switch resp.PINError {
+	case "TTL_EXPIRED", "PIN_EXPIRED":
+		return ErrPINExpired
+	case "NO_MORE_PIN_ATTEMPTS":
+		return ErrNoMorePINAttempts
+	}
+	if resp.AttemptsRemaining == 0 {
+		return ErrNoMorePINAttempts
+	}
+	return &WrongPINError{AttemptsRemaining: resp.AttemptsRemaining}
+}
@@ -0,0 +1,60 @@
+package tfa
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Verification holds the state of a PIN that was sent and is waiting to be verified.
+type Verification struct {
+	PINID             string
+	To                string
+	Channel           DeliveryChannel
+	SentAt            time.Time
+	LastSentAt        time.Time
+	ResendCount       int
+	AttemptsRemaining int
+}
+
+// Store persists pending verifications between calls to the Verifier. Implementations must be safe
+// for concurrent use. Get must return ErrNoPendingVerification when there is nothing stored for the number.
+type Store interface {
+	Save(ctx context.Context, verification Verification) error
+	Get(ctx context.Context, to string) (Verification, error)
+	Delete(ctx context.Context, to string) error
+}
+
+// MemoryStore is an in-process Store. Pending verifications are lost when the process exits.
+type MemoryStore struct {
+	mu            sync.Mutex
+	verifications map[string]Verification
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{verifications: make(map[string]Verification)}
+}
+
+func (m *MemoryStore) Save(_ context.Context, verification Verification) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.verifications[verification.To] = verification
+	return nil
+}
+
+func (m *MemoryStore) Get(_ context.Context, to string) (Verification, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	verification, ok := m.verifications[to]
+	if !ok {
+		return Verification{}, ErrNoPendingVerification
+	}
+	return verification, nil
+}
+
+func (m *MemoryStore) Delete(_ context.Context, to string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.verifications, to)
+	return nil
+}
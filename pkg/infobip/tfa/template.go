@@ -0,0 +1,193 @@
+package tfa
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/infobip-community/infobip-api-go-sdk/v3/pkg/infobip/models"
+	"github.com/infobip-community/infobip-api-go-sdk/v3/pkg/infobip/utils"
+)
+
+const (
+	DefaultPINPlaceholder = "{{pin}}"
+
+	minPINLength = 4
+	maxPINLength = 15
+)
+
+// nolint: gochecknoglobals // compiled once, only read afterwards
+var (
+	placeholderRegexp = regexp.MustCompile(`\{\{\s*([A-Za-z0-9_.\-]+)\s*\}\}`)
+	pinLengthRegexp   = regexp.MustCompile(`(?i)\b(\d+)[\s-]?(digit|character|char|letter)s?\b`)
+)
+
+// TemplateInfo describes the placeholders found in the text of a 2FA message template.
+type TemplateInfo struct {
+	// PINPlaceholder is the placeholder replaced with the PIN, {{pin}} unless set on the template.
+	PINPlaceholder string
+	// Placeholders are the names of custom placeholders, which must be supplied when sending the PIN.
+	Placeholders []string
+}
+
+// TemplateError lists the problems found in a 2FA message template.
+type TemplateError struct {
+	Problems []string
+}
+
+func (e *TemplateError) Error() string {
+	return "invalid 2fa message template: " + strings.Join(e.Problems, "; ")
+}
+
+// MissingPlaceholdersError is returned when a PIN is sent without values for every custom placeholder
+// of the message template.
+type MissingPlaceholdersError struct {
+	Names []string
+}
+
+func (e *MissingPlaceholdersError) Error() string {
+	return "missing values for placeholders: " + strings.Join(e.Names, ", ")
+}
+
+// ParseTemplate returns the PIN placeholder and the custom placeholders used in the template text.
+func ParseTemplate(template models.TFAMessageTemplate) TemplateInfo {
+	info := TemplateInfo{PINPlaceholder: template.PINPlaceholder}
+	if info.PINPlaceholder == "" {
+		info.PINPlaceholder = DefaultPINPlaceholder
+	}
+
+	seen := map[string]bool{}
+	for _, match := range placeholderRegexp.FindAllStringSubmatch(template.MessageText, -1) {
+		if match[0] == info.PINPlaceholder || normalizePlaceholder(match[0]) == info.PINPlaceholder {
+			continue
+		}
+		if !seen[match[1]] {
+			seen[match[1]] = true
+			info.Placeholders = append(info.Placeholders, match[1])
+		}
+	}
+	return info
+}
+
+// CheckTemplate makes sure the template text contains the PIN placeholder, and that the PIN type and length
+// agree with the text, e.g. a template mentioning a "6-digit code" must use a NUMERIC PIN of length 6.
+func CheckTemplate(template models.TFAMessageTemplate) error {
+	var problems []string
+	info := ParseTemplate(template)
+
+	if !containsPlaceholder(template.MessageText, info.PINPlaceholder) {
+		problems = append(problems, fmt.Sprintf("message text does not contain the pin placeholder %s", info.PINPlaceholder))
+	}
+
+	switch template.PINType {
+	case models.NUMERIC, models.ALPHA, models.HEX, models.ALPHANUMERIC:
+	default:
+		problems = append(problems, fmt.Sprintf("unknown pin type %q", template.PINType))
+	}
+
+	if template.PINLength < minPINLength || template.PINLength > maxPINLength {
+		problems = append(problems,
+			fmt.Sprintf("pin length %d is not between %d and %d", template.PINLength, minPINLength, maxPINLength))
+	}
+
+	for _, match := range pinLengthRegexp.FindAllStringSubmatch(template.MessageText, -1) {
+		length, _ := strconv.Atoi(match[1])
+		if length != template.PINLength {
+			problems = append(problems,
+				fmt.Sprintf("message text mentions %q but pin length is %d", match[0], template.PINLength))
+		}
+		if strings.EqualFold(match[2], "digit") && template.PINType != models.NUMERIC {
+			problems = append(problems,
+				fmt.Sprintf("message text mentions %q but pin type is %s", match[0], template.PINType))
+		}
+	}
+
+	if len(problems) > 0 {
+		return &TemplateError{Problems: problems}
+	}
+	return nil
+}
+
+// CheckPlaceholders makes sure a value is supplied for every custom placeholder of the template.
+func CheckPlaceholders(template models.TFAMessageTemplate, placeholders map[string]string) error {
+	var missing []string
+	for _, name := range ParseTemplate(template).Placeholders {
+		if _, ok := placeholders[name]; !ok {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return &MissingPlaceholdersError{Names: missing}
+	}
+	return nil
+}
+
+// CheckSendPINRequest makes sure the request supplies every custom placeholder of the template it references.
+func CheckSendPINRequest(template models.TFAMessageTemplate, req models.SendPINRequest) error {
+	return CheckPlaceholders(template, req.Placeholders)
+}
+
+// Preview is a local rendering of a 2FA message, with a sample PIN in place of the PIN placeholder.
+type Preview struct {
+	Text       string
+	Encoding   string
+	Characters int
+	Segments   int
+}
+
+// PreviewTemplate renders the template with a sample PIN of the configured type and length and the given
+// placeholder values, and counts the SMS parts needed to send it.
+func PreviewTemplate(template models.TFAMessageTemplate, placeholders map[string]string) (Preview, error) {
+	if err := CheckPlaceholders(template, placeholders); err != nil {
+		return Preview{}, err
+	}
+	info := ParseTemplate(template)
+	pin := samplePIN(template.PINType, template.PINLength)
+
+	text := strings.ReplaceAll(template.MessageText, info.PINPlaceholder, pin)
+	text = placeholderRegexp.ReplaceAllStringFunc(text, func(placeholder string) string {
+		if placeholder == info.PINPlaceholder || normalizePlaceholder(placeholder) == info.PINPlaceholder {
+			return pin
+		}
+		name := placeholderRegexp.FindStringSubmatch(placeholder)[1]
+		return placeholders[name]
+	})
+
+	encoding, characters, segments := utils.SMSSegments(text)
+	return Preview{Text: text, Encoding: encoding, Characters: characters, Segments: segments}, nil
+}
+
+func samplePIN(pinType models.PINType, length int) string {
+	charset := "0123456789"
+	switch pinType {
+	case models.ALPHA:
+		charset = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	case models.HEX:
+		charset = "0123456789ABCDEF"
+	case models.ALPHANUMERIC:
+		charset = "A1B2C3D4E5F6G7H8I9J0"
+	case models.NUMERIC:
+	}
+
+	var pin strings.Builder
+	for i := 0; i < length; i++ {
+		pin.WriteByte(charset[i%len(charset)])
+	}
+	return pin.String()
+}
+
+func containsPlaceholder(text string, placeholder string) bool {
+	for _, match := range placeholderRegexp.FindAllString(text, -1) {
+		if match == placeholder || normalizePlaceholder(match) == placeholder {
+			return true
+		}
+	}
+	return strings.Contains(text, placeholder)
+}
+
+func normalizePlaceholder(placeholder string) string {
+	return "{{" + strings.TrimSpace(strings.Trim(placeholder, "{}")) + "}}"
+}
@@ -0,0 +1,157 @@
+package tfa
+
+import (
+	"testing"
+
+	"github.com/infobip-community/infobip-api-go-sdk/v3/pkg/infobip/models"
+	"github.com/infobip-community/infobip-api-go-sdk/v3/pkg/infobip/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTemplate(t *testing.T) {
+	tests := []struct {
+		name     string
+		template models.TFAMessageTemplate
+		expected TemplateInfo
+	}{
+		{
+			name:     "default pin placeholder",
+			template: models.TFAMessageTemplate{MessageText: "Your PIN is {{pin}}"},
+			expected: TemplateInfo{PINPlaceholder: "{{pin}}"},
+		},
+		{
+			name: "custom placeholders",
+			template: models.TFAMessageTemplate{
+				MessageText: "Hello {{name}}, your {{ app }} PIN is {{ pin }}. Bye {{name}}",
+			},
+			expected: TemplateInfo{PINPlaceholder: "{{pin}}", Placeholders: []string{"name", "app"}},
+		},
+		{
+			name: "custom pin placeholder",
+			template: models.TFAMessageTemplate{
+				MessageText:    "Hello {{name}}, your code is {{code}}",
+				PINPlaceholder: "{{code}}",
+			},
+			expected: TemplateInfo{PINPlaceholder: "{{code}}", Placeholders: []string{"name"}},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, ParseTemplate(tc.template))
+		})
+	}
+}
+
+func TestCheckTemplate(t *testing.T) {
+	tests := []struct {
+		name     string
+		template models.TFAMessageTemplate
+		problems int
+	}{
+		{
+			name:     "valid",
+			template: models.TFAMessageTemplate{MessageText: "Your 6-digit code is {{pin}}", PINLength: 6, PINType: models.NUMERIC},
+		},
+		{
+			name:     "missing pin placeholder",
+			template: models.TFAMessageTemplate{MessageText: "Your code is {{code}}", PINLength: 4, PINType: models.NUMERIC},
+			problems: 1,
+		},
+		{
+			name:     "unknown pin type",
+			template: models.TFAMessageTemplate{MessageText: "Your code is {{pin}}", PINLength: 4, PINType: "EMOJI"},
+			problems: 1,
+		},
+		{
+			name:     "pin too short",
+			template: models.TFAMessageTemplate{MessageText: "Your code is {{pin}}", PINLength: 2, PINType: models.NUMERIC},
+			problems: 1,
+		},
+		{
+			name: "length mentioned in text does not match",
+			template: models.TFAMessageTemplate{
+				MessageText: "Your 6 digit code is {{pin}}", PINLength: 4, PINType: models.NUMERIC,
+			},
+			problems: 1,
+		},
+		{
+			name: "digits mentioned for alphanumeric pin",
+			template: models.TFAMessageTemplate{
+				MessageText: "Your 8-digits code is {{pin}}", PINLength: 8, PINType: models.ALPHANUMERIC,
+			},
+			problems: 1,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := CheckTemplate(tc.template)
+			if tc.problems == 0 {
+				require.NoError(t, err)
+				return
+			}
+			var templateErr *TemplateError
+			require.ErrorAs(t, err, &templateErr)
+			assert.Len(t, templateErr.Problems, tc.problems)
+		})
+	}
+}
+
+func TestCheckSendPINRequest(t *testing.T) {
+	template := models.TFAMessageTemplate{
+		MessageText: "Hello {{name}}, your {{app}} PIN is {{pin}}",
+		PINLength:   4,
+		PINType:     models.NUMERIC,
+	}
+
+	err := CheckSendPINRequest(template, models.SendPINRequest{
+		Placeholders: map[string]string{"name": "John", "app": "Gopher"},
+	})
+	require.NoError(t, err)
+
+	err = CheckSendPINRequest(template, models.SendPINRequest{Placeholders: map[string]string{"name": "John"}})
+	assert.Equal(t, &MissingPlaceholdersError{Names: []string{"app"}}, err)
+}
+
+func TestPreviewTemplate(t *testing.T) {
+	tests := []struct {
+		name         string
+		template     models.TFAMessageTemplate
+		placeholders map[string]string
+		expected     Preview
+	}{
+		{
+			name:         "numeric",
+			template:     models.TFAMessageTemplate{MessageText: "Hi {{name}}, PIN: {{pin}}", PINLength: 4, PINType: models.NUMERIC},
+			placeholders: map[string]string{"name": "John"},
+			expected:     Preview{Text: "Hi John, PIN: 0123", Encoding: utils.GSM7Encoding, Characters: 18, Segments: 1},
+		},
+		{
+			name: "hex with custom placeholder",
+			template: models.TFAMessageTemplate{
+				MessageText: "Code <code>", PINPlaceholder: "<code>", PINLength: 6, PINType: models.HEX,
+			},
+			expected: Preview{Text: "Code 012345", Encoding: utils.GSM7Encoding, Characters: 11, Segments: 1},
+		},
+		{
+			name:         "unicode",
+			template:     models.TFAMessageTemplate{MessageText: "Код {{pin}} для {{name}}", PINLength: 5, PINType: models.ALPHA},
+			placeholders: map[string]string{"name": "Иван"},
+			expected:     Preview{Text: "Код ABCDE для Иван", Encoding: utils.UCS2Encoding, Characters: 18, Segments: 1},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			preview, err := PreviewTemplate(tc.template, tc.placeholders)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, preview)
+		})
+	}
+
+	_, err := PreviewTemplate(models.TFAMessageTemplate{MessageText: "Hi {{name}} {{pin}}"}, nil)
+	var missingErr *MissingPlaceholdersError
+	require.ErrorAs(t, err, &missingErr)
+}
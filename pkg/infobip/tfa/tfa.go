@@ -0,0 +1,296 @@
+// Package tfa provides a 2FA verification flow on top of the low-level 2FA methods of the SMS channel.
+// 2FA API docs: https://www.infobip.com/docs/api#channels/sms/2fa
+package tfa
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/infobip-community/infobip-api-go-sdk/v3/pkg/infobip/models"
+	"github.com/infobip-community/infobip-api-go-sdk/v3/pkg/infobip/sms"
+)
+
+type DeliveryChannel string
+
+const (
+	SMS   DeliveryChannel = "SMS"
+	Voice DeliveryChannel = "VOICE"
+)
+
+// Config describes the 2FA application and message templates used by a Verifier.
+type Config struct {
+	// ApplicationName identifies the 2FA application. It is created if no application with this name exists.
+	ApplicationName string
+	// Application is the configuration used when the application has to be created.
+	Application *models.TFAApplicationConfiguration
+	// SMSTemplate is the template used for PINs sent over SMS. A template with the same text, language, PIN length
+	// and PIN type is reused if it already exists in the application.
+	SMSTemplate models.TFAMessageTemplate
+	// VoiceTemplate is the template used for PINs sent over voice. Voice fallback is disabled when nil.
+	VoiceTemplate *models.TFAMessageTemplate
+	// VoiceFallbackDelay is the time after the first SMS from which resends are delivered over voice.
+	VoiceFallbackDelay time.Duration
+	// ResendCooldown is the minimum time between two sends to the same phone number.
+	ResendCooldown time.Duration
+	// Store persists pending verifications. A MemoryStore is used when nil.
+	Store Store
+}
+
+// Verifier orchestrates sending and verifying PINs: it makes sure the 2FA application and message templates
+// exist, sends PINs over SMS falling back to voice, enforces resend cooldowns and keeps track of pending
+// verifications.
+type Verifier struct {
+	client sms.SMS
+	config Config
+	store  Store
+	now    func() time.Time
+
+	mu             sync.Mutex
+	appID          string
+	smsMessageID   string
+	voiceMessageID string
+}
+
+func NewVerifier(client sms.SMS, config Config) *Verifier {
+	store := config.Store
+	if store == nil {
+		store = NewMemoryStore()
+	}
+	return &Verifier{client: client, config: config, store: store, now: time.Now}
+}
+
+// Setup makes sure the 2FA application and message templates exist, creating them when missing.
+// It is safe to call multiple times, and it is called automatically before the first PIN is sent.
+func (v *Verifier) Setup(ctx context.Context) error {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if v.appID != "" {
+		return nil
+	}
+
+	if err := CheckTemplate(v.config.SMSTemplate); err != nil {
+		return err
+	}
+	if v.config.VoiceTemplate != nil {
+		if err := CheckTemplate(*v.config.VoiceTemplate); err != nil {
+			return err
+		}
+	}
+
+	appID, err := v.ensureApplication(ctx)
+	if err != nil {
+		return err
+	}
+
+	templates, respDetails, err := v.client.GetTFAMessageTemplates(ctx, appID)
+	if err = models.CheckResponse(respDetails, err); err != nil {
+		return err
+	}
+
+	smsMessageID, err := v.ensureTemplate(ctx, appID, templates, v.config.SMSTemplate)
+	if err != nil {
+		return err
+	}
+
+	var voiceMessageID string
+	if v.config.VoiceTemplate != nil {
+		voiceMessageID, err = v.ensureTemplate(ctx, appID, templates, *v.config.VoiceTemplate)
+		if err != nil {
+			return err
+		}
+	}
+
+	v.appID, v.smsMessageID, v.voiceMessageID = appID, smsMessageID, voiceMessageID
+	return nil
+}
+
+func (v *Verifier) ensureApplication(ctx context.Context) (string, error) {
+	apps, respDetails, err := v.client.GetTFAApplications(ctx)
+	if err = models.CheckResponse(respDetails, err); err != nil {
+		return "", err
+	}
+	for _, app := range apps {
+		if app.Name == v.config.ApplicationName {
+			return app.ApplicationID, nil
+		}
+	}
+
+	created, respDetails, err := v.client.CreateTFAApplication(ctx, models.CreateTFAApplicationRequest{
+		Name:          v.config.ApplicationName,
+		Configuration: v.config.Application,
+		Enabled:       true,
+	})
+	if err = models.CheckResponse(respDetails, err); err != nil {
+		return "", err
+	}
+	return created.ApplicationID, nil
+}
+
+func (v *Verifier) ensureTemplate(
+	ctx context.Context,
+	appID string,
+	existing models.GetTFAMessageTemplatesResponse,
+	template models.TFAMessageTemplate,
+) (string, error) {
+	for _, t := range existing {
+		if t.MessageText == template.MessageText && t.Language == template.Language &&
+			t.PINLength == template.PINLength && t.PINType == template.PINType {
+			return t.MessageID, nil
+		}
+	}
+
+	created, respDetails, err := v.client.CreateTFAMessageTemplate(
+		ctx, appID, models.CreateTFAMessageTemplateRequest(template))
+	if err = models.CheckResponse(respDetails, err); err != nil {
+		return "", err
+	}
+	return created.MessageID, nil
+}
+
+// Start sends a new PIN over SMS to the phone number and stores it as pending. If a PIN was sent to the
+// same number less than ResendCooldown ago, a CooldownError is returned. A MissingPlaceholdersError is
+// returned when placeholders lacks a value used by the SMS template.
+func (v *Verifier) Start(ctx context.Context, to string, placeholders map[string]string) (Verification, error) {
+	if err := v.Setup(ctx); err != nil {
+		return Verification{}, err
+	}
+	if err := CheckPlaceholders(v.config.SMSTemplate, placeholders); err != nil {
+		return Verification{}, err
+	}
+
+	pending, err := v.store.Get(ctx, to)
+	switch {
+	case err == nil:
+		if err = v.checkCooldown(pending); err != nil {
+			return Verification{}, err
+		}
+	case !errors.Is(err, ErrNoPendingVerification):
+		return Verification{}, err
+	}
+
+	resp, respDetails, err := v.client.SendPINOverSMS(ctx, models.SendPINOverSMSParams{}, models.SendPINOverSMSRequest{
+		ApplicationID: v.appID,
+		MessageID:     v.smsMessageID,
+		To:            to,
+		Placeholders:  placeholders,
+	})
+	if err = models.CheckResponse(respDetails, err); err != nil {
+		return Verification{}, err
+	}
+
+	now := v.now()
+	verification := Verification{PINID: resp.PINID, To: to, Channel: SMS, SentAt: now, LastSentAt: now}
+	if err = v.store.Save(ctx, verification); err != nil {
+		return Verification{}, err
+	}
+	return verification, nil
+}
+
+// Resend sends the pending PIN again. Once VoiceFallbackDelay has passed since the first send, and a voice
+// template is configured, a new PIN is sent over voice with the voice template instead, and later resends
+// repeat it over voice.
+func (v *Verifier) Resend(ctx context.Context, to string, placeholders map[string]string) (Verification, error) {
+	if err := v.Setup(ctx); err != nil {
+		return Verification{}, err
+	}
+
+	verification, err := v.store.Get(ctx, to)
+	if err != nil {
+		return Verification{}, err
+	}
+	if err = v.checkCooldown(verification); err != nil {
+		return Verification{}, err
+	}
+
+	now := v.now()
+	switch {
+	case verification.Channel == Voice:
+		_, respDetails, sendErr := v.client.ResendPINOverVoice(
+			ctx, verification.PINID, models.ResendPINOverVoiceRequest{Placeholders: placeholders})
+		err = models.CheckResponse(respDetails, sendErr)
+	case v.voiceMessageID != "" && now.Sub(verification.SentAt) >= v.config.VoiceFallbackDelay:
+		verification.PINID, err = v.sendOverVoice(ctx, to, placeholders)
+		verification.Channel = Voice
+	default:
+		_, respDetails, sendErr := v.client.ResendPINOverSMS(
+			ctx, verification.PINID, models.ResendPINOverSMSRequest{Placeholders: placeholders})
+		err = models.CheckResponse(respDetails, sendErr)
+	}
+	if err != nil {
+		return Verification{}, err
+	}
+
+	verification.LastSentAt = now
+	verification.ResendCount++
+	if err = v.store.Save(ctx, verification); err != nil {
+		return Verification{}, err
+	}
+	return verification, nil
+}
+
+// sendOverVoice sends a new PIN over voice with the voice template and returns its ID.
+func (v *Verifier) sendOverVoice(ctx context.Context, to string, placeholders map[string]string) (string, error) {
+	if err := CheckPlaceholders(*v.config.VoiceTemplate, placeholders); err != nil {
+		return "", err
+	}
+	resp, respDetails, err := v.client.SendPINOverVoice(ctx, models.SendPINOverVoiceRequest{
+		ApplicationID: v.appID,
+		MessageID:     v.voiceMessageID,
+		To:            to,
+		Placeholders:  placeholders,
+	})
+	if err = models.CheckResponse(respDetails, err); err != nil {
+		return "", err
+	}
+	return resp.PINID, nil
+}
+
+// Verify checks the PIN entered by the user. It returns nil when the phone number is verified, a
+// WrongPINError while attempts remain, and ErrPINExpired or ErrNoMorePINAttempts when the PIN can no
+// longer be verified. The pending verification is removed in every case except a wrong PIN.
+func (v *Verifier) Verify(ctx context.Context, to string, pin string) error {
+	verification, err := v.store.Get(ctx, to)
+	if err != nil {
+		return err
+	}
+
+	resp, respDetails, err := v.client.VerifyPhoneNumber(
+		ctx, verification.PINID, models.VerifyPhoneNumberRequest{PIN: pin})
+	if err = models.CheckResponse(respDetails, err); err != nil {
+		return err
+	}
+
+	if resp.Verified {
+		return v.store.Delete(ctx, to)
+	}
+
+	verifyErr := pinError(resp)
+	var wrongPIN *WrongPINError
+	if errors.As(verifyErr, &wrongPIN) {
+		verification.AttemptsRemaining = resp.AttemptsRemaining
+		if err = v.store.Save(ctx, verification); err != nil {
+			return err
+		}
+		return verifyErr
+	}
+
+	if err = v.store.Delete(ctx, to); err != nil {
+		return err
+	}
+	return verifyErr
+}
+
+// Cancel drops the pending verification for the phone number.
+func (v *Verifier) Cancel(ctx context.Context, to string) error {
+	return v.store.Delete(ctx, to)
+}
+
+func (v *Verifier) checkCooldown(verification Verification) error {
+	elapsed := v.now().Sub(verification.LastSentAt)
+	if elapsed < v.config.ResendCooldown {
+		return &CooldownError{RetryAfter: v.config.ResendCooldown - elapsed}
+	}
+	return nil
+}
@@ -0,0 +1,276 @@
+package tfa
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/infobip-community/infobip-api-go-sdk/v3/internal"
+	"github.com/infobip-community/infobip-api-go-sdk/v3/pkg/infobip/models"
+	"github.com/infobip-community/infobip-api-go-sdk/v3/pkg/infobip/sms"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeTFAServer struct {
+	calls        []string
+	apps         string
+	templates    string
+	verifyResp   string
+	verifyStatus int
+}
+
+func (f *fakeTFAServer) handler(t *testing.T) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		f.calls = append(f.calls, r.Method+" "+r.URL.Path)
+		_, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+
+		var resp string
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/2fa/2/applications":
+			resp = f.apps
+		case r.Method == http.MethodPost && r.URL.Path == "/2fa/2/applications":
+			resp = `{"applicationId": "APP-NEW", "name": "login", "enabled": true}`
+		case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/messages"):
+			resp = f.templates
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/messages"):
+			resp = `{"messageId": "MSG-NEW", "messageText": "Your code is {{pin}}", "pinType": "NUMERIC"}`
+		case r.Method == http.MethodPost && r.URL.Path == "/2fa/2/pin/voice":
+			resp = `{"pinId": "PIN-VOICE", "to": "41793026727", "callStatus": "PENDING_ACCEPTED"}`
+		case strings.HasSuffix(r.URL.Path, "/verify"):
+			if f.verifyStatus != 0 {
+				w.WriteHeader(f.verifyStatus)
+			}
+			resp = f.verifyResp
+		default:
+			resp = `{"pinId": "PIN-1", "to": "41793026727", "smsStatus": "MESSAGE_SENT"}`
+		}
+		_, err = w.Write([]byte(resp))
+		assert.NoError(t, err)
+	}
+}
+
+func newTestVerifier(t *testing.T, f *fakeTFAServer, config Config) (*Verifier, *time.Time) {
+	serv := httptest.NewServer(f.handler(t))
+	t.Cleanup(serv.Close)
+
+	channel := &sms.Channel{ReqHandler: internal.HTTPHandler{
+		HTTPClient: http.Client{},
+		BaseURL:    serv.URL,
+		APIKey:     "some-key",
+	}}
+	now := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	verifier := NewVerifier(channel, config)
+	verifier.now = func() time.Time { return now }
+	return verifier, &now
+}
+
+func testConfig() Config {
+	return Config{
+		ApplicationName: "login",
+		SMSTemplate: models.TFAMessageTemplate{
+			MessageText: "Your code is {{pin}}",
+			PINLength:   4,
+			PINType:     models.NUMERIC,
+		},
+		VoiceTemplate: &models.TFAMessageTemplate{
+			MessageText: "Your code is {{pin}}",
+			Language:    models.English,
+			PINLength:   4,
+			PINType:     models.NUMERIC,
+		},
+		VoiceFallbackDelay: time.Minute,
+		ResendCooldown:     30 * time.Second,
+	}
+}
+
+func TestSetupReusesExistingResources(t *testing.T) {
+	f := &fakeTFAServer{
+		apps: `[{"applicationId": "APP-1", "name": "login", "enabled": true}]`,
+		templates: `[` +
+			`{"messageId": "MSG-6", "messageText": "Your code is {{pin}}", "pinLength": 6, "pinType": "NUMERIC"},` +
+			`{"messageId": "MSG-1", "messageText": "Your code is {{pin}}", "pinLength": 4, "pinType": "NUMERIC"}]`,
+	}
+	verifier, _ := newTestVerifier(t, f, testConfig())
+
+	require.NoError(t, verifier.Setup(context.Background()))
+	require.NoError(t, verifier.Setup(context.Background()))
+
+	assert.Equal(t, "APP-1", verifier.appID)
+	assert.Equal(t, "MSG-1", verifier.smsMessageID)
+	assert.Equal(t, "MSG-NEW", verifier.voiceMessageID)
+	assert.Equal(t, []string{
+		"GET /2fa/2/applications",
+		"GET /2fa/2/applications/APP-1/messages",
+		"POST /2fa/2/applications/APP-1/messages",
+	}, f.calls)
+}
+
+func TestSetupCreatesApplication(t *testing.T) {
+	f := &fakeTFAServer{apps: `[]`, templates: `[]`}
+	config := testConfig()
+	config.VoiceTemplate = nil
+	verifier, _ := newTestVerifier(t, f, config)
+
+	require.NoError(t, verifier.Setup(context.Background()))
+
+	assert.Equal(t, "APP-NEW", verifier.appID)
+	assert.Equal(t, "MSG-NEW", verifier.smsMessageID)
+	assert.Empty(t, verifier.voiceMessageID)
+}
+
+func TestStartAndResend(t *testing.T) {
+	f := &fakeTFAServer{apps: `[{"applicationId": "APP-1", "name": "login"}]`, templates: `[]`}
+	verifier, now := newTestVerifier(t, f, testConfig())
+	ctx := context.Background()
+
+	verification, err := verifier.Start(ctx, "41793026727", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "PIN-1", verification.PINID)
+	assert.Equal(t, SMS, verification.Channel)
+
+	_, err = verifier.Start(ctx, "41793026727", nil)
+	var cooldownErr *CooldownError
+	require.ErrorAs(t, err, &cooldownErr)
+	assert.Equal(t, 30*time.Second, cooldownErr.RetryAfter)
+
+	*now = now.Add(40 * time.Second)
+	verification, err = verifier.Resend(ctx, "41793026727", nil)
+	require.NoError(t, err)
+	assert.Equal(t, SMS, verification.Channel)
+	assert.Equal(t, 1, verification.ResendCount)
+
+	*now = now.Add(40 * time.Second)
+	verification, err = verifier.Resend(ctx, "41793026727", nil)
+	require.NoError(t, err)
+	assert.Equal(t, Voice, verification.Channel)
+	assert.Equal(t, "PIN-VOICE", verification.PINID)
+	assert.Equal(t, 2, verification.ResendCount)
+
+	*now = now.Add(40 * time.Second)
+	verification, err = verifier.Resend(ctx, "41793026727", nil)
+	require.NoError(t, err)
+	assert.Equal(t, Voice, verification.Channel)
+	assert.Equal(t, "PIN-VOICE", verification.PINID)
+	assert.Equal(t, []string{
+		"POST /2fa/2/pin",
+		"POST /2fa/2/pin/PIN-1/resend",
+		"POST /2fa/2/pin/voice",
+		"POST /2fa/2/pin/PIN-VOICE/resend/voice",
+	}, f.calls[len(f.calls)-4:])
+}
+
+func TestResendWithoutPendingVerification(t *testing.T) {
+	f := &fakeTFAServer{apps: `[{"applicationId": "APP-1", "name": "login"}]`, templates: `[]`}
+	verifier, _ := newTestVerifier(t, f, testConfig())
+
+	_, err := verifier.Resend(context.Background(), "41793026727", nil)
+	require.ErrorIs(t, err, ErrNoPendingVerification)
+}
+
+func TestVerify(t *testing.T) {
+	tests := []struct {
+		name         string
+		verifyResp   string
+		verifyStatus int
+		check        func(t *testing.T, err error)
+		stillPending bool
+	}{
+		{
+			name:       "verified",
+			verifyResp: `{"pinId": "PIN-1", "verified": true, "attemptsRemaining": 0}`,
+			check:      func(t *testing.T, err error) { require.NoError(t, err) },
+		},
+		{
+			name:       "wrong pin",
+			verifyResp: `{"pinId": "PIN-1", "verified": false, "attemptsRemaining": 2, "pinError": "WRONG_PIN"}`,
+			check: func(t *testing.T, err error) {
+				var wrongPIN *WrongPINError
+				require.ErrorAs(t, err, &wrongPIN)
+				assert.Equal(t, 2, wrongPIN.AttemptsRemaining)
+			},
+			stillPending: true,
+		},
+		{
+			name:       "no more attempts",
+			verifyResp: `{"pinId": "PIN-1", "verified": false, "attemptsRemaining": 0, "pinError": "NO_MORE_PIN_ATTEMPTS"}`,
+			check:      func(t *testing.T, err error) { require.ErrorIs(t, err, ErrNoMorePINAttempts) },
+		},
+		{
+			name:       "expired",
+			verifyResp: `{"pinId": "PIN-1", "verified": false, "attemptsRemaining": 3, "pinError": "TTL_EXPIRED"}`,
+			check:      func(t *testing.T, err error) { require.ErrorIs(t, err, ErrPINExpired) },
+		},
+		{
+			name:         "api error",
+			verifyResp:   `{"requestError": {"serviceException": {"messageId": "BAD_REQUEST", "text": "Bad request"}}}`,
+			verifyStatus: http.StatusBadRequest,
+			check: func(t *testing.T, err error) {
+				var apiErr *models.APIError
+				require.ErrorAs(t, err, &apiErr)
+				assert.Equal(t, http.StatusBadRequest, apiErr.StatusCode)
+				assert.Equal(t, "Bad request", apiErr.Details.RequestError.ServiceException.Text)
+			},
+			stillPending: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			f := &fakeTFAServer{
+				apps:         `[{"applicationId": "APP-1", "name": "login"}]`,
+				templates:    `[]`,
+				verifyResp:   tc.verifyResp,
+				verifyStatus: tc.verifyStatus,
+			}
+			verifier, _ := newTestVerifier(t, f, testConfig())
+			ctx := context.Background()
+			_, err := verifier.Start(ctx, "41793026727", nil)
+			require.NoError(t, err)
+
+			tc.check(t, verifier.Verify(ctx, "41793026727", "1234"))
+
+			_, err = verifier.store.Get(ctx, "41793026727")
+			if tc.stillPending {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, ErrNoPendingVerification)
+			}
+		})
+	}
+}
+
+func TestVerifyPhoneNumberResponsePINError(t *testing.T) {
+	var resp models.VerifyPhoneNumberResponse
+	err := json.Unmarshal([]byte(`{"pinError": "WRONG_PIN", "attemptsRemaining": 1}`), &resp)
+	require.NoError(t, err)
+	assert.Equal(t, &WrongPINError{AttemptsRemaining: 1}, pinError(resp))
+}
+
+func TestStartWithMissingPlaceholders(t *testing.T) {
+	f := &fakeTFAServer{apps: `[{"applicationId": "APP-1", "name": "login"}]`, templates: `[]`}
+	config := testConfig()
+	config.SMSTemplate.MessageText = "Hello {{name}}, your code is {{pin}}"
+	verifier, _ := newTestVerifier(t, f, config)
+
+	_, err := verifier.Start(context.Background(), "41793026727", nil)
+	assert.Equal(t, &MissingPlaceholdersError{Names: []string{"name"}}, err)
+	assert.NotContains(t, f.calls, "POST /2fa/2/pin")
+}
+
+func TestSetupWithInvalidTemplate(t *testing.T) {
+	f := &fakeTFAServer{apps: `[]`, templates: `[]`}
+	config := testConfig()
+	config.SMSTemplate.MessageText = "Your code is missing"
+	verifier, _ := newTestVerifier(t, f, config)
+
+	var templateErr *TemplateError
+	require.ErrorAs(t, verifier.Setup(context.Background()), &templateErr)
+	assert.Empty(t, f.calls)
+}
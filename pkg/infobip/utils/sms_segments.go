@@ -0,0 +1,61 @@
+package utils
+
+import "strings"
+
+const (
+	GSM7Encoding = "GSM7"
+	UCS2Encoding = "UCS2"
+
+	gsm7SinglePartLength = 160
+	gsm7MultiPartLength  = 153
+	ucs2SinglePartLength = 70
+	ucs2MultiPartLength  = 67
+
+	maxBMPRune = 0xFFFF
+)
+
+const (
+	gsm7BasicCharset = "@£$¥èéùìòÇ\nØø\rÅåΔ_ΦΓΛΩΠΨΣΘΞÆæßÉ !\"#¤%&'()*+,-./0123456789:;<=>?" +
+		"¡ABCDEFGHIJKLMNOPQRSTUVWXYZÄÖÑÜ§¿abcdefghijklmnopqrstuvwxyzäöñüà"
+	gsm7ExtendedCharset = "^{}\\[~]|€\f"
+)
+
+// SMSSegments returns the encoding needed to send the text over SMS, its length in encoded characters and the
+// number of message parts it will be split into. Characters from the GSM 7-bit extension table count twice.
+func SMSSegments(text string) (encoding string, characters int, segments int) {
+	encoding = GSM7Encoding
+	for _, r := range text {
+		switch {
+		case strings.ContainsRune(gsm7BasicCharset, r):
+			characters++
+		case strings.ContainsRune(gsm7ExtendedCharset, r):
+			characters += 2
+		default:
+			encoding = UCS2Encoding
+		}
+	}
+
+	singlePart, multiPart := gsm7SinglePartLength, gsm7MultiPartLength
+	if encoding == UCS2Encoding {
+		singlePart, multiPart = ucs2SinglePartLength, ucs2MultiPartLength
+		characters = 0
+		for _, r := range text {
+			// Characters outside the Basic Multilingual Plane need a surrogate pair.
+			if r > maxBMPRune {
+				characters += 2
+			} else {
+				characters++
+			}
+		}
+	}
+
+	switch {
+	case characters == 0:
+		segments = 0
+	case characters <= singlePart:
+		segments = 1
+	default:
+		segments = (characters + multiPart - 1) / multiPart
+	}
+	return encoding, characters, segments
+}
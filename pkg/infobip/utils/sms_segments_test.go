@@ -0,0 +1,36 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSMSSegments(t *testing.T) {
+	tests := []struct {
+		name       string
+		text       string
+		encoding   string
+		characters int
+		segments   int
+	}{
+		{name: "empty", text: "", encoding: GSM7Encoding},
+		{name: "short gsm7", text: "Your PIN is 1234", encoding: GSM7Encoding, characters: 16, segments: 1},
+		{name: "gsm7 extended", text: "Cost: 5€ [ok]", encoding: GSM7Encoding, characters: 16, segments: 1},
+		{name: "gsm7 single part limit", text: strings.Repeat("a", 160), encoding: GSM7Encoding, characters: 160, segments: 1},
+		{name: "gsm7 two parts", text: strings.Repeat("a", 161), encoding: GSM7Encoding, characters: 161, segments: 2},
+		{name: "ucs2", text: "Ваш код 1234", encoding: UCS2Encoding, characters: 12, segments: 1},
+		{name: "ucs2 two parts", text: strings.Repeat("ж", 71), encoding: UCS2Encoding, characters: 71, segments: 2},
+		{name: "ucs2 surrogate pair", text: "PIN 1234 🔒", encoding: UCS2Encoding, characters: 11, segments: 1},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			encoding, characters, segments := SMSSegments(tc.text)
+			assert.Equal(t, tc.encoding, encoding)
+			assert.Equal(t, tc.characters, characters)
+			assert.Equal(t, tc.segments, segments)
+		})
+	}
+}
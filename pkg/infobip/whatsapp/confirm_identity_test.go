@@ -0,0 +1,62 @@
+package whatsapp
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/infobip-community/infobip-api-go-sdk/v3/internal"
+	"github.com/infobip-community/infobip-api-go-sdk/v3/pkg/infobip/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfirmIdentityValidReq(t *testing.T) {
+	apiKey := "secret"
+	sender := "441134960000"
+	userNumber := "441134960001"
+
+	serv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method)
+		assert.True(t, strings.HasSuffix(r.URL.Path, fmt.Sprintf(identityPath, sender, userNumber)))
+		assert.Equal(t, fmt.Sprintf("App %s", apiKey), r.Header.Get("Authorization"))
+		parsedBody, servErr := ioutil.ReadAll(r.Body)
+		assert.Nil(t, servErr)
+		assert.JSONEq(t, `{"hash": "Sjvjlx8G6Z0="}`, string(parsedBody))
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer serv.Close()
+
+	whatsApp := Channel{ReqHandler: internal.HTTPHandler{
+		HTTPClient: http.Client{},
+		BaseURL:    serv.URL,
+		APIKey:     apiKey,
+	}}
+
+	respDetails, err := whatsApp.ConfirmIdentity(
+		context.Background(), sender, userNumber, models.WAIdentityConfirmation{Hash: "Sjvjlx8G6Z0="})
+
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, respDetails.HTTPResponse.StatusCode)
+}
+
+func TestConfirmIdentityInvalidReq(t *testing.T) {
+	whatsApp := Channel{ReqHandler: internal.HTTPHandler{
+		HTTPClient: http.Client{},
+		BaseURL:    "https://something.api.infobip.com",
+		APIKey:     "secret",
+	}}
+
+	respDetails, err := whatsApp.ConfirmIdentity(
+		context.Background(), "441134960000", "441134960001", models.WAIdentityConfirmation{})
+
+	require.NotNil(t, err)
+	assert.IsType(t, err, validator.ValidationErrors{})
+	assert.Equal(t, models.ResponseDetails{}, respDetails)
+}
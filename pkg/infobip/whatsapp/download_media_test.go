@@ -0,0 +1,82 @@
+package whatsapp
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/infobip-community/infobip-api-go-sdk/v3/internal"
+	"github.com/infobip-community/infobip-api-go-sdk/v3/pkg/infobip/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDownloadMediaValidReq(t *testing.T) {
+	apiKey := "secret"
+	sender := "441134960000"
+	mediaID := "1234567890"
+	content := []byte("%PDF-1.4 fake document")
+
+	serv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.True(t, strings.HasSuffix(r.URL.Path, fmt.Sprintf(mediaPath, sender, mediaID)))
+		assert.Equal(t, fmt.Sprintf("App %s", apiKey), r.Header.Get("Authorization"))
+
+		w.Header().Set("Content-Type", "application/pdf")
+		_, servErr := w.Write(content)
+		assert.Nil(t, servErr)
+	}))
+	defer serv.Close()
+
+	whatsApp := Channel{ReqHandler: internal.HTTPHandler{
+		HTTPClient: http.Client{},
+		BaseURL:    serv.URL,
+		APIKey:     apiKey,
+	}}
+
+	body, respDetails, err := whatsApp.DownloadMedia(context.Background(), sender, mediaID)
+	require.NoError(t, err)
+	defer body.Close()
+
+	received, err := ioutil.ReadAll(body)
+	require.NoError(t, err)
+	assert.Equal(t, content, received)
+	assert.Equal(t, http.StatusOK, respDetails.HTTPResponse.StatusCode)
+	assert.Equal(t, "application/pdf", respDetails.HTTPResponse.Header.Get("Content-Type"))
+}
+
+func TestDownloadMedia4xxErrors(t *testing.T) {
+	rawJSONResp := []byte(`{
+		"requestError": {
+			"serviceException": {
+				"messageId": "NOT_FOUND",
+				"text": "Media not found"
+			}
+		}
+	}`)
+
+	serv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, servErr := w.Write(rawJSONResp)
+		assert.Nil(t, servErr)
+	}))
+	defer serv.Close()
+
+	whatsApp := Channel{ReqHandler: internal.HTTPHandler{
+		HTTPClient: http.Client{},
+		BaseURL:    serv.URL,
+		APIKey:     "secret",
+	}}
+
+	body, respDetails, err := whatsApp.DownloadMedia(context.Background(), "441134960000", "missing")
+
+	require.NoError(t, err)
+	assert.Nil(t, body)
+	assert.Equal(t, http.StatusNotFound, respDetails.HTTPResponse.StatusCode)
+	assert.Equal(t, "Media not found", respDetails.ErrorResponse.RequestError.ServiceException.Text)
+	assert.NotEqual(t, models.ErrorDetails{}, respDetails.ErrorResponse)
+}
@@ -0,0 +1,107 @@
+package whatsapp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/infobip-community/infobip-api-go-sdk/v3/internal"
+	"github.com/infobip-community/infobip-api-go-sdk/v3/pkg/infobip/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEditTemplateValidReq(t *testing.T) {
+	sender := "16175551213"
+	templateID := "111"
+	apiKey := "secret"
+	template := models.TemplateEdit{
+		Category: "UTILITY",
+		Structure: models.TemplateStructure{
+			Body: &models.TemplateStructureBody{
+				Text: "new body {{1}} content",
+			},
+			Type: "TEXT",
+		},
+	}
+	rawJSONResp := []byte(`
+		{
+		  "id": "111",
+		  "businessAccountId": 222,
+		  "name": "template_name",
+		  "language": "en",
+		  "status": "PENDING",
+		  "category": "UTILITY",
+		  "structure": {
+			"body": {
+			  "text": "new body {{1}} content"
+			},
+			"type": "TEXT"
+		  }
+		}
+	`)
+	var expectedResp models.CreateWATemplateResponse
+	err := json.Unmarshal(rawJSONResp, &expectedResp)
+	require.NoError(t, err)
+
+	serv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPatch, r.Method)
+		assert.True(t, strings.HasSuffix(r.URL.Path, fmt.Sprintf(editTemplatePath, sender, templateID)))
+		assert.Equal(t, fmt.Sprintf("App %s", apiKey), r.Header.Get("Authorization"))
+		parsedBody, servErr := ioutil.ReadAll(r.Body)
+		assert.Nil(t, servErr)
+
+		var receivedMsg models.TemplateEdit
+		servErr = json.Unmarshal(parsedBody, &receivedMsg)
+		assert.Nil(t, servErr)
+		assert.Equal(t, receivedMsg, template)
+
+		_, servErr = w.Write(rawJSONResp)
+		assert.Nil(t, servErr)
+	}))
+	defer serv.Close()
+
+	var whatsApp TemplateEditor = &Channel{ReqHandler: internal.HTTPHandler{
+		HTTPClient: http.Client{},
+		BaseURL:    serv.URL,
+		APIKey:     apiKey,
+	}}
+
+	messageResponse, respDetails, err := whatsApp.EditTemplate(context.Background(), sender, templateID, template)
+
+	require.NoError(t, err)
+	assert.Equal(t, expectedResp, messageResponse)
+	assert.NotNil(t, respDetails)
+	assert.Equal(t, http.StatusOK, respDetails.HTTPResponse.StatusCode)
+	assert.Equal(t, models.ErrorDetails{}, respDetails.ErrorResponse)
+}
+
+func TestInvalidEditTemplate(t *testing.T) {
+	template := models.TemplateEdit{
+		Category: "invalid",
+		Structure: models.TemplateStructure{
+			Body: &models.TemplateStructureBody{
+				Text: "body {{1}} content",
+			},
+			Type: "TEXT",
+		},
+	}
+	var whatsApp TemplateEditor = &Channel{ReqHandler: internal.HTTPHandler{
+		HTTPClient: http.Client{},
+		BaseURL:    "https://something.api.infobip.com",
+		APIKey:     "secret",
+	}}
+
+	messageResponse, respDetails, err := whatsApp.EditTemplate(context.Background(), "16175551213", "111", template)
+
+	require.NotNil(t, err)
+	assert.IsType(t, err, validator.ValidationErrors{})
+	assert.Equal(t, models.CreateWATemplateResponse{}, messageResponse)
+	assert.Equal(t, models.ResponseDetails{}, respDetails)
+}
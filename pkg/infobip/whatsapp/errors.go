@@ -0,0 +1,50 @@
+package whatsapp
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrUnsupportedClient is returned when a WhatsApp client lacks a method kept out of the WhatsApp interface, such
+// as EditTemplate of TemplateEditor.
+var ErrUnsupportedClient = errors.New("WhatsApp client does not support the method")
+
+// TemplateNotFoundError is returned when a sender has no template with the given name and language.
+// Languages lists the languages in which a template with that name is available, if any.
+type TemplateNotFoundError struct {
+	Sender    string
+	Name      string
+	Language  string
+	Languages []string
+}
+
+func (e *TemplateNotFoundError) Error() string {
+	if len(e.Languages) > 0 {
+		return fmt.Sprintf("template %s of sender %s is not available in %s, available languages: %s",
+			e.Name, e.Sender, e.Language, strings.Join(e.Languages, ", "))
+	}
+	return fmt.Sprintf("sender %s has no template %s", e.Sender, e.Name)
+}
+
+// TemplateMismatchError lists every difference found between a template message and the registered template.
+type TemplateMismatchError struct {
+	Name     string
+	Language string
+	Problems []TemplateProblem
+}
+
+// TemplateProblem is a single difference between a template message and the registered template.
+// Field is the JSON path of the offending value in the message, e.g. templateData.buttons[1].type.
+type TemplateProblem struct {
+	Field   string
+	Message string
+}
+
+func (e *TemplateMismatchError) Error() string {
+	problems := make([]string, 0, len(e.Problems))
+	for _, problem := range e.Problems {
+		problems = append(problems, problem.Field+": "+problem.Message)
+	}
+	return fmt.Sprintf("message does not match template %s (%s): %s", e.Name, e.Language, strings.Join(problems, "; "))
+}
@@ -0,0 +1,56 @@
+package whatsapp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/infobip-community/infobip-api-go-sdk/v3/internal"
+	"github.com/infobip-community/infobip-api-go-sdk/v3/pkg/infobip/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetBusinessProfileValidReq(t *testing.T) {
+	apiKey := "secret"
+	sender := "441134960000"
+	rawJSONResp := []byte(`{
+		"about": "Customer support",
+		"address": "Zagrebacka 80, Vodnjan",
+		"description": "Global cloud communications platform",
+		"email": "support@example.com",
+		"websites": ["https://www.example.com"],
+		"vertical": "PROFESSIONAL_SERVICES",
+		"logoUrl": "https://www.example.com/logo.png"
+	}`)
+	var expectedResp models.WABusinessProfile
+	err := json.Unmarshal(rawJSONResp, &expectedResp)
+	require.NoError(t, err)
+
+	serv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.True(t, strings.HasSuffix(r.URL.Path, fmt.Sprintf(businessProfilePath, sender)))
+		assert.Equal(t, fmt.Sprintf("App %s", apiKey), r.Header.Get("Authorization"))
+
+		_, servErr := w.Write(rawJSONResp)
+		assert.Nil(t, servErr)
+	}))
+	defer serv.Close()
+
+	whatsApp := Channel{ReqHandler: internal.HTTPHandler{
+		HTTPClient: http.Client{},
+		BaseURL:    serv.URL,
+		APIKey:     apiKey,
+	}}
+
+	resp, respDetails, err := whatsApp.GetBusinessProfile(context.Background(), sender)
+
+	require.NoError(t, err)
+	assert.Equal(t, expectedResp, resp)
+	assert.Equal(t, http.StatusOK, respDetails.HTTPResponse.StatusCode)
+	assert.Equal(t, models.ErrorDetails{}, respDetails.ErrorResponse)
+}
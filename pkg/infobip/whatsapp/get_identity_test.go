@@ -0,0 +1,43 @@
+package whatsapp
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/infobip-community/infobip-api-go-sdk/v3/internal"
+	"github.com/infobip-community/infobip-api-go-sdk/v3/pkg/infobip/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetIdentityValidReq(t *testing.T) {
+	apiKey := "secret"
+	sender := "441134960000"
+	userNumber := "441134960001"
+
+	serv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.True(t, strings.HasSuffix(r.URL.Path, fmt.Sprintf(identityPath, sender, userNumber)))
+		assert.Equal(t, fmt.Sprintf("App %s", apiKey), r.Header.Get("Authorization"))
+
+		_, servErr := w.Write([]byte(`{"hash": "Sjvjlx8G6Z0=", "createdAt": "2022-03-01T10:00:00.000+0000"}`))
+		assert.Nil(t, servErr)
+	}))
+	defer serv.Close()
+
+	whatsApp := Channel{ReqHandler: internal.HTTPHandler{
+		HTTPClient: http.Client{},
+		BaseURL:    serv.URL,
+		APIKey:     apiKey,
+	}}
+
+	resp, respDetails, err := whatsApp.GetIdentity(context.Background(), sender, userNumber)
+
+	require.NoError(t, err)
+	assert.Equal(t, models.WAIdentity{Hash: "Sjvjlx8G6Z0=", CreatedAt: "2022-03-01T10:00:00.000+0000"}, resp)
+	assert.Equal(t, http.StatusOK, respDetails.HTTPResponse.StatusCode)
+}
@@ -0,0 +1,62 @@
+package whatsapp
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/infobip-community/infobip-api-go-sdk/v3/internal"
+	"github.com/infobip-community/infobip-api-go-sdk/v3/pkg/infobip/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetMediaMetadataValidReq(t *testing.T) {
+	apiKey := "secret"
+	sender := "441134960000"
+	mediaID := "1234567890"
+
+	serv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodHead, r.Method)
+		assert.True(t, strings.HasSuffix(r.URL.Path, fmt.Sprintf(mediaPath, sender, mediaID)))
+		assert.Equal(t, fmt.Sprintf("App %s", apiKey), r.Header.Get("Authorization"))
+
+		w.Header().Set("Content-Type", "image/jpeg")
+		w.Header().Set("Content-Length", "2048")
+	}))
+	defer serv.Close()
+
+	whatsApp := Channel{ReqHandler: internal.HTTPHandler{
+		HTTPClient: http.Client{},
+		BaseURL:    serv.URL,
+		APIKey:     apiKey,
+	}}
+
+	resp, respDetails, err := whatsApp.GetMediaMetadata(context.Background(), sender, mediaID)
+
+	require.NoError(t, err)
+	assert.Equal(t, models.WAMediaMetadata{ContentType: "image/jpeg", ContentLength: 2048}, resp)
+	assert.Equal(t, http.StatusOK, respDetails.HTTPResponse.StatusCode)
+}
+
+func TestGetMediaMetadataNotFound(t *testing.T) {
+	serv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer serv.Close()
+
+	whatsApp := Channel{ReqHandler: internal.HTTPHandler{
+		HTTPClient: http.Client{},
+		BaseURL:    serv.URL,
+		APIKey:     "secret",
+	}}
+
+	resp, respDetails, err := whatsApp.GetMediaMetadata(context.Background(), "441134960000", "missing")
+
+	require.NoError(t, err)
+	assert.Equal(t, models.WAMediaMetadata{}, resp)
+	assert.Equal(t, http.StatusNotFound, respDetails.HTTPResponse.StatusCode)
+}
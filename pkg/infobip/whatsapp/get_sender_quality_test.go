@@ -0,0 +1,54 @@
+package whatsapp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/infobip-community/infobip-api-go-sdk/v3/internal"
+	"github.com/infobip-community/infobip-api-go-sdk/v3/pkg/infobip/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetSenderQualityValidReq(t *testing.T) {
+	apiKey := "secret"
+	rawJSONResp := []byte(`{
+		"results": [
+			{"sender": "441134960000", "qualityRating": "GREEN", "status": "CONNECTED", "currentLimit": "TIER_1K"},
+			{"sender": "441134960002", "qualityRating": "RED", "status": "FLAGGED", "currentLimit": "TIER_250"}
+		]
+	}`)
+	var expectedResp models.GetWASenderQualityResponse
+	err := json.Unmarshal(rawJSONResp, &expectedResp)
+	require.NoError(t, err)
+
+	serv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.True(t, strings.HasSuffix(r.URL.Path, senderQualityPath))
+		assert.Equal(t, fmt.Sprintf("App %s", apiKey), r.Header.Get("Authorization"))
+		assert.Equal(t, []string{"441134960000", "441134960002"}, r.URL.Query()["senders"])
+
+		_, servErr := w.Write(rawJSONResp)
+		assert.Nil(t, servErr)
+	}))
+	defer serv.Close()
+
+	whatsApp := Channel{ReqHandler: internal.HTTPHandler{
+		HTTPClient: http.Client{},
+		BaseURL:    serv.URL,
+		APIKey:     apiKey,
+	}}
+
+	resp, respDetails, err := whatsApp.GetSenderQuality(
+		context.Background(), []string{"441134960000", "441134960002"})
+
+	require.NoError(t, err)
+	assert.Equal(t, expectedResp, resp)
+	assert.Equal(t, models.WAQualityRed, resp.Results[1].QualityRating)
+	assert.Equal(t, http.StatusOK, respDetails.HTTPResponse.StatusCode)
+}
@@ -0,0 +1,77 @@
+package whatsapp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/infobip-community/infobip-api-go-sdk/v3/internal"
+	"github.com/infobip-community/infobip-api-go-sdk/v3/pkg/infobip/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetSendersValidReq(t *testing.T) {
+	apiKey := "secret"
+	rawJSONResp := []byte(`{
+		"senders": [
+			{
+				"sender": "441134960000",
+				"displayName": "Infobip",
+				"status": "CONNECTED",
+				"qualityRating": "GREEN",
+				"currentLimit": "TIER_10K"
+			}
+		],
+		"paging": {"page": 1, "size": 20, "totalPages": 2, "totalResults": 21}
+	}`)
+	var expectedResp models.GetWASendersResponse
+	err := json.Unmarshal(rawJSONResp, &expectedResp)
+	require.NoError(t, err)
+
+	serv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.True(t, strings.HasSuffix(r.URL.Path, sendersPath))
+		assert.Equal(t, fmt.Sprintf("App %s", apiKey), r.Header.Get("Authorization"))
+		assert.Equal(t, "1", r.URL.Query().Get("page"))
+		assert.Equal(t, "20", r.URL.Query().Get("size"))
+
+		_, servErr := w.Write(rawJSONResp)
+		assert.Nil(t, servErr)
+	}))
+	defer serv.Close()
+
+	var whatsApp SenderManagement = &Channel{ReqHandler: internal.HTTPHandler{
+		HTTPClient: http.Client{},
+		BaseURL:    serv.URL,
+		APIKey:     apiKey,
+	}}
+
+	resp, respDetails, err := whatsApp.GetSenders(context.Background(), models.GetWASendersParams{Page: 1, Size: 20})
+
+	require.NoError(t, err)
+	assert.Equal(t, expectedResp, resp)
+	assert.Equal(t, 2, resp.Paging.TotalPages)
+	assert.Equal(t, http.StatusOK, respDetails.HTTPResponse.StatusCode)
+	assert.Equal(t, models.ErrorDetails{}, respDetails.ErrorResponse)
+}
+
+func TestGetSendersInvalidParams(t *testing.T) {
+	whatsApp := Channel{ReqHandler: internal.HTTPHandler{
+		HTTPClient: http.Client{},
+		BaseURL:    "https://something.api.infobip.com",
+		APIKey:     "secret",
+	}}
+
+	resp, respDetails, err := whatsApp.GetSenders(context.Background(), models.GetWASendersParams{Size: 1000})
+
+	require.NotNil(t, err)
+	assert.IsType(t, err, validator.ValidationErrors{})
+	assert.Equal(t, models.GetWASendersResponse{}, resp)
+	assert.Equal(t, models.ResponseDetails{}, respDetails)
+}
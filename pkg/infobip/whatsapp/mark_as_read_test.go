@@ -0,0 +1,70 @@
+package whatsapp
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/infobip-community/infobip-api-go-sdk/v3/internal"
+	"github.com/infobip-community/infobip-api-go-sdk/v3/pkg/infobip/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarkAsReadValidReq(t *testing.T) {
+	apiKey := "secret"
+	sender := "441134960000"
+	messageID := "a28dd97c-1ffb-4fcf-99f1-0b557ed381da"
+
+	serv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.True(t, strings.HasSuffix(r.URL.Path, fmt.Sprintf(markAsReadPath, sender, messageID)))
+		assert.Equal(t, fmt.Sprintf("App %s", apiKey), r.Header.Get("Authorization"))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer serv.Close()
+
+	whatsApp := Channel{ReqHandler: internal.HTTPHandler{
+		HTTPClient: http.Client{},
+		BaseURL:    serv.URL,
+		APIKey:     apiKey,
+	}}
+
+	respDetails, err := whatsApp.MarkAsRead(context.Background(), sender, messageID)
+
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, respDetails.HTTPResponse.StatusCode)
+	assert.Equal(t, models.ErrorDetails{}, respDetails.ErrorResponse)
+}
+
+func TestMarkAsRead4xxErrors(t *testing.T) {
+	rawJSONResp := []byte(`{
+		"requestError": {
+			"serviceException": {
+				"messageId": "NOT_FOUND",
+				"text": "Message not found"
+			}
+		}
+	}`)
+	serv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, servErr := w.Write(rawJSONResp)
+		assert.Nil(t, servErr)
+	}))
+	defer serv.Close()
+
+	whatsApp := Channel{ReqHandler: internal.HTTPHandler{
+		HTTPClient: http.Client{},
+		BaseURL:    serv.URL,
+		APIKey:     "secret",
+	}}
+
+	respDetails, err := whatsApp.MarkAsRead(context.Background(), "441134960000", "missing")
+
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, respDetails.HTTPResponse.StatusCode)
+	assert.Equal(t, "NOT_FOUND", respDetails.ErrorResponse.RequestError.ServiceException.MessageID)
+}
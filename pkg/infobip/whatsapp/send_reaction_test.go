@@ -0,0 +1,169 @@
+package whatsapp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/infobip-community/infobip-api-go-sdk/v3/internal"
+	"github.com/infobip-community/infobip-api-go-sdk/v3/pkg/infobip/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReactionValidReq(t *testing.T) {
+	apiKey := "secret"
+	msg := models.WAReactionMsg{
+		MsgCommon: models.GenerateTestMsgCommon(),
+		Content:   models.ReactionContent{MessageID: "a28dd97c-1ffb-4fcf-99f1-0b557ed381da", Reaction: "👍"},
+	}
+	rawJSONResp := []byte(`{
+		"to": "441134960001",
+		"messageCount": 1,
+		"messageId": "a28dd97c-1ffb-4fcf-99f1-0b557ed381da",
+		"status": {
+			"groupId": 1,
+			"groupName": "PENDING",
+			"id": 7,
+			"name": "PENDING_ENROUTE",
+			"description": "Message sent to next instance"
+		}
+	}`)
+	var expectedResp models.SendWAMsgResponse
+	err := json.Unmarshal(rawJSONResp, &expectedResp)
+	require.NoError(t, err)
+
+	serv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.True(t, strings.HasSuffix(r.URL.Path, sendReactionPath))
+		assert.Equal(t, fmt.Sprintf("App %s", apiKey), r.Header.Get("Authorization"))
+		parsedBody, servErr := ioutil.ReadAll(r.Body)
+		assert.Nil(t, servErr)
+
+		var receivedMsg models.WAReactionMsg
+		servErr = json.Unmarshal(parsedBody, &receivedMsg)
+		assert.Nil(t, servErr)
+		assert.Equal(t, receivedMsg, msg)
+
+		_, servErr = w.Write(rawJSONResp)
+		assert.Nil(t, servErr)
+	}))
+	defer serv.Close()
+	var whatsApp ConversationActions = &Channel{ReqHandler: internal.HTTPHandler{
+		HTTPClient: http.Client{},
+		BaseURL:    serv.URL,
+		APIKey:     apiKey,
+	}}
+
+	msgResp, respDetails, err := whatsApp.SendReaction(context.Background(), msg)
+
+	require.NoError(t, err)
+	assert.NotEqual(t, models.SendWAMsgResponse{}, msgResp)
+	assert.Equal(t, expectedResp, msgResp)
+	assert.NotNil(t, respDetails)
+	assert.Equal(t, http.StatusOK, respDetails.HTTPResponse.StatusCode)
+	assert.Equal(t, models.ErrorDetails{}, respDetails.ErrorResponse)
+}
+
+func TestInvalidReactionMsg(t *testing.T) {
+	msg := models.WAReactionMsg{
+		MsgCommon: models.GenerateTestMsgCommon(),
+		Content:   models.ReactionContent{MessageID: "a28dd97c-1ffb-4fcf-99f1-0b557ed381da", Reaction: "hello"},
+	}
+	whatsApp := Channel{ReqHandler: internal.HTTPHandler{
+		HTTPClient: http.Client{},
+		BaseURL:    "https://something.api.infobip.com",
+		APIKey:     "secret",
+	}}
+
+	msgResp, respDetails, err := whatsApp.SendReaction(context.Background(), msg)
+
+	require.NotNil(t, err)
+	assert.IsType(t, err, validator.ValidationErrors{})
+	assert.Equal(t, models.SendWAMsgResponse{}, msgResp)
+	assert.Equal(t, models.ResponseDetails{}, respDetails)
+}
+
+func TestReaction4xxErrors(t *testing.T) {
+	tests := []struct {
+		rawJSONResp []byte
+		statusCode  int
+	}{
+		{
+			rawJSONResp: []byte(`{
+				"requestError": {
+					"serviceException": {
+						"messageId": "BAD_REQUEST",
+						"text": "Bad request",
+						"validationErrors": {
+							"content.messageId": [
+								"must not be blank"
+							]
+						}
+					}
+				}
+			}`),
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			rawJSONResp: []byte(`{
+				"requestError": {
+					"serviceException": {
+						"messageId": "UNAUTHORIZED",
+						"text": "Invalid login details"
+					}
+				}
+			}`),
+			statusCode: http.StatusUnauthorized,
+		},
+		{
+			rawJSONResp: []byte(`{
+				"requestError": {
+					"serviceException": {
+						"messageId": "TOO_MANY_REQUESTS",
+						"text": "Too many requests"
+					}
+				}
+			}`),
+			statusCode: http.StatusTooManyRequests,
+		},
+	}
+	msg := models.WAReactionMsg{
+		MsgCommon: models.GenerateTestMsgCommon(),
+		Content:   models.ReactionContent{MessageID: "a28dd97c-1ffb-4fcf-99f1-0b557ed381da", Reaction: "👍"},
+	}
+
+	for _, tc := range tests {
+		t.Run(strconv.Itoa(tc.statusCode), func(t *testing.T) {
+			var expectedResp models.ErrorDetails
+			err := json.Unmarshal(tc.rawJSONResp, &expectedResp)
+			require.NoError(t, err)
+			serv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tc.statusCode)
+				_, servErr := w.Write(tc.rawJSONResp)
+				assert.Nil(t, servErr)
+			}))
+			whatsApp := Channel{ReqHandler: internal.HTTPHandler{
+				HTTPClient: http.Client{},
+				BaseURL:    serv.URL,
+				APIKey:     "secret",
+			}}
+
+			msgResp, respDetails, err := whatsApp.SendReaction(context.Background(), msg)
+			serv.Close()
+
+			require.NoError(t, err)
+			assert.NotEqual(t, http.Response{}, respDetails.HTTPResponse)
+			assert.NotEqual(t, models.ErrorDetails{}, respDetails.ErrorResponse)
+			assert.Equal(t, expectedResp, respDetails.ErrorResponse)
+			assert.Equal(t, tc.statusCode, respDetails.HTTPResponse.StatusCode)
+			assert.Equal(t, models.SendWAMsgResponse{}, msgResp)
+		})
+	}
+}
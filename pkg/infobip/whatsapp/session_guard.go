@@ -0,0 +1,271 @@
+package whatsapp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/infobip-community/infobip-api-go-sdk/v3/pkg/infobip/models"
+)
+
+// SessionGuard wraps a WhatsApp client and checks the customer service window before sending free-form messages.
+// Outside the window, a free-form message fails with a SessionExpiredError without calling the API, or is replaced
+// by the fallback template when one is configured. Other methods are passed to the wrapped client. Methods of
+// ConversationActions and InteractiveRequests fail with ErrUnsupportedClient when the wrapped client doesn't
+// implement the interface.
+type SessionGuard struct {
+	WhatsApp
+	tracker  *SessionTracker
+	fallback *models.TemplateMsgContent
+}
+
+// NewSessionGuard wraps the client. The fallback template is optional; when set, it is sent with the sender,
+// destination, message ID, callback data and notify URL of the replaced message, and its response is returned.
+func NewSessionGuard(
+	client WhatsApp,
+	tracker *SessionTracker,
+	fallback *models.TemplateMsgContent,
+) *SessionGuard {
+	return &SessionGuard{WhatsApp: client, tracker: tracker, fallback: fallback}
+}
+
+// guard returns handled as true when the message must not be sent as is, along with the outcome of the fallback.
+func (g *SessionGuard) guard(
+	ctx context.Context,
+	msg models.MsgCommon,
+) (resp models.SendWAMsgResponse, respDetails models.ResponseDetails, handled bool, err error) {
+	err = g.tracker.checkWindow(ctx, msg.To)
+	if err == nil {
+		return resp, respDetails, false, nil
+	}
+	var expired *SessionExpiredError
+	if g.fallback == nil || !errors.As(err, &expired) {
+		return resp, respDetails, true, err
+	}
+
+	bulkResp, respDetails, err := g.WhatsApp.SendTemplate(ctx, models.WATemplateMsgs{
+		Messages: []models.TemplateMsg{{MsgCommon: msg, Content: *g.fallback}},
+	})
+	if len(bulkResp.Messages) > 0 {
+		resp = bulkResp.Messages[0]
+	}
+	return resp, respDetails, true, err
+}
+
+func (g *SessionGuard) SendText(
+	ctx context.Context,
+	msg models.WATextMsg,
+) (models.SendWAMsgResponse, models.ResponseDetails, error) {
+	if resp, respDetails, handled, err := g.guard(ctx, msg.MsgCommon); handled {
+		return resp, respDetails, err
+	}
+	return g.WhatsApp.SendText(ctx, msg)
+}
+
+func (g *SessionGuard) SendDocument(
+	ctx context.Context,
+	msg models.WADocumentMsg,
+) (models.SendWAMsgResponse, models.ResponseDetails, error) {
+	if resp, respDetails, handled, err := g.guard(ctx, msg.MsgCommon); handled {
+		return resp, respDetails, err
+	}
+	return g.WhatsApp.SendDocument(ctx, msg)
+}
+
+func (g *SessionGuard) SendImage(
+	ctx context.Context,
+	msg models.WAImageMsg,
+) (models.SendWAMsgResponse, models.ResponseDetails, error) {
+	if resp, respDetails, handled, err := g.guard(ctx, msg.MsgCommon); handled {
+		return resp, respDetails, err
+	}
+	return g.WhatsApp.SendImage(ctx, msg)
+}
+
+func (g *SessionGuard) SendAudio(
+	ctx context.Context,
+	msg models.WAAudioMsg,
+) (models.SendWAMsgResponse, models.ResponseDetails, error) {
+	if resp, respDetails, handled, err := g.guard(ctx, msg.MsgCommon); handled {
+		return resp, respDetails, err
+	}
+	return g.WhatsApp.SendAudio(ctx, msg)
+}
+
+func (g *SessionGuard) SendVideo(
+	ctx context.Context,
+	msg models.WAVideoMsg,
+) (models.SendWAMsgResponse, models.ResponseDetails, error) {
+	if resp, respDetails, handled, err := g.guard(ctx, msg.MsgCommon); handled {
+		return resp, respDetails, err
+	}
+	return g.WhatsApp.SendVideo(ctx, msg)
+}
+
+func (g *SessionGuard) SendSticker(
+	ctx context.Context,
+	msg models.WAStickerMsg,
+) (models.SendWAMsgResponse, models.ResponseDetails, error) {
+	if resp, respDetails, handled, err := g.guard(ctx, msg.MsgCommon); handled {
+		return resp, respDetails, err
+	}
+	return g.WhatsApp.SendSticker(ctx, msg)
+}
+
+func (g *SessionGuard) SendLocation(
+	ctx context.Context,
+	msg models.WALocationMsg,
+) (models.SendWAMsgResponse, models.ResponseDetails, error) {
+	if resp, respDetails, handled, err := g.guard(ctx, msg.MsgCommon); handled {
+		return resp, respDetails, err
+	}
+	return g.WhatsApp.SendLocation(ctx, msg)
+}
+
+func (g *SessionGuard) SendContact(
+	ctx context.Context,
+	msg models.WAContactMsg,
+) (models.SendWAMsgResponse, models.ResponseDetails, error) {
+	if resp, respDetails, handled, err := g.guard(ctx, msg.MsgCommon); handled {
+		return resp, respDetails, err
+	}
+	return g.WhatsApp.SendContact(ctx, msg)
+}
+
+func (g *SessionGuard) SendReaction(
+	ctx context.Context,
+	msg models.WAReactionMsg,
+) (models.SendWAMsgResponse, models.ResponseDetails, error) {
+	actions, err := g.conversationActions()
+	if err != nil {
+		return models.SendWAMsgResponse{}, models.ResponseDetails{}, err
+	}
+	if resp, respDetails, handled, guardErr := g.guard(ctx, msg.MsgCommon); handled {
+		return resp, respDetails, guardErr
+	}
+	return actions.SendReaction(ctx, msg)
+}
+
+// MarkAsRead is passed to the wrapped client without checking the window.
+func (g *SessionGuard) MarkAsRead(
+	ctx context.Context,
+	sender string,
+	messageID string,
+) (models.ResponseDetails, error) {
+	actions, err := g.conversationActions()
+	if err != nil {
+		return models.ResponseDetails{}, err
+	}
+	return actions.MarkAsRead(ctx, sender, messageID)
+}
+
+// SendTypingIndicator is passed to the wrapped client without checking the window.
+func (g *SessionGuard) SendTypingIndicator(
+	ctx context.Context,
+	sender string,
+	messageID string,
+) (models.ResponseDetails, error) {
+	actions, err := g.conversationActions()
+	if err != nil {
+		return models.ResponseDetails{}, err
+	}
+	return actions.SendTypingIndicator(ctx, sender, messageID)
+}
+
+func (g *SessionGuard) conversationActions() (ConversationActions, error) {
+	actions, ok := g.WhatsApp.(ConversationActions)
+	if !ok {
+		return nil, fmt.Errorf("%w: %T does not implement ConversationActions", ErrUnsupportedClient, g.WhatsApp)
+	}
+	return actions, nil
+}
+
+func (g *SessionGuard) SendInteractiveButtons(
+	ctx context.Context,
+	msg models.WAInteractiveButtonsMsg,
+) (models.SendWAMsgResponse, models.ResponseDetails, error) {
+	if resp, respDetails, handled, err := g.guard(ctx, msg.MsgCommon); handled {
+		return resp, respDetails, err
+	}
+	return g.WhatsApp.SendInteractiveButtons(ctx, msg)
+}
+
+func (g *SessionGuard) SendInteractiveList(
+	ctx context.Context,
+	msg models.WAInteractiveListMsg,
+) (models.SendWAMsgResponse, models.ResponseDetails, error) {
+	if resp, respDetails, handled, err := g.guard(ctx, msg.MsgCommon); handled {
+		return resp, respDetails, err
+	}
+	return g.WhatsApp.SendInteractiveList(ctx, msg)
+}
+
+func (g *SessionGuard) SendInteractiveProduct(
+	ctx context.Context,
+	msg models.WAInteractiveProductMsg,
+) (models.SendWAMsgResponse, models.ResponseDetails, error) {
+	if resp, respDetails, handled, err := g.guard(ctx, msg.MsgCommon); handled {
+		return resp, respDetails, err
+	}
+	return g.WhatsApp.SendInteractiveProduct(ctx, msg)
+}
+
+func (g *SessionGuard) SendInteractiveMultiproduct(
+	ctx context.Context,
+	msg models.WAInteractiveMultiproductMsg,
+) (models.SendWAMsgResponse, models.ResponseDetails, error) {
+	if resp, respDetails, handled, err := g.guard(ctx, msg.MsgCommon); handled {
+		return resp, respDetails, err
+	}
+	return g.WhatsApp.SendInteractiveMultiproduct(ctx, msg)
+}
+
+func (g *SessionGuard) SendInteractiveLocationRequest(
+	ctx context.Context,
+	msg models.WAInteractiveLocationRequestMsg,
+) (models.SendWAMsgResponse, models.ResponseDetails, error) {
+	requests, err := g.interactiveRequests()
+	if err != nil {
+		return models.SendWAMsgResponse{}, models.ResponseDetails{}, err
+	}
+	if resp, respDetails, handled, guardErr := g.guard(ctx, msg.MsgCommon); handled {
+		return resp, respDetails, guardErr
+	}
+	return requests.SendInteractiveLocationRequest(ctx, msg)
+}
+
+func (g *SessionGuard) SendInteractiveURLButton(
+	ctx context.Context,
+	msg models.WAInteractiveURLButtonMsg,
+) (models.SendWAMsgResponse, models.ResponseDetails, error) {
+	requests, err := g.interactiveRequests()
+	if err != nil {
+		return models.SendWAMsgResponse{}, models.ResponseDetails{}, err
+	}
+	if resp, respDetails, handled, guardErr := g.guard(ctx, msg.MsgCommon); handled {
+		return resp, respDetails, guardErr
+	}
+	return requests.SendInteractiveURLButton(ctx, msg)
+}
+
+func (g *SessionGuard) SendInteractiveFlow(
+	ctx context.Context,
+	msg models.WAInteractiveFlowMsg,
+) (models.SendWAMsgResponse, models.ResponseDetails, error) {
+	requests, err := g.interactiveRequests()
+	if err != nil {
+		return models.SendWAMsgResponse{}, models.ResponseDetails{}, err
+	}
+	if resp, respDetails, handled, guardErr := g.guard(ctx, msg.MsgCommon); handled {
+		return resp, respDetails, guardErr
+	}
+	return requests.SendInteractiveFlow(ctx, msg)
+}
+
+func (g *SessionGuard) interactiveRequests() (InteractiveRequests, error) {
+	requests, ok := g.WhatsApp.(InteractiveRequests)
+	if !ok {
+		return nil, fmt.Errorf("%w: %T does not implement InteractiveRequests", ErrUnsupportedClient, g.WhatsApp)
+	}
+	return requests, nil
+}
@@ -0,0 +1,140 @@
+package whatsapp
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/infobip-community/infobip-api-go-sdk/v3/internal"
+	"github.com/infobip-community/infobip-api-go-sdk/v3/pkg/infobip/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestGuardServer(t *testing.T, paths *[]string) *Channel {
+	serv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*paths = append(*paths, r.URL.Path)
+		var resp interface{} = models.SendWAMsgResponse{To: "441134960001", MessageID: "text-id"}
+		if r.URL.Path == "/"+sendTemplateMessagesPath {
+			body, err := ioutil.ReadAll(r.Body)
+			assert.NoError(t, err)
+			var msgs models.WATemplateMsgs
+			assert.NoError(t, json.Unmarshal(body, &msgs))
+			assert.Equal(t, "reopen_conversation", msgs.Messages[0].Content.TemplateName)
+			assert.Equal(t, "441134960001", msgs.Messages[0].To)
+			assert.Equal(t, "callback", msgs.Messages[0].CallbackData)
+			resp = models.BulkWAMsgResponse{
+				Messages: []models.SendWAMsgResponse{{To: "441134960001", MessageID: "template-id"}},
+			}
+		}
+		err := json.NewEncoder(w).Encode(resp)
+		assert.NoError(t, err)
+	}))
+	t.Cleanup(serv.Close)
+
+	return &Channel{ReqHandler: internal.HTTPHandler{
+		HTTPClient: http.Client{},
+		BaseURL:    serv.URL,
+		APIKey:     "secret",
+	}}
+}
+
+func TestSessionGuardOpenWindow(t *testing.T) {
+	var paths []string
+	tracker, now := newTestTracker()
+	require.NoError(t, tracker.RecordInbound(context.Background(), "441134960001", now.Add(-time.Hour)))
+	guard := NewSessionGuard(newTestGuardServer(t, &paths), tracker, nil)
+
+	resp, respDetails, err := guard.SendText(context.Background(), models.WATextMsg{
+		MsgCommon: models.MsgCommon{From: "441134960000", To: "441134960001"},
+		Content:   models.TextContent{Text: "Hi"},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "text-id", resp.MessageID)
+	assert.Equal(t, http.StatusOK, respDetails.HTTPResponse.StatusCode)
+	assert.Equal(t, []string{"/" + sendMessagePath}, paths)
+}
+
+func TestSessionGuardClosedWindow(t *testing.T) {
+	var paths []string
+	tracker, _ := newTestTracker()
+	guard := NewSessionGuard(newTestGuardServer(t, &paths), tracker, nil)
+
+	resp, respDetails, err := guard.SendImage(context.Background(), models.WAImageMsg{
+		MsgCommon: models.MsgCommon{From: "441134960000", To: "441134960001"},
+		Content:   models.ImageContent{MediaURL: "https://example.com/image.png"},
+	})
+
+	var expired *SessionExpiredError
+	require.ErrorAs(t, err, &expired)
+	assert.Equal(t, "441134960001", expired.To)
+	assert.Equal(t, models.SendWAMsgResponse{}, resp)
+	assert.Equal(t, models.ResponseDetails{}, respDetails)
+	assert.Empty(t, paths)
+}
+
+func TestSessionGuardFallbackTemplate(t *testing.T) {
+	var paths []string
+	tracker, now := newTestTracker()
+	require.NoError(t, tracker.RecordInbound(context.Background(), "441134960001", now.Add(-25*time.Hour)))
+	guard := NewSessionGuard(newTestGuardServer(t, &paths), tracker, &models.TemplateMsgContent{
+		TemplateName: "reopen_conversation",
+		TemplateData: models.TemplateData{Body: models.TemplateBody{Placeholders: []string{}}},
+		Language:     "en",
+	})
+
+	resp, _, err := guard.SendText(context.Background(), models.WATextMsg{
+		MsgCommon: models.MsgCommon{From: "441134960000", To: "441134960001", CallbackData: "callback"},
+		Content:   models.TextContent{Text: "Hi"},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "template-id", resp.MessageID)
+	assert.Equal(t, []string{"/" + sendTemplateMessagesPath}, paths)
+}
+
+func TestSessionGuardConversationActions(t *testing.T) {
+	var paths []string
+	tracker, now := newTestTracker()
+	require.NoError(t, tracker.RecordInbound(context.Background(), "441134960001", now.Add(-time.Hour)))
+	channel := newTestGuardServer(t, &paths)
+	var actions ConversationActions = NewSessionGuard(channel, tracker, nil)
+
+	_, err := actions.MarkAsRead(context.Background(), "441134960000", "inbound-id")
+	require.NoError(t, err)
+	assert.Len(t, paths, 1)
+
+	guard := NewSessionGuard(struct{ WhatsApp }{channel}, tracker, nil)
+	_, _, err = guard.SendReaction(context.Background(), models.WAReactionMsg{
+		MsgCommon: models.MsgCommon{From: "441134960000", To: "441134960001"},
+	})
+	require.ErrorIs(t, err, ErrUnsupportedClient)
+	_, err = guard.SendTypingIndicator(context.Background(), "441134960000", "inbound-id")
+	require.ErrorIs(t, err, ErrUnsupportedClient)
+	assert.Len(t, paths, 1)
+}
+
+func TestSessionGuardInteractiveRequests(t *testing.T) {
+	var paths []string
+	tracker, _ := newTestTracker()
+	channel := newTestGuardServer(t, &paths)
+	var requests InteractiveRequests = NewSessionGuard(channel, tracker, nil)
+
+	_, _, err := requests.SendInteractiveFlow(context.Background(), models.WAInteractiveFlowMsg{
+		MsgCommon: models.MsgCommon{From: "441134960000", To: "441134960001"},
+	})
+	var expired *SessionExpiredError
+	require.ErrorAs(t, err, &expired)
+
+	guard := NewSessionGuard(struct{ WhatsApp }{channel}, tracker, nil)
+	_, _, err = guard.SendInteractiveURLButton(context.Background(), models.WAInteractiveURLButtonMsg{
+		MsgCommon: models.MsgCommon{From: "441134960000", To: "441134960001"},
+	})
+	require.ErrorIs(t, err, ErrUnsupportedClient)
+	assert.Empty(t, paths)
+}
@@ -0,0 +1,154 @@
+package whatsapp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/infobip-community/infobip-api-go-sdk/v3/pkg/infobip/models"
+)
+
+// CustomerServiceWindow is the time after the last inbound message of a user during which free-form messages can
+// be sent to them. Outside the window, only template messages are delivered.
+const CustomerServiceWindow = 24 * time.Hour
+
+// receivedAtLayout is the timestamp format of inbound message webhooks, e.g. 2019-07-06T15:29:01.000+0000.
+const receivedAtLayout = "2006-01-02T15:04:05.000-0700"
+
+// SessionStore keeps the time of the last inbound message of each user. Implementations backed by a shared
+// database let several instances of a service track the same sessions, and must be safe for concurrent use.
+type SessionStore interface {
+	// LastInbound returns the time of the last inbound message of the user, and false if none was recorded.
+	LastInbound(ctx context.Context, user string) (time.Time, bool, error)
+	// SetLastInbound records the time of the last inbound message of the user.
+	SetLastInbound(ctx context.Context, user string, at time.Time) error
+}
+
+// MemorySessionStore is a SessionStore keeping the sessions in memory.
+type MemorySessionStore struct {
+	mu   sync.RWMutex
+	last map[string]time.Time
+}
+
+func NewMemorySessionStore() *MemorySessionStore {
+	return &MemorySessionStore{last: map[string]time.Time{}}
+}
+
+func (s *MemorySessionStore) LastInbound(_ context.Context, user string) (time.Time, bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	at, ok := s.last[user]
+	return at, ok, nil
+}
+
+func (s *MemorySessionStore) SetLastInbound(_ context.Context, user string, at time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.last[user] = at
+	return nil
+}
+
+// SessionExpiredError is returned when a free-form message is sent to a user outside of the customer service
+// window. LastInbound is zero when no inbound message of the user was recorded.
+type SessionExpiredError struct {
+	To          string
+	LastInbound time.Time
+}
+
+func (e *SessionExpiredError) Error() string {
+	if e.LastInbound.IsZero() {
+		return fmt.Sprintf("no inbound message from %s, only template messages can be sent", e.To)
+	}
+	return fmt.Sprintf("customer service window of %s closed at %s, only template messages can be sent",
+		e.To, e.LastInbound.Add(CustomerServiceWindow).Format(time.RFC3339))
+}
+
+// SessionTracker tracks the customer service window of each user from the inbound messages they send.
+type SessionTracker struct {
+	store SessionStore
+	now   func() time.Time
+	mu    sync.Mutex
+}
+
+// NewSessionTracker creates a tracker recording sessions in the store, or in memory if the store is nil.
+func NewSessionTracker(store SessionStore) *SessionTracker {
+	if store == nil {
+		store = NewMemorySessionStore()
+	}
+	return &SessionTracker{store: store, now: time.Now}
+}
+
+// RecordInbound records an inbound message of the user received at the given time. Messages older than the last
+// recorded one, e.g. redelivered webhook events, are ignored.
+func (t *SessionTracker) RecordInbound(ctx context.Context, user string, at time.Time) error {
+	user = normalizeUser(user)
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	last, ok, err := t.store.LastInbound(ctx, user)
+	if err != nil {
+		return err
+	}
+	if ok && !at.After(last) {
+		return nil
+	}
+	return t.store.SetLastInbound(ctx, user, at)
+}
+
+// RecordInboundMessages records the messages of an inbound message webhook event.
+func (t *SessionTracker) RecordInboundMessages(ctx context.Context, messages models.WAInboundMessages) error {
+	for _, msg := range messages.Results {
+		at, err := parseReceivedAt(msg.ReceivedAt)
+		if err != nil {
+			return fmt.Errorf("message %s: %w", msg.MessageID, err)
+		}
+		if err = t.RecordInbound(ctx, msg.From, at); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CanSendFreeForm reports whether the customer service window of the user is open.
+func (t *SessionTracker) CanSendFreeForm(ctx context.Context, to string) (bool, error) {
+	err := t.checkWindow(ctx, to)
+	if err == nil {
+		return true, nil
+	}
+	var expired *SessionExpiredError
+	if errors.As(err, &expired) {
+		return false, nil
+	}
+	return false, err
+}
+
+// checkWindow returns a SessionExpiredError when the customer service window of the user is closed.
+func (t *SessionTracker) checkWindow(ctx context.Context, to string) error {
+	last, ok, err := t.store.LastInbound(ctx, normalizeUser(to))
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return &SessionExpiredError{To: to}
+	}
+	if t.now().Sub(last) >= CustomerServiceWindow {
+		return &SessionExpiredError{To: to, LastInbound: last}
+	}
+	return nil
+}
+
+// normalizeUser drops the international prefix, as numbers are sent to the API with or without a leading plus and
+// inbound messages use none.
+func normalizeUser(user string) string {
+	return strings.TrimPrefix(strings.TrimSpace(user), "+")
+}
+
+func parseReceivedAt(receivedAt string) (time.Time, error) {
+	at, err := time.Parse(receivedAtLayout, receivedAt)
+	if err == nil {
+		return at, nil
+	}
+	return time.Parse(time.RFC3339, receivedAt)
+}
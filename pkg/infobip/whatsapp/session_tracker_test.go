@@ -0,0 +1,88 @@
+package whatsapp
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/infobip-community/infobip-api-go-sdk/v3/pkg/infobip/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestTracker() (*SessionTracker, *time.Time) {
+	now := time.Date(2022, 1, 2, 12, 0, 0, 0, time.UTC)
+	tracker := NewSessionTracker(nil)
+	tracker.now = func() time.Time { return now }
+	return tracker, &now
+}
+
+func TestSessionTrackerWindow(t *testing.T) {
+	ctx := context.Background()
+	tracker, now := newTestTracker()
+
+	canSend, err := tracker.CanSendFreeForm(ctx, "441134960001")
+	require.NoError(t, err)
+	assert.False(t, canSend)
+
+	require.NoError(t, tracker.RecordInbound(ctx, "441134960001", now.Add(-23*time.Hour)))
+	canSend, err = tracker.CanSendFreeForm(ctx, "+441134960001")
+	require.NoError(t, err)
+	assert.True(t, canSend)
+
+	*now = now.Add(time.Hour)
+	canSend, err = tracker.CanSendFreeForm(ctx, "441134960001")
+	require.NoError(t, err)
+	assert.False(t, canSend)
+
+	err = tracker.checkWindow(ctx, "441134960001")
+	var expired *SessionExpiredError
+	require.ErrorAs(t, err, &expired)
+	assert.Equal(t, now.Add(-24*time.Hour), expired.LastInbound)
+}
+
+func TestSessionTrackerIgnoresOlderMessages(t *testing.T) {
+	ctx := context.Background()
+	tracker, now := newTestTracker()
+
+	require.NoError(t, tracker.RecordInbound(ctx, "441134960001", now.Add(-time.Hour)))
+	require.NoError(t, tracker.RecordInbound(ctx, "441134960001", now.Add(-30*time.Hour)))
+
+	last, ok, err := tracker.store.LastInbound(ctx, "441134960001")
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, now.Add(-time.Hour), last)
+}
+
+func TestSessionTrackerRecordInboundMessages(t *testing.T) {
+	ctx := context.Background()
+	tracker, now := newTestTracker()
+	rawEvent := []byte(`{
+		"results": [
+			{
+				"from": "441134960001",
+				"to": "441134960000",
+				"integrationType": "WHATSAPP",
+				"receivedAt": "2022-01-02T10:30:00.000+0000",
+				"messageId": "ABGGFlA5FpafAgo6EhmKdDg8Vj",
+				"message": {"type": "TEXT", "text": "Hello"},
+				"contact": {"name": "Frank"}
+			}
+		],
+		"messageCount": 1,
+		"pendingMessageCount": 0
+	}`)
+	var event models.WAInboundMessages
+	require.NoError(t, json.Unmarshal(rawEvent, &event))
+
+	require.NoError(t, tracker.RecordInboundMessages(ctx, event))
+
+	last, ok, err := tracker.store.LastInbound(ctx, "441134960001")
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.True(t, last.Equal(now.Add(-90*time.Minute)))
+
+	event.Results[0].ReceivedAt = "yesterday"
+	assert.Error(t, tracker.RecordInboundMessages(ctx, event))
+}
@@ -0,0 +1,395 @@
+package whatsapp
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/infobip-community/infobip-api-go-sdk/v3/pkg/infobip/models"
+)
+
+// nolint: gochecknoglobals // compiled once, only read afterwards
+var namedVariableRegexp = regexp.MustCompile(`\{\{\s*([A-Za-z_][A-Za-z0-9_]*)\s*\}\}`)
+
+const variableTag = "template"
+
+// TemplateBuilder defines a WhatsApp template once, with named variables such as {{customer_name}} in the header,
+// body and dynamic URL button, and builds both the TemplateCreate payload and the messages sent with the template.
+// Variables are numbered in order of appearance when the template is built, as required by WhatsApp.
+type TemplateBuilder struct {
+	create  models.TemplateCreate
+	header  *headerDefinition
+	buttons []buttonDefinition
+	errs    []error
+}
+
+type headerDefinition struct {
+	format    string
+	variables []string
+}
+
+type buttonDefinition struct {
+	msgType  string
+	variable string
+}
+
+// NewTemplateBuilder starts the definition of a template with the given name, language and category.
+func NewTemplateBuilder(name string, language string, category string) *TemplateBuilder {
+	return &TemplateBuilder{create: models.TemplateCreate{
+		Name:      name,
+		Language:  language,
+		Category:  category,
+		Structure: models.TemplateStructure{Type: "TEXT"},
+	}}
+}
+
+// Body sets the body text, e.g. "Hi {{name}}, your order {{order_id}} has shipped".
+func (b *TemplateBuilder) Body(text string) *TemplateBuilder {
+	b.create.Structure.Body = &models.TemplateStructureBody{Text: text}
+	return b
+}
+
+// Footer sets the footer text. Footers cannot contain variables.
+func (b *TemplateBuilder) Footer(text string) *TemplateBuilder {
+	if namedVariableRegexp.MatchString(text) {
+		b.errs = append(b.errs, errors.New("footer cannot contain variables"))
+	}
+	b.create.Structure.Footer = &models.TemplateStructureFooter{Text: text}
+	return b
+}
+
+// TextHeader sets a text header, which may contain one variable.
+func (b *TemplateBuilder) TextHeader(text string) *TemplateBuilder {
+	variables := variableNames(text)
+	if len(variables) > 1 {
+		b.errs = append(b.errs, errors.New("text header can contain at most one variable"))
+	}
+	b.create.Structure.Header = &models.TemplateHeader{Format: "TEXT", Text: text}
+	b.header = &headerDefinition{format: "TEXT", variables: variables}
+	return b
+}
+
+// ImageHeader sets an image header. The URL of the image is taken from the urlVariable of each message.
+func (b *TemplateBuilder) ImageHeader(urlVariable string) *TemplateBuilder {
+	return b.mediaHeader("IMAGE", urlVariable)
+}
+
+// VideoHeader sets a video header. The URL of the video is taken from the urlVariable of each message.
+func (b *TemplateBuilder) VideoHeader(urlVariable string) *TemplateBuilder {
+	return b.mediaHeader("VIDEO", urlVariable)
+}
+
+// DocumentHeader sets a document header. The URL and file name of the document are taken from the urlVariable and
+// filenameVariable of each message.
+func (b *TemplateBuilder) DocumentHeader(urlVariable string, filenameVariable string) *TemplateBuilder {
+	return b.mediaHeader("DOCUMENT", urlVariable, filenameVariable)
+}
+
+// LocationHeader sets a location header. The coordinates are taken from the latitudeVariable and
+// longitudeVariable of each message.
+func (b *TemplateBuilder) LocationHeader(latitudeVariable string, longitudeVariable string) *TemplateBuilder {
+	return b.mediaHeader("LOCATION", latitudeVariable, longitudeVariable)
+}
+
+func (b *TemplateBuilder) mediaHeader(format string, variables ...string) *TemplateBuilder {
+	b.create.Structure.Header = &models.TemplateHeader{Format: format}
+	b.create.Structure.Type = "MEDIA"
+	b.header = &headerDefinition{format: format, variables: variables}
+	return b
+}
+
+// QuickReplyButton adds a quick reply button. The payload returned when the button is tapped is taken from the
+// payloadVariable of each message.
+func (b *TemplateBuilder) QuickReplyButton(text string, payloadVariable string) *TemplateBuilder {
+	b.create.Structure.Buttons = append(b.create.Structure.Buttons, models.TemplateButton{Type: "QUICK_REPLY", Text: text})
+	b.buttons = append(b.buttons, buttonDefinition{msgType: "QUICK_REPLY", variable: payloadVariable})
+	return b
+}
+
+// URLButton adds a URL button. The URL may end with one variable, e.g. https://example.com/orders/{{order_id}}.
+func (b *TemplateBuilder) URLButton(text string, url string) *TemplateBuilder {
+	button := models.TemplateButton{Type: "URL", Text: text, URL: url}
+	switch variables := variableNames(url); {
+	case len(variables) == 0:
+	case len(variables) > 1 || !endsWithVariable(url):
+		b.errs = append(b.errs, fmt.Errorf("url button %q can only contain one variable, at the end of the url", text))
+	default:
+		button.URL = namedVariableRegexp.ReplaceAllString(url, "{{1}}")
+		b.buttons = append(b.buttons, buttonDefinition{msgType: "URL", variable: variables[0]})
+	}
+	b.create.Structure.Buttons = append(b.create.Structure.Buttons, button)
+	return b
+}
+
+// PhoneNumberButton adds a button which calls the phone number.
+func (b *TemplateBuilder) PhoneNumberButton(text string, phoneNumber string) *TemplateBuilder {
+	b.create.Structure.Buttons = append(b.create.Structure.Buttons,
+		models.TemplateButton{Type: "PHONE_NUMBER", Text: text, PhoneNumber: phoneNumber})
+	return b
+}
+
+// Build numbers the variables and validates the resulting TemplateCreate with the same rules used by
+// CreateTemplate.
+func (b *TemplateBuilder) Build() (*TemplateDefinition, error) {
+	if len(b.errs) > 0 {
+		return nil, b.errs[0]
+	}
+
+	create := b.create
+	structure := create.Structure
+	def := &TemplateDefinition{header: b.header, buttons: b.buttons}
+
+	if structure.Body != nil {
+		def.bodyVariables = variableNames(structure.Body.Text)
+		structure.Body = &models.TemplateStructureBody{Text: numberVariables(structure.Body.Text, def.bodyVariables)}
+	}
+	if structure.Header != nil && structure.Header.Format == "TEXT" {
+		header := *structure.Header
+		header.Text = numberVariables(header.Text, b.header.variables)
+		structure.Header = &header
+	}
+	structure.Buttons = append([]models.TemplateButton(nil), structure.Buttons...)
+	create.Structure = structure
+
+	if err := create.Validate(); err != nil {
+		return nil, err
+	}
+	def.create = create
+	return def, nil
+}
+
+// TemplateDefinition is a built template. It is safe for concurrent use.
+type TemplateDefinition struct {
+	create        models.TemplateCreate
+	bodyVariables []string
+	header        *headerDefinition
+	buttons       []buttonDefinition
+}
+
+// TemplateCreate returns the payload for CreateTemplate.
+func (d *TemplateDefinition) TemplateCreate() models.TemplateCreate {
+	return d.create
+}
+
+// Variables returns the names of every variable a message needs, in order of appearance.
+func (d *TemplateDefinition) Variables() []string {
+	var names []string
+	seen := map[string]bool{}
+	add := func(variables ...string) {
+		for _, name := range variables {
+			if !seen[name] {
+				seen[name] = true
+				names = append(names, name)
+			}
+		}
+	}
+	if d.header != nil {
+		add(d.header.variables...)
+	}
+	add(d.bodyVariables...)
+	for _, button := range d.buttons {
+		add(button.variable)
+	}
+	return names
+}
+
+// Message builds a message from the values in vars, which is either a map[string]string or a struct, or pointer
+// to a struct, with a field per variable. Fields are matched by their `template:"name"` tag, or else by their name
+// ignoring case and underscores.
+func (d *TemplateDefinition) Message(from string, to string, vars interface{}) (models.TemplateMsg, error) {
+	values, err := variableValues(vars)
+	if err != nil {
+		return models.TemplateMsg{}, err
+	}
+	if err = d.checkVariables(func(name string) bool { _, ok := values[name]; return ok }); err != nil {
+		return models.TemplateMsg{}, err
+	}
+
+	data := models.TemplateData{Body: models.TemplateBody{Placeholders: []string{}}}
+	for _, name := range d.bodyVariables {
+		data.Body.Placeholders = append(data.Body.Placeholders, variableValue(values, name))
+	}
+	if d.hasHeaderData() {
+		if data.Header, err = d.headerData(values); err != nil {
+			return models.TemplateMsg{}, err
+		}
+	}
+	for _, button := range d.buttons {
+		data.Buttons = append(data.Buttons, models.TemplateMsgButton{
+			Type:      button.msgType,
+			Parameter: variableValue(values, button.variable),
+		})
+	}
+
+	msg := models.TemplateMsg{
+		MsgCommon: models.MsgCommon{From: from, To: to},
+		Content: models.TemplateMsgContent{
+			TemplateName: d.create.Name,
+			TemplateData: data,
+			Language:     d.create.Language,
+		},
+	}
+	msgs := models.WATemplateMsgs{Messages: []models.TemplateMsg{msg}}
+	if err = msgs.Validate(); err != nil {
+		return models.TemplateMsg{}, err
+	}
+	return msg, nil
+}
+
+// TemplateSender builds messages from variables of the struct type bound with Sender.
+type TemplateSender func(from string, to string, vars interface{}) (models.TemplateMsg, error)
+
+// Sender checks that the struct type of vars has a field for every variable of the template, and returns a
+// TemplateSender which only accepts values of that type.
+func (d *TemplateDefinition) Sender(vars interface{}) (TemplateSender, error) {
+	varsType := reflect.TypeOf(vars)
+	if varsType == nil || indirectType(varsType).Kind() != reflect.Struct {
+		return nil, fmt.Errorf("template variables must be a struct, got %T", vars)
+	}
+	fields := structFields(indirectType(varsType))
+	if err := d.checkVariables(func(name string) bool { _, ok := fields[name]; return ok }); err != nil {
+		return nil, err
+	}
+
+	return func(from string, to string, values interface{}) (models.TemplateMsg, error) {
+		if reflect.TypeOf(values) != varsType {
+			return models.TemplateMsg{}, fmt.Errorf("template variables must be of type %s, got %T", varsType, values)
+		}
+		return d.Message(from, to, values)
+	}, nil
+}
+
+func (d *TemplateDefinition) checkVariables(has func(name string) bool) error {
+	var missing []string
+	for _, name := range d.Variables() {
+		if !has(normalizeVariableName(name)) {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("missing template variables: %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+func (d *TemplateDefinition) hasHeaderData() bool {
+	return d.header != nil && len(d.header.variables) > 0
+}
+
+func (d *TemplateDefinition) headerData(values map[string]reflect.Value) (*models.TemplateMsgHeader, error) {
+	header := &models.TemplateMsgHeader{Type: d.header.format}
+	value := func(i int) string { return variableValue(values, d.header.variables[i]) }
+	switch d.header.format {
+	case "TEXT":
+		header.Placeholder = value(0)
+	case "IMAGE", "VIDEO":
+		header.MediaURL = value(0)
+	case "DOCUMENT":
+		header.MediaURL, header.Filename = value(0), value(1)
+	case "LOCATION":
+		latitude, err := coordinate(value(0))
+		if err != nil {
+			return nil, err
+		}
+		longitude, err := coordinate(value(1))
+		if err != nil {
+			return nil, err
+		}
+		header.Latitude, header.Longitude = &latitude, &longitude
+	}
+	return header, nil
+}
+
+func variableValue(values map[string]reflect.Value, name string) string {
+	return fmt.Sprint(values[normalizeVariableName(name)].Interface())
+}
+
+func coordinate(value string) (float32, error) {
+	parsed, err := strconv.ParseFloat(value, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid coordinate %q: %w", value, err)
+	}
+	return float32(parsed), nil
+}
+
+func variableNames(text string) []string {
+	var names []string
+	seen := map[string]bool{}
+	for _, match := range namedVariableRegexp.FindAllStringSubmatch(text, -1) {
+		if !seen[match[1]] {
+			seen[match[1]] = true
+			names = append(names, match[1])
+		}
+	}
+	return names
+}
+
+func endsWithVariable(text string) bool {
+	matches := namedVariableRegexp.FindAllStringIndex(text, -1)
+	return len(matches) > 0 && matches[len(matches)-1][1] == len(text)
+}
+
+func numberVariables(text string, names []string) string {
+	return namedVariableRegexp.ReplaceAllStringFunc(text, func(variable string) string {
+		name := namedVariableRegexp.FindStringSubmatch(variable)[1]
+		for i, n := range names {
+			if n == name {
+				return "{{" + strconv.Itoa(i+1) + "}}"
+			}
+		}
+		return variable
+	})
+}
+
+func variableValues(vars interface{}) (map[string]reflect.Value, error) {
+	fields := map[string]reflect.Value{}
+	if values, ok := vars.(map[string]string); ok {
+		for name, value := range values {
+			fields[normalizeVariableName(name)] = reflect.ValueOf(value)
+		}
+		return fields, nil
+	}
+
+	value := reflect.ValueOf(vars)
+	for value.Kind() == reflect.Ptr && !value.IsNil() {
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("template variables must be a struct or a map[string]string, got %T", vars)
+	}
+	for name, index := range structFields(value.Type()) {
+		fields[name] = value.FieldByIndex(index)
+	}
+	return fields, nil
+}
+
+// structFields returns the index of each exported field of the struct, by normalized variable name.
+func structFields(structType reflect.Type) map[string][]int {
+	fields := map[string][]int{}
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+		name := field.Tag.Get(variableTag)
+		if name == "" {
+			name = field.Name
+		}
+		fields[normalizeVariableName(name)] = field.Index
+	}
+	return fields
+}
+
+func indirectType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t
+}
+
+func normalizeVariableName(name string) string {
+	return strings.ToLower(strings.ReplaceAll(name, "_", ""))
+}
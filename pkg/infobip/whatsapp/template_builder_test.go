@@ -0,0 +1,189 @@
+package whatsapp
+
+import (
+	"testing"
+
+	"github.com/infobip-community/infobip-api-go-sdk/v3/pkg/infobip/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type orderShippedVars struct {
+	Name       string
+	OrderID    int    `template:"order_id"`
+	InvoiceURL string `template:"invoice_url"`
+	Invoice    string `template:"invoice_name"`
+}
+
+func orderShippedBuilder() *TemplateBuilder {
+	return NewTemplateBuilder("order_shipped", "en", "UTILITY").
+		DocumentHeader("invoice_url", "invoice_name").
+		Body("Hi {{name}}, order {{order_id}} is on its way. Thanks {{ name }}!").
+		Footer("Reply STOP to opt out").
+		URLButton("Track", "https://example.com/track/{{order_id}}").
+		PhoneNumberButton("Call us", "41793026727")
+}
+
+func TestTemplateBuilderBuild(t *testing.T) {
+	def, err := orderShippedBuilder().Build()
+	require.NoError(t, err)
+
+	assert.Equal(t, models.TemplateCreate{
+		Name:     "order_shipped",
+		Language: "en",
+		Category: "UTILITY",
+		Structure: models.TemplateStructure{
+			Header: &models.TemplateHeader{Format: "DOCUMENT"},
+			Body:   &models.TemplateStructureBody{Text: "Hi {{1}}, order {{2}} is on its way. Thanks {{1}}!"},
+			Footer: &models.TemplateStructureFooter{Text: "Reply STOP to opt out"},
+			Buttons: []models.TemplateButton{
+				{Type: "URL", Text: "Track", URL: "https://example.com/track/{{1}}"},
+				{Type: "PHONE_NUMBER", Text: "Call us", PhoneNumber: "41793026727"},
+			},
+			Type: "MEDIA",
+		},
+	}, def.TemplateCreate())
+	assert.Equal(t, []string{"invoice_url", "invoice_name", "name", "order_id"}, def.Variables())
+}
+
+func TestTemplateBuilderBuildErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		builder *TemplateBuilder
+	}{
+		{
+			name:    "invalid name",
+			builder: NewTemplateBuilder("Order Shipped", "en", "UTILITY").Body("Hi"),
+		},
+		{
+			name:    "invalid category",
+			builder: NewTemplateBuilder("order_shipped", "en", "SHIPPING").Body("Hi"),
+		},
+		{
+			name:    "variable in footer",
+			builder: NewTemplateBuilder("order_shipped", "en", "UTILITY").Body("Hi").Footer("Bye {{name}}"),
+		},
+		{
+			name: "two variables in text header",
+			builder: NewTemplateBuilder("order_shipped", "en", "UTILITY").
+				TextHeader("{{first}} {{last}}").Body("Hi"),
+		},
+		{
+			name: "variable in the middle of url",
+			builder: NewTemplateBuilder("order_shipped", "en", "UTILITY").
+				Body("Hi").URLButton("Track", "https://example.com/{{order_id}}/track"),
+		},
+		{
+			name: "quick reply mixed with url",
+			builder: NewTemplateBuilder("order_shipped", "en", "UTILITY").Body("Hi").
+				QuickReplyButton("Yes", "yes").URLButton("Track", "https://example.com/track"),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := tc.builder.Build()
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestTemplateDefinitionMessage(t *testing.T) {
+	def, err := orderShippedBuilder().Build()
+	require.NoError(t, err)
+
+	vars := orderShippedVars{
+		Name:       "John",
+		OrderID:    1234,
+		InvoiceURL: "https://example.com/invoice.pdf",
+		Invoice:    "invoice.pdf",
+	}
+	msg, err := def.Message("441134960000", "41793026727", vars)
+	require.NoError(t, err)
+
+	assert.Equal(t, models.TemplateMsg{
+		MsgCommon: models.MsgCommon{From: "441134960000", To: "41793026727"},
+		Content: models.TemplateMsgContent{
+			TemplateName: "order_shipped",
+			Language:     "en",
+			TemplateData: models.TemplateData{
+				Body: models.TemplateBody{Placeholders: []string{"John", "1234"}},
+				Header: &models.TemplateMsgHeader{
+					Type:     "DOCUMENT",
+					MediaURL: "https://example.com/invoice.pdf",
+					Filename: "invoice.pdf",
+				},
+				Buttons: []models.TemplateMsgButton{{Type: "URL", Parameter: "1234"}},
+			},
+		},
+	}, msg)
+
+	registered := models.CreateWATemplateResponse{
+		Name:      def.TemplateCreate().Name,
+		Language:  def.TemplateCreate().Language,
+		Status:    TemplateStatusApproved,
+		Structure: def.TemplateCreate().Structure,
+	}
+	assert.NoError(t, CheckTemplateMsg(registered, msg))
+
+	fromMap, err := def.Message("441134960000", "41793026727", map[string]string{
+		"name": "John", "order_id": "1234", "invoice_url": "https://example.com/invoice.pdf", "invoice_name": "invoice.pdf",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, msg, fromMap)
+
+	_, err = def.Message("441134960000", "41793026727", map[string]string{"name": "John"})
+	assert.EqualError(t, err, "missing template variables: invoice_url, invoice_name, order_id")
+}
+
+func TestTemplateDefinitionLocationAndQuickReplies(t *testing.T) {
+	def, err := NewTemplateBuilder("store_location", "en", "MARKETING").
+		LocationHeader("lat", "lng").
+		Body("Visit us today").
+		QuickReplyButton("Yes", "yes_payload").
+		QuickReplyButton("No", "no_payload").
+		Build()
+	require.NoError(t, err)
+
+	msg, err := def.Message("441134960000", "41793026727", map[string]string{
+		"lat": "45.8", "lng": "15.97", "yes_payload": "visit", "no_payload": "skip",
+	})
+	require.NoError(t, err)
+
+	data := msg.Content.TemplateData
+	assert.Equal(t, []string{}, data.Body.Placeholders)
+	assert.Equal(t, float32(45.8), *data.Header.Latitude)
+	assert.Equal(t, float32(15.97), *data.Header.Longitude)
+	assert.Equal(t, []models.TemplateMsgButton{
+		{Type: "QUICK_REPLY", Parameter: "visit"},
+		{Type: "QUICK_REPLY", Parameter: "skip"},
+	}, data.Buttons)
+
+	_, err = def.Message("441134960000", "41793026727", map[string]string{
+		"lat": "north", "lng": "15.97", "yes_payload": "visit", "no_payload": "skip",
+	})
+	assert.Error(t, err)
+}
+
+func TestTemplateDefinitionSender(t *testing.T) {
+	def, err := orderShippedBuilder().Build()
+	require.NoError(t, err)
+
+	send, err := def.Sender(orderShippedVars{})
+	require.NoError(t, err)
+
+	msg, err := send("441134960000", "41793026727", orderShippedVars{
+		Name: "John", OrderID: 1, InvoiceURL: "https://example.com/invoice.pdf", Invoice: "invoice.pdf",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "order_shipped", msg.Content.TemplateName)
+
+	_, err = send("441134960000", "41793026727", map[string]string{})
+	assert.Error(t, err)
+
+	_, err = def.Sender(struct{ Name string }{})
+	assert.EqualError(t, err, "missing template variables: invoice_url, invoice_name, order_id")
+
+	_, err = def.Sender("not a struct")
+	assert.Error(t, err)
+}
@@ -0,0 +1,234 @@
+package whatsapp
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/infobip-community/infobip-api-go-sdk/v3/pkg/infobip/models"
+)
+
+const (
+	TemplateStatusApproved = "APPROVED"
+	TemplateStatusRejected = "REJECTED"
+	TemplateStatusPending  = "PENDING"
+)
+
+// nolint: gochecknoglobals // compiled once, only read afterwards
+var templatePlaceholderRegexp = regexp.MustCompile(`\{\{\s*(\d+)\s*\}\}`)
+
+// TemplateRegistry caches the templates of each sender and checks template messages against them before sending,
+// so that mismatches are reported locally instead of by Meta after the message was sent.
+type TemplateRegistry struct {
+	client WhatsApp
+	ttl    time.Duration
+	now    func() time.Time
+
+	mu      sync.Mutex
+	senders map[string]senderTemplates
+}
+
+type senderTemplates struct {
+	templates []models.CreateWATemplateResponse
+	loadedAt  time.Time
+}
+
+// NewTemplateRegistry creates a registry which loads templates with client and keeps them for ttl.
+// Templates are cached until Refresh or Invalidate is called when ttl is zero.
+func NewTemplateRegistry(client WhatsApp, ttl time.Duration) *TemplateRegistry {
+	return &TemplateRegistry{client: client, ttl: ttl, now: time.Now, senders: map[string]senderTemplates{}}
+}
+
+// Templates returns the templates of the sender, loading them when they are not cached or have expired.
+func (r *TemplateRegistry) Templates(ctx context.Context, sender string) ([]models.CreateWATemplateResponse, error) {
+	r.mu.Lock()
+	cached, ok := r.senders[sender]
+	r.mu.Unlock()
+	if ok && (r.ttl == 0 || r.now().Sub(cached.loadedAt) < r.ttl) {
+		return cached.templates, nil
+	}
+	return r.Refresh(ctx, sender)
+}
+
+// Refresh loads the templates of the sender, replacing the cached ones.
+func (r *TemplateRegistry) Refresh(ctx context.Context, sender string) ([]models.CreateWATemplateResponse, error) {
+	resp, respDetails, err := r.client.GetTemplates(ctx, sender)
+	if err = models.CheckResponse(respDetails, err); err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.senders[sender] = senderTemplates{templates: resp.Templates, loadedAt: r.now()}
+	return resp.Templates, nil
+}
+
+// Invalidate drops the cached templates of the sender.
+func (r *TemplateRegistry) Invalidate(sender string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.senders, sender)
+}
+
+// Lookup returns the template of the sender with the given name and language, or a TemplateNotFoundError.
+func (r *TemplateRegistry) Lookup(
+	ctx context.Context, sender string, name string, language string,
+) (models.CreateWATemplateResponse, error) {
+	templates, err := r.Templates(ctx, sender)
+	if err != nil {
+		return models.CreateWATemplateResponse{}, err
+	}
+
+	var languages []string
+	for _, template := range templates {
+		if template.Name != name {
+			continue
+		}
+		if template.Language == language {
+			return template, nil
+		}
+		languages = append(languages, template.Language)
+	}
+	sort.Strings(languages)
+	return models.CreateWATemplateResponse{}, &TemplateNotFoundError{
+		Sender: sender, Name: name, Language: language, Languages: languages,
+	}
+}
+
+// Check looks up the template used by the message and checks the message against it.
+func (r *TemplateRegistry) Check(ctx context.Context, msg models.TemplateMsg) error {
+	template, err := r.Lookup(ctx, msg.From, msg.Content.TemplateName, msg.Content.Language)
+	if err != nil {
+		return err
+	}
+	return CheckTemplateMsg(template, msg)
+}
+
+// SendTemplate checks every message against its template and sends them only when all of them match.
+func (r *TemplateRegistry) SendTemplate(
+	ctx context.Context,
+	messages models.WATemplateMsgs,
+) (resp models.BulkWAMsgResponse, respDetails models.ResponseDetails, err error) {
+	for i, msg := range messages.Messages {
+		if err = r.Check(ctx, msg); err != nil {
+			return resp, respDetails, fmt.Errorf("messages[%d]: %w", i, err)
+		}
+	}
+	return r.client.SendTemplate(ctx, messages)
+}
+
+// CheckTemplateMsg checks that the message can be sent with the template: the template must be APPROVED in the
+// language of the message, and the message must supply one value for each body and header placeholder, a header of
+// the template format, and one parameter for each quick reply and dynamic URL button, in the template order.
+// All problems found are returned in a TemplateMismatchError.
+func CheckTemplateMsg(template models.CreateWATemplateResponse, msg models.TemplateMsg) error {
+	var problems []TemplateProblem
+	report := func(field string, format string, args ...interface{}) {
+		problems = append(problems, TemplateProblem{Field: field, Message: fmt.Sprintf(format, args...)})
+	}
+
+	content := msg.Content
+	if content.TemplateName != template.Name {
+		report("templateName", "expected %s, got %s", template.Name, content.TemplateName)
+	}
+	if content.Language != template.Language {
+		report("language", "expected %s, got %s", template.Language, content.Language)
+	}
+	if template.Status != TemplateStatusApproved {
+		report("templateName", "template status is %s, only %s templates can be sent",
+			template.Status, TemplateStatusApproved)
+	}
+
+	structure := template.Structure
+	var bodyText string
+	if structure.Body != nil {
+		bodyText = structure.Body.Text
+	}
+	if expected, got := countTemplatePlaceholders(bodyText), len(content.TemplateData.Body.Placeholders); expected != got {
+		report("templateData.body.placeholders", "template body has %d placeholders, got %d values", expected, got)
+	}
+
+	problems = append(problems, checkTemplateMsgHeader(structure.Header, content.TemplateData.Header)...)
+	problems = append(problems, checkTemplateMsgButtons(structure.Buttons, content.TemplateData.Buttons)...)
+
+	if len(problems) > 0 {
+		return &TemplateMismatchError{Name: template.Name, Language: template.Language, Problems: problems}
+	}
+	return nil
+}
+
+func checkTemplateMsgHeader(expected *models.TemplateHeader, header *models.TemplateMsgHeader) []TemplateProblem {
+	const field = "templateData.header"
+	switch {
+	case expected == nil && header == nil:
+		return nil
+	case expected == nil:
+		return []TemplateProblem{{Field: field, Message: "template has no header"}}
+	case expected.Format == "TEXT" && countTemplatePlaceholders(expected.Text) == 0:
+		if header != nil {
+			return []TemplateProblem{{Field: field, Message: "template header has no placeholder"}}
+		}
+		return nil
+	case header == nil:
+		return []TemplateProblem{{
+			Field:   field,
+			Message: fmt.Sprintf("template requires a header of format %s", expected.Format),
+		}}
+	case header.Type != expected.Format:
+		return []TemplateProblem{{
+			Field:   field + ".type",
+			Message: fmt.Sprintf("expected %s, got %s", expected.Format, header.Type),
+		}}
+	case expected.Format == "TEXT" && header.Placeholder == "":
+		return []TemplateProblem{{Field: field + ".placeholder", Message: "template header requires a placeholder value"}}
+	case expected.Format != "TEXT" && expected.Format != "LOCATION" && header.MediaURL == "":
+		return []TemplateProblem{{
+			Field:   field + ".mediaUrl",
+			Message: fmt.Sprintf("template header of format %s requires a media URL", expected.Format),
+		}}
+	}
+	return nil
+}
+
+func checkTemplateMsgButtons(expected []models.TemplateButton, buttons []models.TemplateMsgButton) []TemplateProblem {
+	var types []string
+	for _, button := range expected {
+		switch {
+		case button.Type == "QUICK_REPLY":
+			types = append(types, button.Type)
+		case button.Type == "URL" && countTemplatePlaceholders(button.URL) > 0:
+			types = append(types, button.Type)
+		}
+	}
+
+	if len(types) != len(buttons) {
+		return []TemplateProblem{{
+			Field: "templateData.buttons",
+			Message: fmt.Sprintf("template has %d buttons with parameters (%s), got %d",
+				len(types), strings.Join(types, ", "), len(buttons)),
+		}}
+	}
+
+	var problems []TemplateProblem
+	for i, button := range buttons {
+		if button.Type != types[i] {
+			problems = append(problems, TemplateProblem{
+				Field:   fmt.Sprintf("templateData.buttons[%d].type", i),
+				Message: fmt.Sprintf("expected %s, got %s", types[i], button.Type),
+			})
+		}
+	}
+	return problems
+}
+
+func countTemplatePlaceholders(text string) int {
+	seen := map[string]bool{}
+	for _, match := range templatePlaceholderRegexp.FindAllStringSubmatch(text, -1) {
+		seen[match[1]] = true
+	}
+	return len(seen)
+}
@@ -0,0 +1,272 @@
+package whatsapp
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/infobip-community/infobip-api-go-sdk/v3/internal"
+	"github.com/infobip-community/infobip-api-go-sdk/v3/pkg/infobip/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const registryTemplatesResp = `
+{
+  "templates": [
+	{
+	  "id": "111",
+	  "name": "order_shipped",
+	  "language": "en",
+	  "status": "APPROVED",
+	  "category": "UTILITY",
+	  "structure": {
+		"header": {"format": "IMAGE"},
+		"body": {"text": "Hi {{1}}, order {{2}} is on its way. Thanks {{1}}!"},
+		"buttons": [
+		  {"type": "URL", "text": "Track", "url": "https://example.com/track/{{1}}"},
+		  {"type": "PHONE_NUMBER", "text": "Call us", "phoneNumber": "41793026727"}
+		],
+		"type": "MEDIA"
+	  }
+	},
+	{
+	  "id": "222",
+	  "name": "order_shipped",
+	  "language": "de",
+	  "status": "PENDING",
+	  "category": "UTILITY",
+	  "structure": {"body": {"text": "Hallo {{1}}"}, "type": "TEXT"}
+	},
+	{
+	  "id": "333",
+	  "name": "welcome",
+	  "language": "en",
+	  "status": "APPROVED",
+	  "category": "MARKETING",
+	  "structure": {
+		"header": {"format": "TEXT", "text": "Welcome {{1}}"},
+		"body": {"text": "Glad to have you on board."},
+		"type": "TEXT"
+	  }
+	}
+  ]
+}`
+
+func newTestRegistry(t *testing.T, ttl time.Duration) (*TemplateRegistry, *int, *time.Time) {
+	calls := 0
+	serv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		_, err := w.Write([]byte(registryTemplatesResp))
+		assert.NoError(t, err)
+	}))
+	t.Cleanup(serv.Close)
+
+	channel := &Channel{ReqHandler: internal.HTTPHandler{
+		HTTPClient: http.Client{},
+		BaseURL:    serv.URL,
+		APIKey:     "secret",
+	}}
+	now := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	registry := NewTemplateRegistry(channel, ttl)
+	registry.now = func() time.Time { return now }
+	return registry, &calls, &now
+}
+
+func orderShippedMsg() models.TemplateMsg {
+	return models.TemplateMsg{
+		MsgCommon: models.MsgCommon{From: "441134960000", To: "41793026727"},
+		Content: models.TemplateMsgContent{
+			TemplateName: "order_shipped",
+			Language:     "en",
+			TemplateData: models.TemplateData{
+				Body:    models.TemplateBody{Placeholders: []string{"John", "1234"}},
+				Header:  &models.TemplateMsgHeader{Type: "IMAGE", MediaURL: "https://example.com/box.png"},
+				Buttons: []models.TemplateMsgButton{{Type: "URL", Parameter: "1234"}},
+			},
+		},
+	}
+}
+
+func TestTemplateRegistryCache(t *testing.T) {
+	registry, calls, now := newTestRegistry(t, time.Minute)
+	ctx := context.Background()
+
+	templates, err := registry.Templates(ctx, "441134960000")
+	require.NoError(t, err)
+	assert.Len(t, templates, 3)
+
+	_, err = registry.Templates(ctx, "441134960000")
+	require.NoError(t, err)
+	assert.Equal(t, 1, *calls)
+
+	*now = now.Add(2 * time.Minute)
+	_, err = registry.Templates(ctx, "441134960000")
+	require.NoError(t, err)
+	assert.Equal(t, 2, *calls)
+
+	registry.Invalidate("441134960000")
+	_, err = registry.Templates(ctx, "441134960000")
+	require.NoError(t, err)
+	assert.Equal(t, 3, *calls)
+}
+
+func TestTemplateRegistryLookup(t *testing.T) {
+	registry, _, _ := newTestRegistry(t, 0)
+	ctx := context.Background()
+
+	template, err := registry.Lookup(ctx, "441134960000", "order_shipped", "de")
+	require.NoError(t, err)
+	assert.Equal(t, "222", template.ID)
+
+	_, err = registry.Lookup(ctx, "441134960000", "order_shipped", "fr")
+	var notFound *TemplateNotFoundError
+	require.ErrorAs(t, err, &notFound)
+	assert.Equal(t, []string{"de", "en"}, notFound.Languages)
+
+	_, err = registry.Lookup(ctx, "441134960000", "unknown", "en")
+	require.ErrorAs(t, err, &notFound)
+	assert.Empty(t, notFound.Languages)
+}
+
+func TestTemplateRegistryCheck(t *testing.T) {
+	tests := []struct {
+		name     string
+		modify   func(msg *models.TemplateMsg)
+		problems []TemplateProblem
+	}{
+		{
+			name:   "matching message",
+			modify: func(msg *models.TemplateMsg) {},
+		},
+		{
+			name: "wrong placeholder count",
+			modify: func(msg *models.TemplateMsg) {
+				msg.Content.TemplateData.Body.Placeholders = []string{"John"}
+			},
+			problems: []TemplateProblem{{
+				Field:   "templateData.body.placeholders",
+				Message: "template body has 2 placeholders, got 1 values",
+			}},
+		},
+		{
+			name: "missing header",
+			modify: func(msg *models.TemplateMsg) {
+				msg.Content.TemplateData.Header = nil
+			},
+			problems: []TemplateProblem{{Field: "templateData.header", Message: "template requires a header of format IMAGE"}},
+		},
+		{
+			name: "wrong header format",
+			modify: func(msg *models.TemplateMsg) {
+				msg.Content.TemplateData.Header.Type = "VIDEO"
+			},
+			problems: []TemplateProblem{{Field: "templateData.header.type", Message: "expected IMAGE, got VIDEO"}},
+		},
+		{
+			name: "missing header media URL",
+			modify: func(msg *models.TemplateMsg) {
+				msg.Content.TemplateData.Header.MediaURL = ""
+			},
+			problems: []TemplateProblem{{
+				Field:   "templateData.header.mediaUrl",
+				Message: "template header of format IMAGE requires a media URL",
+			}},
+		},
+		{
+			name: "missing header placeholder",
+			modify: func(msg *models.TemplateMsg) {
+				msg.Content.TemplateName = "welcome"
+				msg.Content.TemplateData = models.TemplateData{Header: &models.TemplateMsgHeader{Type: "TEXT"}}
+			},
+			problems: []TemplateProblem{{
+				Field:   "templateData.header.placeholder",
+				Message: "template header requires a placeholder value",
+			}},
+		},
+		{
+			name: "header placeholder",
+			modify: func(msg *models.TemplateMsg) {
+				msg.Content.TemplateName = "welcome"
+				msg.Content.TemplateData = models.TemplateData{
+					Header: &models.TemplateMsgHeader{Type: "TEXT", Placeholder: "John"},
+				}
+			},
+		},
+		{
+			name: "wrong button type",
+			modify: func(msg *models.TemplateMsg) {
+				msg.Content.TemplateData.Buttons[0].Type = "QUICK_REPLY"
+			},
+			problems: []TemplateProblem{{Field: "templateData.buttons[0].type", Message: "expected URL, got QUICK_REPLY"}},
+		},
+		{
+			name: "missing button",
+			modify: func(msg *models.TemplateMsg) {
+				msg.Content.TemplateData.Buttons = nil
+			},
+			problems: []TemplateProblem{{
+				Field:   "templateData.buttons",
+				Message: "template has 1 buttons with parameters (URL), got 0",
+			}},
+		},
+		{
+			name: "template not approved",
+			modify: func(msg *models.TemplateMsg) {
+				msg.Content.Language = "de"
+				msg.Content.TemplateData = models.TemplateData{Body: models.TemplateBody{Placeholders: []string{"Hans"}}}
+			},
+			problems: []TemplateProblem{{
+				Field:   "templateName",
+				Message: "template status is PENDING, only APPROVED templates can be sent",
+			}},
+		},
+	}
+
+	registry, _, _ := newTestRegistry(t, 0)
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			msg := orderShippedMsg()
+			tc.modify(&msg)
+
+			err := registry.Check(context.Background(), msg)
+			if tc.problems == nil {
+				require.NoError(t, err)
+				return
+			}
+			var mismatch *TemplateMismatchError
+			require.ErrorAs(t, err, &mismatch)
+			assert.Equal(t, tc.problems, mismatch.Problems)
+		})
+	}
+}
+
+func TestTemplateRegistrySendTemplate(t *testing.T) {
+	registry, _, _ := newTestRegistry(t, 0)
+	msg := orderShippedMsg()
+	msg.Content.Language = "fr"
+
+	_, _, err := registry.SendTemplate(context.Background(), models.WATemplateMsgs{Messages: []models.TemplateMsg{msg}})
+	var notFound *TemplateNotFoundError
+	require.ErrorAs(t, err, &notFound)
+	assert.Equal(t, "fr", notFound.Language)
+}
+
+func TestTemplateRegistryAPIError(t *testing.T) {
+	serv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer serv.Close()
+	registry := NewTemplateRegistry(&Channel{ReqHandler: internal.HTTPHandler{
+		HTTPClient: http.Client{},
+		BaseURL:    serv.URL,
+		APIKey:     "secret",
+	}}, time.Minute)
+
+	_, err := registry.Templates(context.Background(), "441134960000")
+	var apiErr *models.APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusUnauthorized, apiErr.StatusCode)
+}
@@ -0,0 +1,415 @@
+package whatsapp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"strings"
+	"time"
+
+	"github.com/infobip-community/infobip-api-go-sdk/v3/pkg/infobip/models"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// TemplateStatusDeleted is the TemplateSyncResult status of deleted templates.
+	TemplateStatusDeleted = "DELETED"
+
+	defaultTemplatePollInterval = 30 * time.Second
+	defaultTemplateReviewWait   = 24 * time.Hour
+)
+
+type TemplateAction string
+
+const (
+	CreateTemplateAction TemplateAction = "CREATE"
+	EditTemplateAction   TemplateAction = "EDIT"
+	DeleteTemplateAction TemplateAction = "DELETE"
+)
+
+// TemplateChange is a single step of a TemplatePlan. Desired is nil for deletions and Current is nil for
+// creations. Deletions remove the template in every language, so Language is empty for them.
+type TemplateChange struct {
+	Action   TemplateAction
+	Name     string
+	Language string
+	Desired  *models.TemplateCreate
+	Current  *models.CreateWATemplateResponse
+}
+
+func (c TemplateChange) String() string {
+	switch c.Action {
+	case CreateTemplateAction:
+		return fmt.Sprintf("+ create %s (%s)", c.Name, c.Language)
+	case EditTemplateAction:
+		return fmt.Sprintf("~ edit %s (%s)", c.Name, c.Language)
+	case DeleteTemplateAction:
+		return fmt.Sprintf("- delete %s (all languages)", c.Name)
+	}
+	return fmt.Sprintf("? %s %s (%s)", c.Action, c.Name, c.Language)
+}
+
+// TemplatePlan lists the changes needed to bring the templates of a sender to the desired state.
+type TemplatePlan struct {
+	Sender  string
+	Changes []TemplateChange
+}
+
+// String renders the plan for a dry run, one change per line.
+func (p TemplatePlan) String() string {
+	if len(p.Changes) == 0 {
+		return fmt.Sprintf("templates of sender %s are up to date\n", p.Sender)
+	}
+	var out strings.Builder
+	fmt.Fprintf(&out, "templates of sender %s:\n", p.Sender)
+	for _, change := range p.Changes {
+		out.WriteString(change.String() + "\n")
+	}
+	return out.String()
+}
+
+// TemplateSyncResult is the outcome of a change. Status is the template status once Meta reviewed it, APPROVED or
+// REJECTED, or DELETED for deletions.
+type TemplateSyncResult struct {
+	Change         TemplateChange
+	Status         string
+	RejectedReason string
+}
+
+// TemplatesRejectedError is returned by Apply when Meta rejected at least one of the created or edited templates.
+type TemplatesRejectedError struct {
+	Rejected []TemplateSyncResult
+}
+
+func (e *TemplatesRejectedError) Error() string {
+	rejected := make([]string, 0, len(e.Rejected))
+	for _, result := range e.Rejected {
+		rejected = append(rejected, fmt.Sprintf("%s (%s): %s",
+			result.Change.Name, result.Change.Language, result.RejectedReason))
+	}
+	return "templates rejected: " + strings.Join(rejected, "; ")
+}
+
+// TemplateReviewTimeoutError is returned by Apply when Meta did not review every created and edited template within
+// the MaxReviewWait of the TemplateSync. The changes are applied, only their review is pending.
+type TemplateReviewTimeoutError struct {
+	Pending []TemplateSyncResult
+}
+
+func (e *TemplateReviewTimeoutError) Error() string {
+	pending := make([]string, 0, len(e.Pending))
+	for _, result := range e.Pending {
+		pending = append(pending, fmt.Sprintf("%s (%s)", result.Change.Name, result.Change.Language))
+	}
+	return "templates still pending review: " + strings.Join(pending, ", ")
+}
+
+// TemplateSyncConfig configures a TemplateSync.
+type TemplateSyncConfig struct {
+	// Sender is the WhatsApp sender number whose templates are synced.
+	Sender string
+	// Prune deletes the templates of the sender whose name is not in the desired set. The API deletes templates by
+	// name, so a pruned template is removed in every language, including languages the desired set does not cover.
+	Prune bool
+	// PollInterval is the time between checks of the review status of changed templates. Defaults to 30 seconds.
+	PollInterval time.Duration
+	// MaxReviewWait is the longest time Apply waits for the review of changed templates. Defaults to 24 hours.
+	MaxReviewWait time.Duration
+}
+
+// TemplateSync brings the templates of a sender in line with a desired set, usually kept in version control, and
+// waits for Meta to review the changed templates.
+type TemplateSync struct {
+	client WhatsApp
+	config TemplateSyncConfig
+}
+
+// NewTemplateSync returns a TemplateSync using client, which must also implement TemplateEditor for templates to
+// be edited, as Channel does.
+func NewTemplateSync(client WhatsApp, config TemplateSyncConfig) *TemplateSync {
+	if config.PollInterval <= 0 {
+		config.PollInterval = defaultTemplatePollInterval
+	}
+	if config.MaxReviewWait <= 0 {
+		config.MaxReviewWait = defaultTemplateReviewWait
+	}
+	return &TemplateSync{client: client, config: config}
+}
+
+// Plan compares the desired templates, identified by name and language, with the templates of the sender. Nothing
+// is changed, so the plan can be printed for a dry run.
+func (s *TemplateSync) Plan(ctx context.Context, desired []models.TemplateCreate) (TemplatePlan, error) {
+	plan := TemplatePlan{Sender: s.config.Sender}
+	desiredNames := map[string]bool{}
+	seen := map[string]bool{}
+	for i := range desired {
+		template := desired[i]
+		if err := template.Validate(); err != nil {
+			return plan, fmt.Errorf("template %s (%s): %w", template.Name, template.Language, err)
+		}
+		key := template.Name + "/" + template.Language
+		if seen[key] {
+			return plan, fmt.Errorf("template %s (%s) is defined more than once", template.Name, template.Language)
+		}
+		seen[key] = true
+		desiredNames[template.Name] = true
+	}
+
+	resp, respDetails, err := s.client.GetTemplates(ctx, s.config.Sender)
+	if err = models.CheckResponse(respDetails, err); err != nil {
+		return plan, err
+	}
+	current := map[string]*models.CreateWATemplateResponse{}
+	for i := range resp.Templates {
+		template := &resp.Templates[i]
+		current[template.Name+"/"+template.Language] = template
+	}
+
+	for i := range desired {
+		template := &desired[i]
+		existing, ok := current[template.Name+"/"+template.Language]
+		switch {
+		case !ok:
+			plan.Changes = append(plan.Changes, TemplateChange{
+				Action: CreateTemplateAction, Name: template.Name, Language: template.Language, Desired: template,
+			})
+		case !templateMatches(*template, *existing):
+			plan.Changes = append(plan.Changes, TemplateChange{
+				Action: EditTemplateAction, Name: template.Name, Language: template.Language,
+				Desired: template, Current: existing,
+			})
+		}
+	}
+
+	if s.config.Prune {
+		deleted := map[string]bool{}
+		for i := range resp.Templates {
+			template := &resp.Templates[i]
+			if desiredNames[template.Name] || deleted[template.Name] {
+				continue
+			}
+			deleted[template.Name] = true
+			plan.Changes = append(plan.Changes, TemplateChange{
+				Action: DeleteTemplateAction, Name: template.Name, Current: template,
+			})
+		}
+	}
+	return plan, nil
+}
+
+// Apply executes the plan, then waits until Meta approves or rejects every created and edited template. A
+// TemplatesRejectedError is returned along with the results when any template was rejected, and a
+// TemplateReviewTimeoutError when the review takes longer than MaxReviewWait.
+func (s *TemplateSync) Apply(ctx context.Context, plan TemplatePlan) ([]TemplateSyncResult, error) {
+	results := make([]TemplateSyncResult, len(plan.Changes))
+	for i, change := range plan.Changes {
+		results[i].Change = change
+		if err := s.applyChange(ctx, change); err != nil {
+			return results[:i], fmt.Errorf("%s: %w", change, err)
+		}
+		if change.Action == DeleteTemplateAction {
+			results[i].Status = TemplateStatusDeleted
+		}
+	}
+
+	if err := s.waitForReview(ctx, results); err != nil {
+		return results, err
+	}
+
+	var rejected []TemplateSyncResult
+	for _, result := range results {
+		if result.Status == TemplateStatusRejected {
+			rejected = append(rejected, result)
+		}
+	}
+	if len(rejected) > 0 {
+		return results, &TemplatesRejectedError{Rejected: rejected}
+	}
+	return results, nil
+}
+
+// Sync plans and applies the changes needed to reach the desired templates.
+func (s *TemplateSync) Sync(ctx context.Context, desired []models.TemplateCreate) ([]TemplateSyncResult, error) {
+	plan, err := s.Plan(ctx, desired)
+	if err != nil {
+		return nil, err
+	}
+	return s.Apply(ctx, plan)
+}
+
+func (s *TemplateSync) applyChange(ctx context.Context, change TemplateChange) error {
+	switch change.Action {
+	case CreateTemplateAction:
+		_, respDetails, err := s.client.CreateTemplate(ctx, s.config.Sender, *change.Desired)
+		return models.CheckResponse(respDetails, err)
+	case EditTemplateAction:
+		editor, ok := s.client.(TemplateEditor)
+		if !ok {
+			return fmt.Errorf("%w: %T does not implement TemplateEditor", ErrUnsupportedClient, s.client)
+		}
+		_, respDetails, err := editor.EditTemplate(ctx, s.config.Sender, change.Current.ID, models.TemplateEdit{
+			Category:  change.Desired.Category,
+			Structure: change.Desired.Structure,
+		})
+		return models.CheckResponse(respDetails, err)
+	case DeleteTemplateAction:
+		respDetails, err := s.client.DeleteTemplate(ctx, s.config.Sender, change.Name)
+		return models.CheckResponse(respDetails, err)
+	}
+	return fmt.Errorf("unknown template action %s", change.Action)
+}
+
+// waitForReview polls the templates until every created and edited template is approved or rejected. The status of
+// an edited template is only trusted once the edit is visible, as the first polls can still return the template
+// as it was, along with its previous status.
+func (s *TemplateSync) waitForReview(ctx context.Context, results []TemplateSyncResult) error {
+	deadline := time.Now().Add(s.config.MaxReviewWait)
+	for reviewPending(results) {
+		resp, respDetails, err := s.client.GetTemplates(ctx, s.config.Sender)
+		if err = models.CheckResponse(respDetails, err); err != nil {
+			return err
+		}
+		for i := range results {
+			if results[i].Status != "" {
+				continue
+			}
+			for _, template := range resp.Templates {
+				if template.Name != results[i].Change.Name || template.Language != results[i].Change.Language {
+					continue
+				}
+				if reviewed(results[i].Change, template) {
+					results[i].Status = template.Status
+					results[i].RejectedReason = template.RejectedReason
+				}
+			}
+		}
+
+		if !reviewPending(results) {
+			return nil
+		}
+		if time.Now().Add(s.config.PollInterval).After(deadline) {
+			return &TemplateReviewTimeoutError{Pending: pendingResults(results)}
+		}
+		if err = sleepContext(ctx, s.config.PollInterval); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// reviewed reports whether the polled template holds the review of the change.
+func reviewed(change TemplateChange, template models.CreateWATemplateResponse) bool {
+	if template.Status != TemplateStatusApproved && template.Status != TemplateStatusRejected {
+		return false
+	}
+	if change.Action != EditTemplateAction {
+		return true
+	}
+	return template.Status != change.Current.Status || templateMatches(*change.Desired, template)
+}
+
+func pendingResults(results []TemplateSyncResult) []TemplateSyncResult {
+	var pending []TemplateSyncResult
+	for _, result := range results {
+		if result.Status == "" {
+			pending = append(pending, result)
+		}
+	}
+	return pending
+}
+
+func reviewPending(results []TemplateSyncResult) bool {
+	for _, result := range results {
+		if result.Status == "" {
+			return true
+		}
+	}
+	return false
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// templateMatches compares the fields set by the sync, ignoring those only filled in by the API.
+func templateMatches(desired models.TemplateCreate, current models.CreateWATemplateResponse) bool {
+	want, got := desired.Structure, current.Structure
+	if desired.Category != current.Category || want.Type != got.Type || len(want.Buttons) != len(got.Buttons) {
+		return false
+	}
+	if (want.Header == nil) != (got.Header == nil) ||
+		want.Header != nil && (want.Header.Format != got.Header.Format || want.Header.Text != got.Header.Text) {
+		return false
+	}
+	if (want.Body == nil) != (got.Body == nil) || want.Body != nil && want.Body.Text != got.Body.Text {
+		return false
+	}
+	if (want.Footer == nil) != (got.Footer == nil) || want.Footer != nil && want.Footer.Text != got.Footer.Text {
+		return false
+	}
+	for i, button := range want.Buttons {
+		other := got.Buttons[i]
+		if button.Type != other.Type || button.Text != other.Text || button.URL != other.URL ||
+			button.PhoneNumber != other.PhoneNumber {
+			return false
+		}
+	}
+	return true
+}
+
+// ParseTemplates reads templates from YAML or JSON. The document holds either a single template or a list of
+// templates, with the same field names as the JSON payload of CreateTemplate.
+func ParseTemplates(data []byte) ([]models.TemplateCreate, error) {
+	var document interface{}
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		return nil, err
+	}
+	if document == nil {
+		return nil, nil
+	}
+	if _, ok := document.([]interface{}); !ok {
+		document = []interface{}{document}
+	}
+
+	// Converting through JSON reuses the json tags of the models instead of duplicating them as yaml tags.
+	raw, err := json.Marshal(document)
+	if err != nil {
+		return nil, err
+	}
+	var templates []models.TemplateCreate
+	if err = json.Unmarshal(raw, &templates); err != nil {
+		return nil, err
+	}
+	return templates, nil
+}
+
+// LoadTemplates parses the templates of every file in fsys matching the pattern, e.g. os.DirFS("templates") and
+// "*.yaml", or an embed.FS.
+func LoadTemplates(fsys fs.FS, pattern string) ([]models.TemplateCreate, error) {
+	paths, err := fs.Glob(fsys, pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	var templates []models.TemplateCreate
+	for _, path := range paths {
+		data, readErr := fs.ReadFile(fsys, path)
+		if readErr != nil {
+			return nil, readErr
+		}
+		parsed, parseErr := ParseTemplates(data)
+		if parseErr != nil {
+			return nil, fmt.Errorf("%s: %w", path, parseErr)
+		}
+		templates = append(templates, parsed...)
+	}
+	return templates, nil
+}
@@ -0,0 +1,364 @@
+package whatsapp
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/infobip-community/infobip-api-go-sdk/v3/internal"
+	"github.com/infobip-community/infobip-api-go-sdk/v3/pkg/infobip/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeTemplateServer keeps the templates of one sender. Created and edited templates are PENDING until the next
+// GetTemplates call, which approves them, or rejects them when their body contains "reject". With staleGets, that
+// many GetTemplates calls following an edit still return the templates as they were before it. With holdReview,
+// templates stay PENDING.
+type fakeTemplateServer struct {
+	templates  []models.CreateWATemplateResponse
+	calls      []string
+	staleGets  int
+	beforeEdit []models.CreateWATemplateResponse
+	holdReview bool
+}
+
+func (f *fakeTemplateServer) handler(t *testing.T) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		f.calls = append(f.calls, r.Method+" "+r.URL.Path)
+		switch r.Method {
+		case http.MethodGet:
+			if f.beforeEdit != nil && f.staleGets > 0 {
+				f.staleGets--
+				resp := models.GetWATemplatesResponse{Templates: f.beforeEdit}
+				assert.NoError(t, json.NewEncoder(w).Encode(resp))
+				return
+			}
+			resp := models.GetWATemplatesResponse{Templates: append([]models.CreateWATemplateResponse{}, f.templates...)}
+			assert.NoError(t, json.NewEncoder(w).Encode(resp))
+			f.review()
+		case http.MethodPost:
+			var create models.TemplateCreate
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&create))
+			f.templates = append(f.templates, models.CreateWATemplateResponse{
+				ID: create.Name + "-" + create.Language, Name: create.Name, Language: create.Language,
+				Status: TemplateStatusPending, Category: create.Category, Structure: create.Structure,
+			})
+			w.WriteHeader(http.StatusCreated)
+			assert.NoError(t, json.NewEncoder(w).Encode(f.templates[len(f.templates)-1]))
+		case http.MethodPatch:
+			var edit models.TemplateEdit
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&edit))
+			id := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+			f.beforeEdit = append([]models.CreateWATemplateResponse{}, f.templates...)
+			for i := range f.templates {
+				if f.templates[i].ID == id {
+					f.templates[i].Category = edit.Category
+					f.templates[i].Structure = edit.Structure
+					f.templates[i].Status = TemplateStatusPending
+					assert.NoError(t, json.NewEncoder(w).Encode(f.templates[i]))
+				}
+			}
+		case http.MethodDelete:
+			name := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+			var kept []models.CreateWATemplateResponse
+			for _, template := range f.templates {
+				if template.Name != name {
+					kept = append(kept, template)
+				}
+			}
+			f.templates = kept
+		}
+	}
+}
+
+func (f *fakeTemplateServer) review() {
+	if f.holdReview {
+		return
+	}
+	for i := range f.templates {
+		if f.templates[i].Status != TemplateStatusPending {
+			continue
+		}
+		f.templates[i].Status = TemplateStatusApproved
+		if strings.Contains(f.templates[i].Structure.Body.Text, "reject") {
+			f.templates[i].Status = TemplateStatusRejected
+			f.templates[i].RejectedReason = "INVALID_FORMAT"
+		}
+	}
+}
+
+func newTestTemplateSync(t *testing.T, f *fakeTemplateServer, prune bool) *TemplateSync {
+	serv := httptest.NewServer(f.handler(t))
+	t.Cleanup(serv.Close)
+	channel := &Channel{ReqHandler: internal.HTTPHandler{
+		HTTPClient: http.Client{},
+		BaseURL:    serv.URL,
+		APIKey:     "secret",
+	}}
+	return NewTemplateSync(channel, TemplateSyncConfig{
+		Sender: "441134960000", Prune: prune, PollInterval: time.Millisecond,
+	})
+}
+
+func textTemplate(name string, language string, body string) models.TemplateCreate {
+	return models.TemplateCreate{
+		Name:     name,
+		Language: language,
+		Category: "UTILITY",
+		Structure: models.TemplateStructure{
+			Body: &models.TemplateStructureBody{Text: body},
+			Type: "TEXT",
+		},
+	}
+}
+
+func existingTemplate(template models.TemplateCreate) models.CreateWATemplateResponse {
+	return models.CreateWATemplateResponse{
+		ID: template.Name + "-" + template.Language, Name: template.Name, Language: template.Language,
+		Status: TemplateStatusApproved, Category: template.Category, Structure: template.Structure,
+	}
+}
+
+func TestTemplateSyncPlan(t *testing.T) {
+	f := &fakeTemplateServer{templates: []models.CreateWATemplateResponse{
+		existingTemplate(textTemplate("welcome", "en", "Welcome {{1}}")),
+		existingTemplate(textTemplate("order_shipped", "en", "Order {{1}} shipped")),
+		existingTemplate(textTemplate("old_promo", "en", "Sale!")),
+		existingTemplate(textTemplate("old_promo", "de", "Angebot!")),
+	}}
+	sync := newTestTemplateSync(t, f, true)
+
+	plan, err := sync.Plan(context.Background(), []models.TemplateCreate{
+		textTemplate("welcome", "en", "Welcome {{1}}"),
+		textTemplate("order_shipped", "en", "Your order {{1}} has shipped"),
+		textTemplate("order_shipped", "de", "Bestellung {{1}} versandt"),
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "templates of sender 441134960000:\n"+
+		"~ edit order_shipped (en)\n"+
+		"+ create order_shipped (de)\n"+
+		"- delete old_promo (all languages)\n", plan.String())
+	assert.Equal(t, []string{"GET /whatsapp/2/senders/441134960000/templates"}, f.calls)
+}
+
+func TestTemplateSyncPlanErrors(t *testing.T) {
+	sync := newTestTemplateSync(t, &fakeTemplateServer{}, false)
+
+	_, err := sync.Plan(context.Background(), []models.TemplateCreate{textTemplate("Invalid Name", "en", "Hi")})
+	assert.Error(t, err)
+
+	_, err = sync.Plan(context.Background(), []models.TemplateCreate{
+		textTemplate("welcome", "en", "Hi"),
+		textTemplate("welcome", "en", "Hello"),
+	})
+	assert.EqualError(t, err, "template welcome (en) is defined more than once")
+}
+
+func TestTemplateSyncApply(t *testing.T) {
+	f := &fakeTemplateServer{templates: []models.CreateWATemplateResponse{
+		existingTemplate(textTemplate("order_shipped", "en", "Order {{1}} shipped")),
+		existingTemplate(textTemplate("old_promo", "en", "Sale!")),
+	}}
+	sync := newTestTemplateSync(t, f, true)
+
+	results, err := sync.Sync(context.Background(), []models.TemplateCreate{
+		textTemplate("order_shipped", "en", "Your order {{1}} has shipped"),
+		textTemplate("welcome", "en", "Welcome {{1}}"),
+	})
+	require.NoError(t, err)
+
+	require.Len(t, results, 3)
+	assert.Equal(t, EditTemplateAction, results[0].Change.Action)
+	assert.Equal(t, TemplateStatusApproved, results[0].Status)
+	assert.Equal(t, CreateTemplateAction, results[1].Change.Action)
+	assert.Equal(t, TemplateStatusApproved, results[1].Status)
+	assert.Equal(t, DeleteTemplateAction, results[2].Change.Action)
+	assert.Equal(t, TemplateStatusDeleted, results[2].Status)
+	assert.Contains(t, f.calls, "PATCH /whatsapp/2/senders/441134960000/templates/order_shipped-en")
+	assert.Contains(t, f.calls, "DELETE /whatsapp/2/senders/441134960000/templates/old_promo")
+
+	plan, err := sync.Plan(context.Background(), []models.TemplateCreate{
+		textTemplate("order_shipped", "en", "Your order {{1}} has shipped"),
+		textTemplate("welcome", "en", "Welcome {{1}}"),
+	})
+	require.NoError(t, err)
+	assert.Empty(t, plan.Changes)
+}
+
+func TestTemplateSyncApplyRejected(t *testing.T) {
+	f := &fakeTemplateServer{}
+	sync := newTestTemplateSync(t, f, false)
+
+	results, err := sync.Sync(context.Background(), []models.TemplateCreate{
+		textTemplate("welcome", "en", "Welcome {{1}}"),
+		textTemplate("promo", "en", "Please reject me"),
+	})
+
+	var rejectedErr *TemplatesRejectedError
+	require.ErrorAs(t, err, &rejectedErr)
+	require.Len(t, rejectedErr.Rejected, 1)
+	assert.Equal(t, "promo", rejectedErr.Rejected[0].Change.Name)
+	assert.Equal(t, "INVALID_FORMAT", rejectedErr.Rejected[0].RejectedReason)
+	assert.Equal(t, TemplateStatusApproved, results[0].Status)
+}
+
+func TestTemplateSyncApplyWaitsForEdit(t *testing.T) {
+	f := &fakeTemplateServer{
+		templates: []models.CreateWATemplateResponse{existingTemplate(textTemplate("promo", "en", "Sale!"))},
+		staleGets: 2,
+	}
+	sync := newTestTemplateSync(t, f, false)
+
+	results, err := sync.Sync(context.Background(), []models.TemplateCreate{
+		textTemplate("promo", "en", "Please reject me"),
+	})
+
+	var rejectedErr *TemplatesRejectedError
+	require.ErrorAs(t, err, &rejectedErr)
+	assert.Equal(t, TemplateStatusRejected, results[0].Status)
+	assert.Zero(t, f.staleGets)
+}
+
+func TestTemplateSyncApplyReviewTimeout(t *testing.T) {
+	f := &fakeTemplateServer{holdReview: true}
+	serv := httptest.NewServer(f.handler(t))
+	defer serv.Close()
+	sync := NewTemplateSync(&Channel{ReqHandler: internal.HTTPHandler{
+		HTTPClient: http.Client{},
+		BaseURL:    serv.URL,
+		APIKey:     "secret",
+	}}, TemplateSyncConfig{Sender: "441134960000", PollInterval: time.Millisecond, MaxReviewWait: 20 * time.Millisecond})
+
+	results, err := sync.Sync(context.Background(), []models.TemplateCreate{textTemplate("welcome", "en", "Hi")})
+
+	var timeoutErr *TemplateReviewTimeoutError
+	require.ErrorAs(t, err, &timeoutErr)
+	assert.EqualError(t, err, "templates still pending review: welcome (en)")
+	assert.Equal(t, results, timeoutErr.Pending)
+}
+
+func TestTemplateMatches(t *testing.T) {
+	desired := textTemplate("welcome", "en", "Welcome {{1}}")
+	current := existingTemplate(desired)
+	current.Status = TemplateStatusPending
+	current.RejectedReason = "NONE"
+	current.Structure.Buttons = []models.TemplateButton{}
+	assert.True(t, templateMatches(desired, current))
+
+	current.Structure.Footer = &models.TemplateStructureFooter{Text: "Reply STOP to opt out"}
+	assert.False(t, templateMatches(desired, current))
+
+	desired.Structure.Footer = &models.TemplateStructureFooter{Text: "Reply STOP to opt out"}
+	desired.Structure.Buttons = []models.TemplateButton{{Type: "QUICK_REPLY", Text: "Yes"}}
+	current.Structure.Buttons = []models.TemplateButton{{Type: "QUICK_REPLY", Text: "No"}}
+	assert.False(t, templateMatches(desired, current))
+
+	desired.Structure.Buttons = []models.TemplateButton{{Type: "URL", Text: "Track", URL: "https://example.com/{{1}}"}}
+	current.Structure.Buttons = []models.TemplateButton{{Type: "URL", Text: "Track", URL: "https://example.com/{{1}}"}}
+	assert.True(t, templateMatches(desired, current))
+
+	current.Structure.Buttons[0].URL = "https://example.org/{{1}}"
+	assert.False(t, templateMatches(desired, current))
+}
+
+func TestTemplateSyncApplyCanceled(t *testing.T) {
+	f := &fakeTemplateServer{}
+	serv := httptest.NewServer(f.handler(t))
+	defer serv.Close()
+	sync := NewTemplateSync(&Channel{ReqHandler: internal.HTTPHandler{
+		HTTPClient: http.Client{},
+		BaseURL:    serv.URL,
+		APIKey:     "secret",
+	}}, TemplateSyncConfig{Sender: "441134960000", PollInterval: time.Hour})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	desired := textTemplate("welcome", "en", "Welcome {{1}}")
+	results := []TemplateSyncResult{{Change: TemplateChange{
+		Action: CreateTemplateAction, Name: "welcome", Language: "en", Desired: &desired,
+	}}}
+
+	err := sync.waitForReview(ctx, results)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Empty(t, results[0].Status)
+}
+
+func TestParseTemplates(t *testing.T) {
+	yamlTemplates := `
+- name: welcome
+  language: en
+  category: UTILITY
+  structure:
+    body:
+      text: "Welcome {{1}}"
+    buttons:
+      - type: URL
+        text: Open
+        url: https://example.com
+    type: TEXT
+- name: welcome
+  language: de
+  category: UTILITY
+  structure:
+    body:
+      text: "Willkommen {{1}}"
+    type: TEXT
+`
+	templates, err := ParseTemplates([]byte(yamlTemplates))
+	require.NoError(t, err)
+	require.Len(t, templates, 2)
+	assert.Equal(t, "Welcome {{1}}", templates[0].Structure.Body.Text)
+	assert.Equal(t, []models.TemplateButton{{Type: "URL", Text: "Open", URL: "https://example.com"}},
+		templates[0].Structure.Buttons)
+
+	jsonTemplate := `{"name": "welcome", "language": "en", "category": "UTILITY",
+		"structure": {"body": {"text": "Welcome {{1}}"}, "type": "TEXT"}}`
+	templates, err = ParseTemplates([]byte(jsonTemplate))
+	require.NoError(t, err)
+	assert.Equal(t, []models.TemplateCreate{textTemplate("welcome", "en", "Welcome {{1}}")}, templates)
+
+	_, err = ParseTemplates([]byte("name: [unclosed"))
+	assert.Error(t, err)
+}
+
+func TestLoadTemplates(t *testing.T) {
+	fsys := fstest.MapFS{
+		"welcome.yaml": {Data: []byte("name: welcome\nlanguage: en\ncategory: UTILITY\n" +
+			"structure:\n  body:\n    text: Welcome\n  type: TEXT\n")},
+		"promo.json": {Data: []byte(`[{"name": "promo", "language": "en", "category": "MARKETING",
+			"structure": {"body": {"text": "Sale"}, "type": "TEXT"}}]`)},
+		"README.md": {Data: []byte("not a template")},
+	}
+
+	templates, err := LoadTemplates(fsys, "*.yaml")
+	require.NoError(t, err)
+	require.Len(t, templates, 1)
+	assert.Equal(t, "welcome", templates[0].Name)
+
+	templates, err = LoadTemplates(fsys, "*.json")
+	require.NoError(t, err)
+	require.Len(t, templates, 1)
+	assert.Equal(t, "MARKETING", templates[0].Category)
+}
+
+func TestTemplateSyncApplyWithoutTemplateEditor(t *testing.T) {
+	f := &fakeTemplateServer{templates: []models.CreateWATemplateResponse{
+		existingTemplate(textTemplate("order_shipped", "en", "Order {{1}} shipped")),
+	}}
+	sync := newTestTemplateSync(t, f, false)
+	sync.client = struct{ WhatsApp }{sync.client}
+
+	_, err := sync.Sync(context.Background(), []models.TemplateCreate{
+		textTemplate("order_shipped", "en", "Your order {{1}} has shipped"),
+	})
+
+	require.ErrorIs(t, err, ErrUnsupportedClient)
+	assert.NotContains(t, f.calls, "PATCH /whatsapp/2/senders/441134960000/templates/order_shipped-en")
+}
@@ -0,0 +1,97 @@
+package whatsapp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/infobip-community/infobip-api-go-sdk/v3/internal"
+	"github.com/infobip-community/infobip-api-go-sdk/v3/pkg/infobip/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateBusinessProfileValidReq(t *testing.T) {
+	apiKey := "secret"
+	sender := "441134960000"
+	profile := models.WABusinessProfile{About: "Open 9 to 5", Websites: []string{"https://www.example.com"}}
+
+	serv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPatch, r.Method)
+		assert.True(t, strings.HasSuffix(r.URL.Path, fmt.Sprintf(businessProfilePath, sender)))
+		assert.Equal(t, fmt.Sprintf("App %s", apiKey), r.Header.Get("Authorization"))
+		parsedBody, servErr := ioutil.ReadAll(r.Body)
+		assert.Nil(t, servErr)
+		assert.JSONEq(t, `{"about": "Open 9 to 5", "websites": ["https://www.example.com"]}`, string(parsedBody))
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer serv.Close()
+
+	whatsApp := Channel{ReqHandler: internal.HTTPHandler{
+		HTTPClient: http.Client{},
+		BaseURL:    serv.URL,
+		APIKey:     apiKey,
+	}}
+
+	respDetails, err := whatsApp.UpdateBusinessProfile(context.Background(), sender, profile)
+
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, respDetails.HTTPResponse.StatusCode)
+	assert.Equal(t, models.ErrorDetails{}, respDetails.ErrorResponse)
+}
+
+func TestUpdateBusinessProfileInvalidReq(t *testing.T) {
+	whatsApp := Channel{ReqHandler: internal.HTTPHandler{
+		HTTPClient: http.Client{},
+		BaseURL:    "https://something.api.infobip.com",
+		APIKey:     "secret",
+	}}
+
+	respDetails, err := whatsApp.UpdateBusinessProfile(
+		context.Background(), "441134960000", models.WABusinessProfile{Email: "not an email"})
+
+	require.NotNil(t, err)
+	assert.IsType(t, err, validator.ValidationErrors{})
+	assert.Equal(t, models.ResponseDetails{}, respDetails)
+}
+
+func TestUpdateBusinessProfile4xxErrors(t *testing.T) {
+	rawJSONResp := []byte(`{
+		"requestError": {
+			"serviceException": {
+				"messageId": "BAD_REQUEST",
+				"text": "Bad request"
+			}
+		}
+	}`)
+	var expectedResp models.ErrorDetails
+	err := json.Unmarshal(rawJSONResp, &expectedResp)
+	require.NoError(t, err)
+
+	serv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, servErr := w.Write(rawJSONResp)
+		assert.Nil(t, servErr)
+	}))
+	defer serv.Close()
+
+	whatsApp := Channel{ReqHandler: internal.HTTPHandler{
+		HTTPClient: http.Client{},
+		BaseURL:    serv.URL,
+		APIKey:     "secret",
+	}}
+
+	respDetails, err := whatsApp.UpdateBusinessProfile(
+		context.Background(), "441134960000", models.WABusinessProfile{About: "Open 9 to 5"})
+
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, respDetails.HTTPResponse.StatusCode)
+	assert.Equal(t, expectedResp, respDetails.ErrorResponse)
+}
@@ -0,0 +1,169 @@
+package whatsapp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/infobip-community/infobip-api-go-sdk/v3/internal"
+	"github.com/infobip-community/infobip-api-go-sdk/v3/pkg/infobip/media"
+	"github.com/infobip-community/infobip-api-go-sdk/v3/pkg/infobip/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUploadMediaValidReq(t *testing.T) {
+	apiKey := "secret"
+	sender := "441134960000"
+	content := []byte("\x89PNG fake image content")
+	req := models.WAUploadMediaRequest{
+		MediaType:   models.WAMediaImage,
+		ContentType: "image/png",
+		Filename:    "logo.png",
+		Media:       bytes.NewReader(content),
+	}
+	rawJSONResp := []byte(`{"mediaId": "1234567890"}`)
+	var expectedResp models.WAUploadMediaResponse
+	err := json.Unmarshal(rawJSONResp, &expectedResp)
+	require.NoError(t, err)
+
+	serv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.True(t, strings.HasSuffix(r.URL.Path, fmt.Sprintf(uploadMediaPath, sender)))
+		assert.Equal(t, fmt.Sprintf("App %s", apiKey), r.Header.Get("Authorization"))
+
+		file, header, servErr := r.FormFile("media")
+		require.NoError(t, servErr)
+		defer file.Close()
+		assert.Equal(t, "logo.png", header.Filename)
+		assert.Equal(t, "image/png", header.Header.Get("Content-Type"))
+		received, servErr := ioutil.ReadAll(file)
+		assert.NoError(t, servErr)
+		assert.Equal(t, content, received)
+		assert.Equal(t, models.WAMediaImage, r.FormValue("mediaType"))
+
+		_, servErr = w.Write(rawJSONResp)
+		assert.Nil(t, servErr)
+	}))
+	defer serv.Close()
+
+	var whatsApp MediaStore = &Channel{ReqHandler: internal.HTTPHandler{
+		HTTPClient: http.Client{},
+		BaseURL:    serv.URL,
+		APIKey:     apiKey,
+	}}
+
+	resp, respDetails, err := whatsApp.UploadMedia(context.Background(), sender, req)
+
+	require.NoError(t, err)
+	assert.Equal(t, expectedResp, resp)
+	assert.Equal(t, http.StatusOK, respDetails.HTTPResponse.StatusCode)
+	assert.Equal(t, models.ErrorDetails{}, respDetails.ErrorResponse)
+}
+
+func TestUploadMediaInvalidReq(t *testing.T) {
+	whatsApp := Channel{ReqHandler: internal.HTTPHandler{
+		HTTPClient: http.Client{},
+		BaseURL:    "https://something.api.infobip.com",
+		APIKey:     "secret",
+	}}
+
+	resp, respDetails, err := whatsApp.UploadMedia(context.Background(), "441134960000", models.WAUploadMediaRequest{
+		MediaType:   models.WAMediaImage,
+		ContentType: "image/gif",
+		Filename:    "animation.gif",
+		Media:       strings.NewReader("GIF89a"),
+	})
+
+	require.NotNil(t, err)
+	assert.IsType(t, err, validator.ValidationErrors{})
+	assert.Equal(t, models.WAUploadMediaResponse{}, resp)
+	assert.Equal(t, models.ResponseDetails{}, respDetails)
+}
+
+func TestUploadMediaTooLarge(t *testing.T) {
+	whatsApp := Channel{ReqHandler: internal.HTTPHandler{
+		HTTPClient: http.Client{},
+		BaseURL:    "https://something.api.infobip.com",
+		APIKey:     "secret",
+	}}
+
+	_, _, err := whatsApp.UploadMedia(context.Background(), "441134960000", models.WAUploadMediaRequest{
+		MediaType:   models.WAMediaSticker,
+		ContentType: "image/webp",
+		Filename:    "sticker.webp",
+		Media:       bytes.NewReader(make([]byte, 600*1024)),
+	})
+
+	require.ErrorIs(t, err, models.ErrWAMediaTooLarge)
+}
+
+type jpegProcessor struct{}
+
+func (jpegProcessor) Supports(contentType string) bool {
+	return contentType == "image/png"
+}
+
+func (jpegProcessor) Process(_ context.Context, attachment models.Attachment, content []byte, _ int64) (
+	models.Attachment, models.MediaTransformation, error,
+) {
+	converted := []byte("\xff\xd8 fake jpeg content")
+	return models.NewBytesAttachment("logo.jpg", "image/jpeg", converted), models.MediaTransformation{
+		Name: attachment.Name, Processor: "jpeg", OriginalContentType: attachment.ContentType,
+		ContentType: "image/jpeg", OriginalSize: int64(len(content)), Size: int64(len(converted)),
+	}, nil
+}
+
+func TestUploadMediaFitsMedia(t *testing.T) {
+	var received []string
+	serv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		file, header, servErr := r.FormFile("media")
+		require.NoError(t, servErr)
+		defer file.Close()
+		content, servErr := ioutil.ReadAll(file)
+		assert.NoError(t, servErr)
+		received = append(received, header.Filename, header.Header.Get("Content-Type"), string(content))
+		_, servErr = w.Write([]byte(`{"mediaId": "1234567890"}`))
+		assert.Nil(t, servErr)
+	}))
+	defer serv.Close()
+	whatsApp := Channel{
+		ReqHandler:    internal.HTTPHandler{HTTPClient: http.Client{}, BaseURL: serv.URL, APIKey: "secret"},
+		MediaPipeline: media.NewPipeline(jpegProcessor{}),
+	}
+	original := bytes.Repeat([]byte("x"), 6*1024*1024)
+
+	resp, _, err := whatsApp.UploadMedia(context.Background(), "441134960000", models.WAUploadMediaRequest{
+		MediaType:   models.WAMediaImage,
+		ContentType: "image/png",
+		Filename:    "logo.png",
+		Media:       bytes.NewReader(original),
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "1234567890", resp.MediaID)
+	assert.Equal(t, []string{"logo.jpg", "image/jpeg", "\xff\xd8 fake jpeg content"}, received)
+	assert.Equal(t, &models.MediaTransformation{
+		Name: "logo.png", Processor: "jpeg", OriginalContentType: "image/png", ContentType: "image/jpeg",
+		OriginalSize: int64(len(original)), Size: 20,
+	}, resp.MediaTransformation)
+
+	received = nil
+	resp, _, err = whatsApp.UploadMedia(context.Background(), "441134960000", models.WAUploadMediaRequest{
+		MediaType:   models.WAMediaImage,
+		ContentType: "image/png",
+		Filename:    "logo.png",
+		Media:       strings.NewReader("small"),
+	})
+
+	require.NoError(t, err)
+	assert.Nil(t, resp.MediaTransformation)
+	assert.Equal(t, []string{"logo.png", "image/png", "small"}, received)
+}
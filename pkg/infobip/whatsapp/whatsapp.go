@@ -1,10 +1,16 @@
 package whatsapp
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
 
 	"github.com/infobip-community/infobip-api-go-sdk/v3/internal"
+	"github.com/infobip-community/infobip-api-go-sdk/v3/pkg/infobip/media"
 	"github.com/infobip-community/infobip-api-go-sdk/v3/pkg/infobip/models"
 )
 
@@ -35,8 +41,58 @@ type WhatsApp interface {
 	) (models.ResponseDetails, error)
 }
 
+// SenderManagement lists the senders of the account and manages their quality, business profiles and customer
+// identities. Channel implements it, e.g. client.WhatsApp.(whatsapp.SenderManagement).
+type SenderManagement interface {
+	GetSenders(context.Context, models.GetWASendersParams,
+	) (models.GetWASendersResponse, models.ResponseDetails, error)
+	GetSenderQuality(context.Context, []string) (models.GetWASenderQualityResponse, models.ResponseDetails, error)
+	GetBusinessProfile(context.Context, string) (models.WABusinessProfile, models.ResponseDetails, error)
+	UpdateBusinessProfile(context.Context, string, models.WABusinessProfile) (models.ResponseDetails, error)
+	GetIdentity(context.Context, string, string) (models.WAIdentity, models.ResponseDetails, error)
+	ConfirmIdentity(context.Context, string, string, models.WAIdentityConfirmation) (models.ResponseDetails, error)
+}
+
+// ConversationActions reacts to, marks as read and shows typing for received messages. Channel implements it;
+// SessionGuard passes it to the wrapped client.
+type ConversationActions interface {
+	SendReaction(context.Context, models.WAReactionMsg) (models.SendWAMsgResponse, models.ResponseDetails, error)
+	MarkAsRead(context.Context, string, string) (models.ResponseDetails, error)
+	SendTypingIndicator(context.Context, string, string) (models.ResponseDetails, error)
+}
+
+// InteractiveRequests sends interactive messages asking the customer for their location, to open a URL or to
+// complete a Flow. Channel and SessionGuard implement it.
+type InteractiveRequests interface {
+	SendInteractiveLocationRequest(context.Context, models.WAInteractiveLocationRequestMsg,
+	) (models.SendWAMsgResponse, models.ResponseDetails, error)
+	SendInteractiveURLButton(context.Context, models.WAInteractiveURLButtonMsg,
+	) (models.SendWAMsgResponse, models.ResponseDetails, error)
+	SendInteractiveFlow(context.Context, models.WAInteractiveFlowMsg,
+	) (models.SendWAMsgResponse, models.ResponseDetails, error)
+}
+
+// MediaStore uploads media to WhatsApp and reads uploaded and received media. Channel implements it; it is kept
+// apart from WhatsApp, whose method set stays unchanged.
+type MediaStore interface {
+	UploadMedia(context.Context, string, models.WAUploadMediaRequest,
+	) (models.WAUploadMediaResponse, models.ResponseDetails, error)
+	DownloadMedia(context.Context, string, string) (io.ReadCloser, models.ResponseDetails, error)
+	GetMediaMetadata(context.Context, string, string) (models.WAMediaMetadata, models.ResponseDetails, error)
+}
+
+// TemplateEditor edits existing templates. Channel implements it; it is kept out of WhatsApp so that other
+// implementations of WhatsApp, such as mocks, don't need to implement it.
+type TemplateEditor interface {
+	EditTemplate(context.Context, string, string, models.TemplateEdit,
+	) (models.CreateWATemplateResponse, models.ResponseDetails, error)
+}
+
 type Channel struct {
 	ReqHandler internal.HTTPHandler
+	// MediaPipeline, when set, fits uploaded media it supports to the WhatsApp limit of their media type. The applied
+	// transformation is recorded in WAUploadMediaResponse.MediaTransformation.
+	MediaPipeline *media.Pipeline
 }
 
 const (
@@ -49,12 +105,25 @@ const (
 	sendStickerPath                 = "whatsapp/1/message/sticker"
 	sendLocationPath                = "whatsapp/1/message/location"
 	sendContactPath                 = "whatsapp/1/message/contact"
+	sendReactionPath                = "whatsapp/1/message/reaction"
 	sendInteractiveButtonsPath      = "whatsapp/1/message/interactive/buttons"
 	sendInteractiveListPath         = "whatsapp/1/message/interactive/list"
 	sendInteractiveProductPath      = "whatsapp/1/message/interactive/product"
 	sendInteractiveMultiproductPath = "whatsapp/1/message/interactive/multi-product"
+	sendLocationRequestPath         = "whatsapp/1/message/interactive/location-request"
+	sendURLButtonPath               = "whatsapp/1/message/interactive/url-button"
+	sendFlowPath                    = "whatsapp/1/message/interactive/flow"
+	markAsReadPath                  = "whatsapp/1/senders/%s/message/%s/read"
+	typingIndicatorPath             = "whatsapp/1/senders/%s/message/%s/typing-indicator"
 	templatesPath                   = "whatsapp/2/senders/%s/templates"
+	editTemplatePath                = "whatsapp/2/senders/%s/templates/%s"
 	deleteTemplatePath              = "whatsapp/2/senders/%s/templates/%s"
+	uploadMediaPath                 = "whatsapp/1/senders/%s/media"
+	mediaPath                       = "whatsapp/1/senders/%s/media/%s"
+	sendersPath                     = "whatsapp/1/senders"
+	senderQualityPath               = "whatsapp/1/senders/quality"
+	businessProfilePath             = "whatsapp/1/senders/%s/business-info"
+	identityPath                    = "whatsapp/1/%s/contacts/%s/identity"
 )
 
 func (wap *Channel) SendTemplate(
@@ -129,6 +198,14 @@ func (wap *Channel) SendContact(
 	return msgResp, respDetails, err
 }
 
+func (wap *Channel) SendReaction(
+	ctx context.Context,
+	msg models.WAReactionMsg,
+) (msgResp models.SendWAMsgResponse, respDetails models.ResponseDetails, err error) {
+	respDetails, err = wap.ReqHandler.PostJSONReq(ctx, &msg, &msgResp, sendReactionPath)
+	return msgResp, respDetails, err
+}
+
 func (wap *Channel) SendInteractiveButtons(
 	ctx context.Context,
 	msg models.WAInteractiveButtonsMsg,
@@ -161,6 +238,53 @@ func (wap *Channel) SendInteractiveMultiproduct(
 	return msgResp, respDetails, err
 }
 
+func (wap *Channel) SendInteractiveLocationRequest(
+	ctx context.Context,
+	msg models.WAInteractiveLocationRequestMsg,
+) (msgResp models.SendWAMsgResponse, respDetails models.ResponseDetails, err error) {
+	respDetails, err = wap.ReqHandler.PostJSONReq(ctx, &msg, &msgResp, sendLocationRequestPath)
+	return msgResp, respDetails, err
+}
+
+func (wap *Channel) SendInteractiveURLButton(
+	ctx context.Context,
+	msg models.WAInteractiveURLButtonMsg,
+) (msgResp models.SendWAMsgResponse, respDetails models.ResponseDetails, err error) {
+	respDetails, err = wap.ReqHandler.PostJSONReq(ctx, &msg, &msgResp, sendURLButtonPath)
+	return msgResp, respDetails, err
+}
+
+func (wap *Channel) SendInteractiveFlow(
+	ctx context.Context,
+	msg models.WAInteractiveFlowMsg,
+) (msgResp models.SendWAMsgResponse, respDetails models.ResponseDetails, err error) {
+	respDetails, err = wap.ReqHandler.PostJSONReq(ctx, &msg, &msgResp, sendFlowPath)
+	return msgResp, respDetails, err
+}
+
+// MarkAsRead marks the inbound message with the ID, and the earlier messages of the conversation, as read.
+func (wap *Channel) MarkAsRead(
+	ctx context.Context,
+	sender string,
+	messageID string,
+) (respDetails models.ResponseDetails, err error) {
+	path := fmt.Sprintf(markAsReadPath, sender, url.PathEscape(messageID))
+	respDetails, err = wap.ReqHandler.PostNoBodyReq(ctx, nil, path)
+	return respDetails, err
+}
+
+// SendTypingIndicator marks the inbound message with the ID as read and shows a typing indicator to the user until
+// a reply is sent, or for up to 25 seconds.
+func (wap *Channel) SendTypingIndicator(
+	ctx context.Context,
+	sender string,
+	messageID string,
+) (respDetails models.ResponseDetails, err error) {
+	path := fmt.Sprintf(typingIndicatorPath, sender, url.PathEscape(messageID))
+	respDetails, err = wap.ReqHandler.PostNoBodyReq(ctx, nil, path)
+	return respDetails, err
+}
+
 func (wap *Channel) GetTemplates(
 	ctx context.Context,
 	sender string,
@@ -178,6 +302,17 @@ func (wap *Channel) CreateTemplate(
 	return resp, respDetails, err
 }
 
+func (wap *Channel) EditTemplate(
+	ctx context.Context,
+	sender string,
+	templateID string,
+	template models.TemplateEdit,
+) (resp models.CreateWATemplateResponse, respDetails models.ResponseDetails, err error) {
+	respDetails, err = wap.ReqHandler.PatchJSONReq(
+		ctx, &template, &resp, fmt.Sprintf(editTemplatePath, sender, templateID), nil)
+	return resp, respDetails, err
+}
+
 func (wap *Channel) DeleteTemplate(
 	ctx context.Context,
 	sender string,
@@ -186,3 +321,151 @@ func (wap *Channel) DeleteTemplate(
 	respDetails, err = wap.ReqHandler.DeleteRequest(ctx, fmt.Sprintf(deleteTemplatePath, sender, templateName), nil)
 	return respDetails, err
 }
+
+func (wap *Channel) UploadMedia(
+	ctx context.Context,
+	sender string,
+	req models.WAUploadMediaRequest,
+) (resp models.WAUploadMediaResponse, respDetails models.ResponseDetails, err error) {
+	if wap.MediaPipeline != nil {
+		req, resp.MediaTransformation, err = fitUploadMedia(ctx, wap.MediaPipeline, req)
+		if err != nil {
+			return resp, respDetails, err
+		}
+	}
+	respDetails, err = wap.ReqHandler.PostMultipartReq(ctx, &req, &resp, fmt.Sprintf(uploadMediaPath, sender))
+	return resp, respDetails, err
+}
+
+// fitUploadMedia reads the media of the request, when the pipeline supports its content type, and replaces it with
+// the media fitted to the limit of the media type.
+func fitUploadMedia(ctx context.Context, pipeline *media.Pipeline, req models.WAUploadMediaRequest) (
+	models.WAUploadMediaRequest, *models.MediaTransformation, error,
+) {
+	limit, ok := models.WAMediaLimitFor(req.MediaType)
+	source := models.Attachment{Name: req.Filename, ContentType: req.ContentType}
+	if !ok || req.Media == nil || !pipeline.Supports(media.ContentType(source)) {
+		return req, nil, nil
+	}
+	content, err := ioutil.ReadAll(req.Media)
+	if err != nil {
+		return req, nil, err
+	}
+	source.Open = func() (io.ReadCloser, error) {
+		return ioutil.NopCloser(bytes.NewReader(content)), nil
+	}
+	fitted, transformation, err := pipeline.FitContent(ctx, source, content, limit.MaxSize)
+	if err != nil {
+		return req, nil, err
+	}
+	if transformation == nil {
+		req.Media, req.Size = bytes.NewReader(content), int64(len(content))
+		return req, nil, nil
+	}
+
+	reader, err := fitted.Open()
+	if err != nil {
+		return req, nil, err
+	}
+	defer reader.Close()
+	if content, err = ioutil.ReadAll(reader); err != nil {
+		return req, nil, err
+	}
+	req.Media, req.Size = bytes.NewReader(content), int64(len(content))
+	req.ContentType, req.Filename = transformation.ContentType, fitted.Name
+	return req, transformation, nil
+}
+
+// DownloadMedia streams inbound media. The returned body must be closed by the caller, and is nil when the API
+// responds with an error.
+func (wap *Channel) DownloadMedia(
+	ctx context.Context,
+	sender string,
+	mediaID string,
+) (body io.ReadCloser, respDetails models.ResponseDetails, err error) {
+	return wap.ReqHandler.GetStreamRequest(ctx, fmt.Sprintf(mediaPath, sender, mediaID), nil)
+}
+
+func (wap *Channel) GetMediaMetadata(
+	ctx context.Context,
+	sender string,
+	mediaID string,
+) (resp models.WAMediaMetadata, respDetails models.ResponseDetails, err error) {
+	respDetails, err = wap.ReqHandler.HeadRequest(ctx, fmt.Sprintf(mediaPath, sender, mediaID), nil)
+	if err == nil && respDetails.HTTPResponse.StatusCode == http.StatusOK {
+		resp.ContentType = respDetails.HTTPResponse.Header.Get("Content-Type")
+		resp.ContentLength = respDetails.HTTPResponse.ContentLength
+	}
+	return resp, respDetails, err
+}
+
+func (wap *Channel) GetSenders(
+	ctx context.Context,
+	queryParams models.GetWASendersParams,
+) (resp models.GetWASendersResponse, respDetails models.ResponseDetails, err error) {
+	if err = queryParams.Validate(); err != nil {
+		return resp, respDetails, err
+	}
+	var params []internal.QueryParameter
+	if queryParams.Page != 0 {
+		params = append(params, internal.QueryParameter{Name: "page", Value: fmt.Sprint(queryParams.Page)})
+	}
+	if queryParams.Size != 0 {
+		params = append(params, internal.QueryParameter{Name: "size", Value: fmt.Sprint(queryParams.Size)})
+	}
+	respDetails, err = wap.ReqHandler.GetRequest(ctx, &resp, sendersPath, params)
+	return resp, respDetails, err
+}
+
+// GetSenderQuality returns the quality rating and messaging limit tier of each of the senders.
+func (wap *Channel) GetSenderQuality(
+	ctx context.Context,
+	senders []string,
+) (resp models.GetWASenderQualityResponse, respDetails models.ResponseDetails, err error) {
+	params := make([]internal.QueryParameter, 0, len(senders))
+	for _, sender := range senders {
+		params = append(params, internal.QueryParameter{Name: "senders", Value: sender})
+	}
+	respDetails, err = wap.ReqHandler.GetRequest(ctx, &resp, senderQualityPath, params)
+	return resp, respDetails, err
+}
+
+func (wap *Channel) GetBusinessProfile(
+	ctx context.Context,
+	sender string,
+) (resp models.WABusinessProfile, respDetails models.ResponseDetails, err error) {
+	respDetails, err = wap.ReqHandler.GetRequest(ctx, &resp, fmt.Sprintf(businessProfilePath, sender), nil)
+	return resp, respDetails, err
+}
+
+// UpdateBusinessProfile changes the fields set in the profile and keeps the others.
+func (wap *Channel) UpdateBusinessProfile(
+	ctx context.Context,
+	sender string,
+	profile models.WABusinessProfile,
+) (respDetails models.ResponseDetails, err error) {
+	respDetails, err = wap.ReqHandler.PatchJSONReq(ctx, &profile, nil, fmt.Sprintf(businessProfilePath, sender), nil)
+	return respDetails, err
+}
+
+// GetIdentity returns the current identity of the user. When the identity of a user changes, messages to them fail
+// until the new identity is confirmed with ConfirmIdentity.
+func (wap *Channel) GetIdentity(
+	ctx context.Context,
+	sender string,
+	userNumber string,
+) (resp models.WAIdentity, respDetails models.ResponseDetails, err error) {
+	respDetails, err = wap.ReqHandler.GetRequest(ctx, &resp, fmt.Sprintf(identityPath, sender, userNumber), nil)
+	return resp, respDetails, err
+}
+
+func (wap *Channel) ConfirmIdentity(
+	ctx context.Context,
+	sender string,
+	userNumber string,
+	confirmation models.WAIdentityConfirmation,
+) (respDetails models.ResponseDetails, err error) {
+	path := fmt.Sprintf(identityPath, sender, userNumber)
+	respDetails, err = wap.ReqHandler.PutJSONReq(ctx, &confirmation, nil, path, nil)
+	return respDetails, err
+}